@@ -0,0 +1,281 @@
+package archive
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	importChain = "import"
+
+	// chainFileProgressInterval is the interval between the progress logs of the export and the import
+	chainFileProgressInterval = 8 * time.Second
+
+	gzipExtension = ".gz"
+)
+
+var (
+	errChainFileInterrupted = errors.New("interrupted")
+)
+
+// chainFileStorage is the read access to the canonical chain needed to export it
+type chainFileStorage interface {
+	ReadHeadNumber() (uint64, bool)
+	ReadCanonicalHash(uint64) (types.Hash, bool)
+	ReadHeader(uint64, types.Hash) (*types.Header, error)
+	ReadBody(uint64, types.Hash) (*types.Body, error)
+}
+
+// chainFileBlockchain is the blockchain the blocks of a chain file are imported into
+type chainFileBlockchain interface {
+	Header() *types.Header
+	GetHashByNumber(uint64) types.Hash
+	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
+	WriteFullBlock(*types.FullBlock, string) error
+}
+
+// ImportResult reports the outcome of a chain file import
+type ImportResult struct {
+	// Skipped is the number of blocks of the file already present in the local chain
+	Skipped uint64
+	// Imported is the number of blocks executed and written to the local chain
+	Imported uint64
+	// Head is the head of the local chain after the import
+	Head uint64
+	// Interrupted is set if the import has been stopped by a termination signal before the end of the file
+	Interrupted bool
+}
+
+// CreateChainFile creates a new chain file at the given path, gzip compressed if the path ends with .gz.
+// The file must not exist already
+func CreateChainFile(path string) (io.WriteCloser, error) {
+	fs, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(path, gzipExtension) {
+		return fs, nil
+	}
+
+	return &gzipFileWriter{Writer: gzip.NewWriter(fs), file: fs}, nil
+}
+
+// OpenChainFile opens the chain file at the given path, decompressing it if the path ends with .gz
+func OpenChainFile(path string) (io.ReadCloser, error) {
+	fs, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(path, gzipExtension) {
+		return fs, nil
+	}
+
+	reader, err := gzip.NewReader(fs)
+	if err != nil {
+		_ = fs.Close()
+
+		return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+
+	return &gzipFileReader{Reader: reader, file: fs}, nil
+}
+
+// ExportChain writes the canonical blocks in the given range to the writer,
+// as the concatenation of their RLP encodings. The range is capped at the head of the chain.
+// Returns the number of the last written block
+func ExportChain(
+	db chainFileStorage,
+	writer io.Writer,
+	from uint64,
+	to *uint64,
+	logger hclog.Logger,
+) (uint64, error) {
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return 0, errors.New("unable to read the head of the chain")
+	}
+
+	last := head
+	if to != nil {
+		last = min(*to, head)
+	}
+
+	if from > last {
+		return 0, fmt.Errorf("the beginning of the range (%d) is above its end (%d)", from, last)
+	}
+
+	shutdownCh := common.GetTerminationSignalCh()
+	lastLog := time.Now()
+
+	for number := from; number <= last; number++ {
+		block, err := readCanonicalBlock(db, number)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := writer.Write(block.MarshalRLP()); err != nil {
+			return 0, fmt.Errorf("failed to write block %d: %w", number, err)
+		}
+
+		if time.Since(lastLog) >= chainFileProgressInterval {
+			logger.Info("exporting blocks", "number", number, "last", last)
+
+			lastLog = time.Now()
+		}
+
+		select {
+		case <-shutdownCh:
+			return 0, errChainFileInterrupted
+		default:
+		}
+	}
+
+	return last, nil
+}
+
+// ImportChain reads the RLP encoded blocks from the reader, then executes and writes them to the chain.
+// The blocks already present in the local chain are skipped, so an interrupted import
+// is resumed from the head of the chain by running it again with the same file
+func ImportChain(chain chainFileBlockchain, reader io.Reader, logger hclog.Logger) (*ImportResult, error) {
+	var (
+		stream     = newBlockStream(reader)
+		shutdownCh = common.GetTerminationSignalCh()
+		result     = &ImportResult{}
+		start      = time.Now()
+		lastLog    = start
+	)
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block: %w", err)
+		}
+
+		if block == nil {
+			break
+		}
+
+		head := chain.Header().Number
+
+		if block.Number() <= head {
+			if hash := chain.GetHashByNumber(block.Number()); hash != block.Hash() {
+				return nil, fmt.Errorf("block %d (%s) does not match the local chain (%s)",
+					block.Number(), block.Hash(), hash)
+			}
+
+			result.Skipped++
+
+			continue
+		}
+
+		if block.Number() != head+1 {
+			return nil, fmt.Errorf("block %d does not follow the head of the local chain (%d)", block.Number(), head)
+		}
+
+		fullBlock, err := chain.VerifyFinalizedBlock(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify block %d: %w", block.Number(), err)
+		}
+
+		if err := chain.WriteFullBlock(fullBlock, importChain); err != nil {
+			return nil, fmt.Errorf("failed to write block %d: %w", block.Number(), err)
+		}
+
+		result.Imported++
+
+		if time.Since(lastLog) >= chainFileProgressInterval {
+			logger.Info("importing blocks",
+				"number", block.Number(),
+				"imported", result.Imported,
+				"skipped", result.Skipped,
+				"elapsed", time.Since(start).Round(time.Second))
+
+			lastLog = time.Now()
+		}
+
+		select {
+		case <-shutdownCh:
+			result.Interrupted = true
+			result.Head = chain.Header().Number
+
+			return result, nil
+		default:
+		}
+	}
+
+	result.Head = chain.Header().Number
+
+	return result, nil
+}
+
+// readCanonicalBlock reads the canonical block with the given number from the storage
+func readCanonicalBlock(db chainFileStorage, number uint64) (*types.Block, error) {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := db.ReadHeader(number, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of block %d: %w", number, err)
+	}
+
+	// the genesis has no body
+	if number == 0 {
+		return &types.Block{Header: header}, nil
+	}
+
+	body, err := db.ReadBody(number, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body of block %d: %w", number, err)
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+		Uncles:       body.Uncles,
+	}, nil
+}
+
+// gzipFileWriter flushes the gzip stream before closing the underlying file
+type gzipFileWriter struct {
+	*gzip.Writer
+	file *os.File
+}
+
+func (w *gzipFileWriter) Close() error {
+	if err := w.Writer.Close(); err != nil {
+		_ = w.file.Close()
+
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// gzipFileReader closes the gzip stream along with the underlying file
+type gzipFileReader struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (r *gzipFileReader) Close() error {
+	if err := r.Reader.Close(); err != nil {
+		_ = r.file.Close()
+
+		return err
+	}
+
+	return r.file.Close()
+}
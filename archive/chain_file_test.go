@@ -0,0 +1,178 @@
+package archive
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockChainFileStorage serves the canonical blocks given in order, starting with genesis
+type mockChainFileStorage struct {
+	blocks []*types.Block
+}
+
+func (m *mockChainFileStorage) ReadHeadNumber() (uint64, bool) {
+	return uint64(len(m.blocks) - 1), len(m.blocks) > 0
+}
+
+func (m *mockChainFileStorage) ReadCanonicalHash(number uint64) (types.Hash, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return types.ZeroHash, false
+	}
+
+	return m.blocks[number].Hash(), true
+}
+
+func (m *mockChainFileStorage) ReadHeader(number uint64, _ types.Hash) (*types.Header, error) {
+	return m.blocks[number].Header, nil
+}
+
+func (m *mockChainFileStorage) ReadBody(number uint64, _ types.Hash) (*types.Body, error) {
+	return m.blocks[number].Body(), nil
+}
+
+// mockImportChain holds the canonical blocks given in order, starting with genesis
+type mockImportChain struct {
+	blocks []*types.Block
+}
+
+func (m *mockImportChain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockImportChain) GetHashByNumber(number uint64) types.Hash {
+	if number >= uint64(len(m.blocks)) {
+		return types.ZeroHash
+	}
+
+	return m.blocks[number].Hash()
+}
+
+func (m *mockImportChain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
+	return &types.FullBlock{Block: block}, nil
+}
+
+func (m *mockImportChain) WriteFullBlock(fullBlock *types.FullBlock, _ string) error {
+	m.blocks = append(m.blocks, fullBlock.Block)
+
+	return nil
+}
+
+func TestExportChain(t *testing.T) {
+	t.Parallel()
+
+	db := &mockChainFileStorage{blocks: []*types.Block{genesis, blocks[0], blocks[1], blocks[2]}}
+
+	t.Run("capped at head", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			buf bytes.Buffer
+			to  = uint64(10)
+		)
+
+		last, err := ExportChain(db, &buf, 1, &to, hclog.NewNullLogger())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), last)
+
+		var expected bytes.Buffer
+		for _, b := range blocks {
+			expected.Write(b.MarshalRLP())
+		}
+
+		assert.Equal(t, expected.Bytes(), buf.Bytes())
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		t.Parallel()
+
+		to := uint64(1)
+
+		_, err := ExportChain(db, io.Discard, 2, &to, hclog.NewNullLogger())
+		require.ErrorContains(t, err, "above its end")
+	})
+}
+
+func TestImportChain(t *testing.T) {
+	t.Parallel()
+
+	newStream := func(blocks ...*types.Block) io.Reader {
+		var buf bytes.Buffer
+		for _, b := range blocks {
+			buf.Write(b.MarshalRLP())
+		}
+
+		return &buf
+	}
+
+	t.Run("resume from head", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockImportChain{blocks: []*types.Block{genesis, blocks[0]}}
+
+		res, err := ImportChain(chain, newStream(genesis, blocks[0], blocks[1], blocks[2]), hclog.NewNullLogger())
+		require.NoError(t, err)
+
+		assert.Equal(t, &ImportResult{Skipped: 2, Imported: 2, Head: 3}, res)
+		assert.Equal(t, blocks[2].Hash(), chain.GetHashByNumber(3))
+	})
+
+	t.Run("mismatching block", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockImportChain{blocks: []*types.Block{genesis, blocks[0]}}
+
+		_, err := ImportChain(chain, newStream(genesis, blocks[2]), hclog.NewNullLogger())
+		require.ErrorContains(t, err, "block 3 does not follow the head of the local chain (1)")
+
+		other := &types.Block{Header: &types.Header{Number: 1, ExtraData: []byte{1}}}
+		other.Header.ComputeHash()
+
+		_, err = ImportChain(chain, newStream(genesis, other), hclog.NewNullLogger())
+		require.ErrorContains(t, err, "does not match the local chain")
+	})
+}
+
+func TestChainFile_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db := &mockChainFileStorage{blocks: []*types.Block{genesis, blocks[0], blocks[1], blocks[2]}}
+
+	for _, name := range []string{"chain.rlp", "chain.rlp.gz"} {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), name)
+
+			writer, err := CreateChainFile(path)
+			require.NoError(t, err)
+
+			_, err = ExportChain(db, writer, 0, nil, hclog.NewNullLogger())
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			_, err = CreateChainFile(path)
+			require.Error(t, err)
+
+			reader, err := OpenChainFile(path)
+			require.NoError(t, err)
+
+			defer reader.Close()
+
+			chain := &mockImportChain{blocks: []*types.Block{genesis}}
+
+			res, err := ImportChain(chain, reader, hclog.NewNullLogger())
+			require.NoError(t, err)
+
+			assert.Equal(t, &ImportResult{Skipped: 1, Imported: 3, Head: 3}, res)
+		})
+	}
+}
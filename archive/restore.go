@@ -224,7 +224,7 @@ func (b *blockStream) loadRLPArray() (uint64, error) {
 // loadRLPPrefix loads first byte of RLP encoded data from input
 func (b *blockStream) loadRLPPrefix() (byte, error) {
 	buf := b.buffer[:1]
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return 0, err
 	}
 
@@ -246,17 +246,16 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
-
-		if err != nil {
-			return 0, 0, err
-		}
-
+		n, err := io.ReadFull(b.input, payloadSizeBytes)
 		if uint64(n) < payloadSizeSize {
 			// couldn't load required amount of bytes
 			return 0, 0, io.EOF
 		}
 
+		if err != nil {
+			return 0, 0, err
+		}
+
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
 
 		return payloadSizeSize + 1, uint64(payloadSize), nil
@@ -270,7 +269,7 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -94,6 +95,7 @@ type Verifier interface {
 
 type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
+	HasState(root types.Hash) bool
 }
 
 type TxSigner interface {
@@ -448,6 +450,12 @@ func (b *Blockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error
 	return b.db.ReadReceipts(n, hash)
 }
 
+// HistoryTail returns the number of the first block whose body, receipts and transaction lookups
+// are kept, the history of the older blocks is pruned
+func (b *Blockchain) HistoryTail() uint64 {
+	return b.db.ReadHistoryTail()
+}
+
 // GetBodyByHash returns the body by their hash
 func (b *Blockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
 	return b.readBody(hash)
@@ -499,7 +507,9 @@ func (b *Blockchain) readBody(hash types.Hash) (*types.Body, bool) {
 
 	bb, err := b.db.ReadBody(n, hash)
 	if err != nil {
-		b.logger.Error("failed to read body", "err", err)
+		if !errors.Is(err, storagev2.ErrHistoryPruned) {
+			b.logger.Error("failed to read body", "err", err)
+		}
 
 		return nil, false
 	}
@@ -638,6 +648,79 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock,
 	return &types.FullBlock{Block: block, Receipts: receipts}, nil
 }
 
+// VerifyFinalizedBlockWithoutState verifies the finalized block, along with its receipts fetched from a peer,
+// without executing its transactions. It is used for the blocks whose parent state is not available locally
+// (e.g. the blocks below the state sync pivot), so the header and the roots of the block body are checked,
+// and the receipts are matched against the receipts root and the gas used of the header
+func (b *Blockchain) VerifyFinalizedBlockWithoutState(block *types.Block, receipts []*types.Receipt) error {
+	if block == nil {
+		return ErrNoBlock
+	}
+
+	// Make sure the consensus layer verifies this block header
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	if err := b.verifyBlockParent(block); err != nil {
+		return err
+	}
+
+	if err := b.verifyBlockRoots(block); err != nil {
+		return err
+	}
+
+	return b.verifyReceiptsWithoutState(block, receipts)
+}
+
+// verifyReceiptsWithoutState makes sure that the receipts match up to the block header, and fills in
+// their fields which are not covered by the receipts root, deriving them from the block transactions
+func (b *Blockchain) verifyReceiptsWithoutState(block *types.Block, receipts []*types.Receipt) error {
+	if len(receipts) != len(block.Transactions) {
+		return ErrInvalidReceiptsSize
+	}
+
+	if buildroot.CalculateReceiptsRoot(receipts) != block.Header.ReceiptsRoot {
+		return ErrInvalidReceiptsRoot
+	}
+
+	cumulativeGasUsed := uint64(0)
+
+	for i, receipt := range receipts {
+		tx := block.Transactions[i]
+
+		if receipt.CumulativeGasUsed < cumulativeGasUsed {
+			return ErrInvalidGasUsed
+		}
+
+		receipt.GasUsed = receipt.CumulativeGasUsed - cumulativeGasUsed
+		receipt.TxHash = tx.Hash()
+
+		cumulativeGasUsed = receipt.CumulativeGasUsed
+
+		// the address of the created contract depends on the sender
+		if tx.To() == nil {
+			sender := tx.From()
+
+			if sender == types.ZeroAddress {
+				var err error
+
+				if sender, err = b.txSigner.Sender(tx); err != nil {
+					return fmt.Errorf("failed to recover the sender of transaction %s: %w", tx.Hash(), err)
+				}
+			}
+
+			receipt.ContractAddress = crypto.CreateAddress(sender, tx.Nonce()).Ptr()
+		}
+	}
+
+	if cumulativeGasUsed != block.Header.GasUsed {
+		return ErrInvalidGasUsed
+	}
+
+	return nil
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
@@ -711,6 +794,27 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBlockRoots makes sure that the uncles and the transactions of the block
+// match up to the roots in its header
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -719,7 +823,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -730,21 +834,10 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
-	}
-
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+		return ErrInvalidTxRoot
 	}
 
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
-	}
-
-	return blockResult.Receipts, nil
+	return nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -1329,6 +1422,72 @@ func (b *Blockchain) GetForks() ([]types.Hash, error) {
 	return b.db.ReadForks()
 }
 
+// SetHead sets the head of the chain back to the canonical block with the given number,
+// removing the newer canonical blocks. The state of the block has to be available.
+// The removed blocks are reported as the old chain of a reorg event,
+// their transactions are returned so that they can be added back to the pool
+func (b *Blockchain) SetHead(number uint64) ([]*types.Transaction, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	currentHeader := b.Header()
+	if number > currentHeader.Number {
+		return nil, fmt.Errorf("block %d is above the current head %d", number, currentHeader.Number)
+	}
+
+	header, ok := b.GetHeaderByNumber(number)
+	if !ok {
+		return nil, fmt.Errorf("header of block %d not found", number)
+	}
+
+	if !b.executor.HasState(header.StateRoot) {
+		return nil, fmt.Errorf("%w: block %d, state root %s", state.ErrStateNotAvailable, number, header.StateRoot)
+	}
+
+	diff, ok := b.readTotalDifficulty(header.Hash)
+	if !ok {
+		return nil, fmt.Errorf("total difficulty of block %d not found", number)
+	}
+
+	var (
+		evnt = &Event{Type: EventReorg}
+		txs  []*types.Transaction
+	)
+
+	for bn := number + 1; bn <= currentHeader.Number; bn++ {
+		removed, ok := b.GetHeaderByNumber(bn)
+		if !ok {
+			continue
+		}
+
+		evnt.AddOldHeader(removed)
+
+		if body, ok := b.readBody(removed.Hash); ok {
+			txs = append(txs, body.Transactions...)
+		}
+	}
+
+	if err := b.db.Rewind(number); err != nil {
+		return nil, err
+	}
+
+	// the caches may still hold the removed blocks
+	b.headersCache.Purge()
+	b.difficultyCache.Purge()
+	b.receiptsCache.Purge()
+
+	b.setCurrentHeader(header, diff)
+
+	b.logger.Info("head set back", "number", header.Number, "hash", header.Hash,
+		"removed blocks", currentHeader.Number-number)
+
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(diff)
+	b.dispatchEvent(evnt)
+
+	return txs, nil
+}
+
 // GetBlockByHash returns the block using the block hash
 func (b *Blockchain) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	header, ok := b.readHeader(hash)
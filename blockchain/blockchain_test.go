@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/hashicorp/go-hclog"
@@ -26,6 +27,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 )
 
 const (
@@ -1653,6 +1655,155 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, r)
 }
 
+func TestBlockchain_VerifyReceiptsWithoutState(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("1")
+	to := types.StringToAddress("2")
+
+	newBlock := func() (*types.Block, []*types.Receipt) {
+		txs := []*types.Transaction{
+			types.NewTx(types.NewLegacyTx(types.WithNonce(0), types.WithFrom(sender), types.WithTo(&to))),
+			types.NewTx(types.NewLegacyTx(types.WithNonce(1), types.WithFrom(sender))),
+		}
+
+		receipts := []*types.Receipt{
+			{CumulativeGasUsed: 21000, Logs: []*types.Log{}},
+			{CumulativeGasUsed: 71000, Logs: []*types.Log{}},
+		}
+
+		return &types.Block{
+			Header: &types.Header{
+				GasUsed:      71000,
+				ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+			},
+			Transactions: txs,
+		}, receipts
+	}
+
+	t.Run("valid receipts", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock()
+
+		require.NoError(t, (&Blockchain{}).verifyReceiptsWithoutState(block, receipts))
+
+		// the derived fields are filled in
+		require.Equal(t, uint64(21000), receipts[0].GasUsed)
+		require.Equal(t, uint64(50000), receipts[1].GasUsed)
+		require.Equal(t, block.Transactions[1].Hash(), receipts[1].TxHash)
+		require.Nil(t, receipts[0].ContractAddress)
+		require.Equal(t, crypto.CreateAddress(sender, 1), *receipts[1].ContractAddress)
+	})
+
+	t.Run("missing receipts", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock()
+
+		require.ErrorIs(t, (&Blockchain{}).verifyReceiptsWithoutState(block, receipts[:1]), ErrInvalidReceiptsSize)
+		require.ErrorIs(t, (&Blockchain{}).verifyReceiptsWithoutState(block, nil), ErrInvalidReceiptsSize)
+	})
+
+	t.Run("tampered receipts", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock()
+		receipts[1].SetStatus(types.ReceiptFailed)
+
+		require.ErrorIs(t, (&Blockchain{}).verifyReceiptsWithoutState(block, receipts), ErrInvalidReceiptsRoot)
+	})
+
+	t.Run("gas used mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock()
+		block.Header.GasUsed = 50000
+
+		require.ErrorIs(t, (&Blockchain{}).verifyReceiptsWithoutState(block, receipts), ErrInvalidGasUsed)
+	})
+}
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+
+	t.Run("head set back", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		sub := b.SubscribeEvents()
+		defer b.UnsubscribeEvents(sub)
+
+		// the removed header is cached
+		_, ok := b.GetHeaderByHash(headers[7].Hash)
+		require.True(t, ok)
+
+		// a removed block with a transaction
+		tx := types.NewTx(types.NewLegacyTx(types.WithNonce(1)))
+		tx.ComputeHash()
+
+		w := b.db.NewWriter()
+		w.PutBody(headers[8].Number, headers[8].Hash, &types.Body{Transactions: []*types.Transaction{tx}})
+		require.NoError(t, w.WriteBatch())
+
+		removed, err := b.SetHead(5)
+		require.NoError(t, err)
+		require.Len(t, removed, 1)
+		require.Equal(t, tx.Hash(), removed[0].Hash())
+
+		require.Equal(t, headers[5].Hash, b.Header().Hash)
+
+		td, ok := b.GetTD(headers[5].Hash)
+		require.True(t, ok)
+		require.Equal(t, td, b.CurrentTD())
+
+		for _, h := range headers[6:] {
+			_, ok := b.GetHeaderByNumber(h.Number)
+			require.False(t, ok)
+
+			_, ok = b.GetHeaderByHash(h.Hash)
+			require.False(t, ok)
+		}
+
+		evnt := sub.GetEvent()
+		require.Equal(t, EventReorg, evnt.Type)
+		require.Equal(t, headers[5].Hash, evnt.Header().Hash)
+		require.Len(t, evnt.OldChain, 4)
+
+		// the chain continues from the new head
+		require.NoError(t, b.WriteHeadersWithBodies(AppendNewTestheadersWithSeed(headers[:6], 2, 1)[6:]))
+		require.Equal(t, uint64(7), b.Header().Number)
+	})
+
+	t.Run("block above head", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		_, err := b.SetHead(10)
+		require.ErrorContains(t, err, "above the current head")
+		require.Equal(t, uint64(9), b.Header().Number)
+	})
+
+	t.Run("state not available", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+		b.executor = &mockExecutor{
+			hasStateFn: func(types.Hash) bool {
+				return false
+			},
+		}
+
+		_, err := b.SetHead(5)
+		require.ErrorIs(t, err, state.ErrStateNotAvailable)
+		require.Equal(t, uint64(9), b.Header().Number)
+	})
+}
+
 func TestDiskUsageWriteBatchAndUpdate(t *testing.T) {
 	const (
 		checkInterval  = 100 * time.Millisecond
@@ -0,0 +1,156 @@
+package bloombits
+
+import (
+	"errors"
+)
+
+var (
+	errMissingData      = errors.New("missing bytes on input")
+	errUnreferencedData = errors.New("extra bytes on input")
+	errExceededTarget   = errors.New("target data size exceeded")
+	errZeroContent      = errors.New("zero byte in input content")
+)
+
+// compressBytes compresses the input byte slice according to the sparse bitset
+// representation algorithm. If the result is bigger than the original input,
+// no compression is done.
+//
+// The compressed form consists of a (recursively compressed) bitset marking
+// the non-zero bytes of the input, followed by the non-zero bytes themselves.
+// Bloom bit vectors are mostly zeros, so this gives a significant size reduction.
+func compressBytes(data []byte) []byte {
+	if out := bitsetEncodeBytes(data); len(out) < len(data) {
+		return out
+	}
+
+	cpy := make([]byte, len(data))
+	copy(cpy, data)
+
+	return cpy
+}
+
+// bitsetEncodeBytes compresses the input byte slice according to the sparse
+// bitset representation algorithm.
+func bitsetEncodeBytes(data []byte) []byte {
+	// Empty slices get compressed to nil
+	if len(data) == 0 {
+		return nil
+	}
+
+	// One byte slices compress to nil or retain the single byte
+	if len(data) == 1 {
+		if data[0] == 0 {
+			return nil
+		}
+
+		return data
+	}
+
+	// Calculate the bitset of set bytes, and gather the non-zero bytes
+	nonZeroBitset := make([]byte, (len(data)+7)/8)
+	nonZeroBytes := make([]byte, 0, len(data))
+
+	for i, b := range data {
+		if b != 0 {
+			nonZeroBytes = append(nonZeroBytes, b)
+			nonZeroBitset[i/8] |= 1 << byte(7-i%8)
+		}
+	}
+
+	if len(nonZeroBytes) == 0 {
+		return nil
+	}
+
+	return append(bitsetEncodeBytes(nonZeroBitset), nonZeroBytes...)
+}
+
+// decompressBytes decompresses data with a known target size. If the input
+// data matches the size of the target, it means no compression was done
+// in the first place.
+func decompressBytes(data []byte, target int) ([]byte, error) {
+	if len(data) > target {
+		return nil, errExceededTarget
+	}
+
+	if len(data) == target {
+		cpy := make([]byte, len(data))
+		copy(cpy, data)
+
+		return cpy, nil
+	}
+
+	return bitsetDecodeBytes(data, target)
+}
+
+// bitsetDecodeBytes decompresses data with a known target size.
+func bitsetDecodeBytes(data []byte, length int) ([]byte, error) {
+	res, size, err := bitsetDecodePartialBytes(data, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if size != len(data) {
+		return nil, errUnreferencedData
+	}
+
+	return res, nil
+}
+
+// bitsetDecodePartialBytes decompresses data with a known target size, but does
+// not enforce consuming all the input bytes. In addition to the decompressed
+// output, the function returns the length of compressed input data corresponding
+// to the output as the input slice may be longer.
+func bitsetDecodePartialBytes(data []byte, length int) ([]byte, int, error) {
+	// Sanity check 0 targets to avoid infinite recursion
+	if length == 0 {
+		return nil, 0, nil
+	}
+
+	// Handle the zero and single byte corner cases
+	decomp := make([]byte, length)
+
+	if length == 1 {
+		if len(data) == 0 {
+			return decomp, 0, nil
+		}
+
+		if data[0] == 0 {
+			return nil, 0, errZeroContent
+		}
+
+		decomp[0] = data[0]
+
+		return decomp, 1, nil
+	}
+
+	// Decompress the bitset of set bytes and distribute the non zero bytes
+	nonZeroBitset, ptr, err := bitsetDecodePartialBytes(data, (length+7)/8)
+	if err != nil {
+		return nil, ptr, err
+	}
+
+	for i := 0; i < 8*len(nonZeroBitset); i++ {
+		if nonZeroBitset[i/8]&(1<<byte(7-i%8)) == 0 {
+			continue
+		}
+
+		// Make sure we have enough data to push into the correct slot
+		if ptr >= len(data) {
+			return nil, 0, errMissingData
+		}
+
+		if i >= len(decomp) {
+			return nil, 0, errExceededTarget
+		}
+
+		// Make sure the data is valid and push into the slot
+		if data[ptr] == 0 {
+			return nil, 0, errZeroContent
+		}
+
+		decomp[i] = data[ptr]
+		ptr++
+	}
+
+	return decomp, ptr, nil
+}
@@ -0,0 +1,58 @@
+package bloombits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressBytes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", make([]byte, 512)},
+		{"sparse", append(append(make([]byte, 100), 0x1), make([]byte, 411)...)},
+		{"dense", func() []byte {
+			data := make([]byte, 512)
+			for i := range data {
+				data[i] = byte(i%255) + 1
+			}
+
+			return data
+		}()},
+		{"single byte", []byte{0x5}},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			compressed := compressBytes(c.data)
+			require.LessOrEqual(t, len(compressed), len(c.data))
+
+			decompressed, err := decompressBytes(compressed, len(c.data))
+			require.NoError(t, err)
+			require.Equal(t, c.data, decompressed)
+		})
+	}
+}
+
+func TestDecompressBytes_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := decompressBytes([]byte{0x1, 0x2, 0x3}, 2)
+	require.ErrorIs(t, err, errExceededTarget)
+
+	// bitset marks a non zero byte which is missing
+	_, err = decompressBytes([]byte{0x80}, 8)
+	require.ErrorIs(t, err, errMissingData)
+
+	// extra bytes which are not referenced by the bitset
+	_, err = decompressBytes([]byte{0x80, 0x1, 0x2}, 8)
+	require.ErrorIs(t, err, errUnreferencedData)
+}
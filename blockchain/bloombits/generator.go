@@ -0,0 +1,85 @@
+package bloombits
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// BloomBitLength is the number of bits in a single bloom filter
+const BloomBitLength = types.BloomByteLength * 8
+
+var (
+	errSectionSize        = errors.New("section size must be a multiple of 8")
+	errSectionOutOfBounds = errors.New("bloom index out of section bounds")
+	errSectionNotFull     = errors.New("section not fully generated")
+)
+
+// Generator takes a number of bloom filters and generates the rotated bloom bits
+// to be used for batched filtering. Instead of storing a bloom filter per block,
+// the generator produces one bit vector per bloom bit, where the n-th bit of the
+// vector is set if the bloom filter of the n-th block of the section has that bit set.
+type Generator struct {
+	blooms   [BloomBitLength][]byte // Rotated blooms for per-bit matching
+	sections uint64                 // Number of sections to batch together
+	nextSec  uint64                 // Next section to set when adding a bloom
+}
+
+// NewGenerator creates a rotated bloom generator that can iteratively fill a
+// batched bloom filter's bits.
+func NewGenerator(sections uint64) (*Generator, error) {
+	if sections%8 != 0 {
+		return nil, errSectionSize
+	}
+
+	b := &Generator{sections: sections}
+	for i := 0; i < BloomBitLength; i++ {
+		b.blooms[i] = make([]byte, sections/8)
+	}
+
+	return b, nil
+}
+
+// AddBloom takes a single bloom filter and sets the corresponding bit column
+// in memory accordingly. Blooms have to be added in order.
+func (b *Generator) AddBloom(index uint64, bloom types.Bloom) error {
+	// Make sure we're not adding more bloom filters than our capacity
+	if b.nextSec >= b.sections {
+		return errSectionOutOfBounds
+	}
+
+	if b.nextSec != index {
+		return errors.New("bloom filter with unexpected index")
+	}
+
+	// Rotate the bloom and insert into our collection
+	byteIndex := b.nextSec / 8
+	bitMask := byte(1) << byte(7-b.nextSec%8)
+
+	for i := 0; i < BloomBitLength; i++ {
+		bloomByteIndex := types.BloomByteLength - 1 - i/8
+		bloomBitMask := byte(1) << byte(i%8)
+
+		if (bloom[bloomByteIndex] & bloomBitMask) != 0 {
+			b.blooms[i][byteIndex] |= bitMask
+		}
+	}
+
+	b.nextSec++
+
+	return nil
+}
+
+// Bitset returns the bit vector belonging to the given bit index after all
+// blooms have been added.
+func (b *Generator) Bitset(idx uint) ([]byte, error) {
+	if b.nextSec != b.sections {
+		return nil, errSectionNotFull
+	}
+
+	if idx >= BloomBitLength {
+		return nil, errSectionOutOfBounds
+	}
+
+	return b.blooms[idx], nil
+}
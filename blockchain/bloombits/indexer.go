@@ -0,0 +1,321 @@
+package bloombits
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// DefaultSectionSize is the number of blocks covered by a single section of the bloom bits index
+const DefaultSectionSize = uint64(4096)
+
+// blockchainStore provides the blockchain methods required by the Indexer
+type blockchainStore interface {
+	// Header returns the current header of the chain
+	Header() *types.Header
+
+	// GetHeaderByNumber returns the canonical header with the given number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+
+	// GetReceiptsByHash returns the receipts of the block with the given hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// SubscribeEvents subscribes for chain head events
+	SubscribeEvents() blockchain.Subscription
+
+	// UnsubscribeEvents removes the chain head events subscription
+	UnsubscribeEvents(blockchain.Subscription)
+}
+
+// Indexer maintains the bloom bits index of the canonical chain. The chain is split into
+// sections of sectionSize blocks and, once a section is complete, a bit vector per bloom bit
+// is generated from the blocks of the section and persisted into the storage.
+type Indexer struct {
+	logger hclog.Logger
+
+	blockchain  blockchainStore
+	db          *storagev2.Storage
+	sectionSize uint64
+
+	// sections is the number of processed (persisted) sections
+	sections atomic.Uint64
+
+	// sectionsLock serializes the updates of the processed sections with their persistence,
+	// so a reorg can't be overwritten by a section processed concurrently
+	sectionsLock sync.Mutex
+
+	updateCh chan struct{}
+	closeCh  chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewIndexer creates a new bloom bits indexer on top of the given blockchain and storage
+func NewIndexer(
+	logger hclog.Logger,
+	blockchain blockchainStore,
+	db *storagev2.Storage,
+	sectionSize uint64,
+) (*Indexer, error) {
+	if sectionSize == 0 || sectionSize%8 != 0 {
+		return nil, errSectionSize
+	}
+
+	i := &Indexer{
+		logger:      logger.Named("bloombits"),
+		blockchain:  blockchain,
+		db:          db,
+		sectionSize: sectionSize,
+		updateCh:    make(chan struct{}, 1),
+		closeCh:     make(chan struct{}),
+	}
+
+	if sections, ok := db.ReadBloomSections(); ok {
+		i.sections.Store(sections)
+	}
+
+	return i, nil
+}
+
+// Start starts the background indexing of the chain
+func (i *Indexer) Start() {
+	subscription := i.blockchain.SubscribeEvents()
+
+	i.wg.Add(2)
+
+	go i.runEventLoop(subscription)
+	go i.runIndexing()
+
+	// index whatever is already present in the chain
+	i.notify()
+}
+
+// Close stops the indexer and waits for the background routines to finish
+func (i *Indexer) Close() {
+	close(i.closeCh)
+	i.wg.Wait()
+}
+
+// BloomStatus returns the section size and the number of indexed sections
+func (i *Indexer) BloomStatus() (uint64, uint64) {
+	return i.sectionSize, i.sections.Load()
+}
+
+// GetBloomBits returns the bit vector of the given bloom bit for the given (indexed) section
+func (i *Indexer) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	if section >= i.sections.Load() {
+		return nil, fmt.Errorf("section %d is not indexed", section)
+	}
+
+	data, ok := i.db.ReadBloomBits(bit, section)
+	if !ok {
+		return nil, fmt.Errorf("bloom bits not found for bit %d in section %d", bit, section)
+	}
+
+	return decompressBytes(data, int(i.sectionSize/8))
+}
+
+// runEventLoop listens for the blockchain events and triggers the indexing.
+// It has to consume the events promptly, so the actual work is done in a separate routine
+func (i *Indexer) runEventLoop(subscription blockchain.Subscription) {
+	defer i.wg.Done()
+	defer i.blockchain.UnsubscribeEvents(subscription)
+
+	eventCh := subscription.GetEventCh()
+
+	for {
+		select {
+		case <-i.closeCh:
+			return
+
+		case ev := <-eventCh:
+			if ev == nil {
+				continue
+			}
+
+			if ev.Type == blockchain.EventReorg {
+				i.handleReorg(ev)
+			}
+
+			i.notify()
+		}
+	}
+}
+
+// runIndexing processes the complete sections of the chain whenever it gets notified
+func (i *Indexer) runIndexing() {
+	defer i.wg.Done()
+
+	for {
+		select {
+		case <-i.closeCh:
+			return
+
+		case <-i.updateCh:
+			if err := i.processSections(); err != nil {
+				i.logger.Error("failed to index bloom bits", "err", err)
+			}
+		}
+	}
+}
+
+// notify signals the indexing routine without blocking
+func (i *Indexer) notify() {
+	select {
+	case i.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+// handleReorg discards the indexed sections that contain the blocks replaced by the reorg
+func (i *Indexer) handleReorg(ev *blockchain.Event) {
+	if len(ev.NewChain) == 0 {
+		return
+	}
+
+	lowest := ev.NewChain[0].Number
+	for _, header := range ev.NewChain {
+		if header.Number < lowest {
+			lowest = header.Number
+		}
+	}
+
+	section := lowest / i.sectionSize
+	if section >= i.sections.Load() {
+		return
+	}
+
+	i.logger.Info("reorg detected, discarding indexed sections", "from section", section)
+
+	i.sectionsLock.Lock()
+	defer i.sectionsLock.Unlock()
+
+	i.sections.Store(section)
+
+	if err := i.storeSections(section); err != nil {
+		i.logger.Error("failed to store bloom bits sections", "err", err)
+	}
+}
+
+// processSections indexes all the complete sections of the chain which are not yet indexed
+func (i *Indexer) processSections() error {
+	for {
+		select {
+		case <-i.closeCh:
+			return nil
+		default:
+		}
+
+		section := i.sections.Load()
+		lastBlock := (section+1)*i.sectionSize - 1
+
+		if i.blockchain.Header().Number < lastBlock {
+			return nil
+		}
+
+		if err := i.processSection(section); err != nil {
+			return err
+		}
+
+		// in case of a reorg in the meantime, the section is going to be processed again
+		indexed, err := i.completeSection(section)
+		if err != nil {
+			return err
+		}
+
+		if !indexed {
+			continue
+		}
+
+		i.logger.Debug("bloom bits section indexed", "section", section,
+			"from", section*i.sectionSize, "to", lastBlock)
+	}
+}
+
+// processSection generates and persists the bloom bits of the given section
+func (i *Indexer) processSection(section uint64) error {
+	gen, err := NewGenerator(i.sectionSize)
+	if err != nil {
+		return err
+	}
+
+	first := section * i.sectionSize
+
+	for idx := uint64(0); idx < i.sectionSize; idx++ {
+		header, ok := i.blockchain.GetHeaderByNumber(first + idx)
+		if !ok {
+			return fmt.Errorf("header %d not found", first+idx)
+		}
+
+		bloom, err := i.blockBloom(header)
+		if err != nil {
+			return err
+		}
+
+		if err := gen.AddBloom(idx, bloom); err != nil {
+			return err
+		}
+	}
+
+	writer := i.db.NewWriter()
+
+	for bit := uint(0); bit < BloomBitLength; bit++ {
+		bits, err := gen.Bitset(bit)
+		if err != nil {
+			return err
+		}
+
+		writer.PutBloomBits(bit, section, compressBytes(bits))
+	}
+
+	return writer.WriteBatch()
+}
+
+// completeSection marks the given processed section as indexed, unless the indexed sections
+// were discarded by a reorg in the meantime. Returns true if the section got marked as indexed
+func (i *Indexer) completeSection(section uint64) (bool, error) {
+	i.sectionsLock.Lock()
+	defer i.sectionsLock.Unlock()
+
+	if i.sections.Load() != section {
+		return false, nil
+	}
+
+	if err := i.storeSections(section + 1); err != nil {
+		return false, fmt.Errorf("failed to store bloom bits sections: %w", err)
+	}
+
+	i.sections.Store(section + 1)
+
+	return true, nil
+}
+
+// storeSections persists the number of indexed sections
+func (i *Indexer) storeSections(sections uint64) error {
+	writer := i.db.NewWriter()
+	writer.PutBloomSections(sections)
+
+	return writer.WriteBatch()
+}
+
+// blockBloom calculates the bloom of the block from its receipts.
+// The header bloom is not used because not every consensus engine populates it
+func (i *Indexer) blockBloom(header *types.Header) (types.Bloom, error) {
+	if header.TxRoot == types.EmptyRootHash {
+		return types.Bloom{}, nil
+	}
+
+	// missing receipts are an error rather than an empty bloom, otherwise the logs
+	// of the block would never be matched once the section is indexed
+	receipts, err := i.blockchain.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return types.Bloom{}, fmt.Errorf("failed to get receipts of block %d: %w", header.Number, err)
+	}
+
+	return types.CreateBloom(receipts), nil
+}
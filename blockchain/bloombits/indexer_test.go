@@ -0,0 +1,346 @@
+package bloombits
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockBlockchain struct {
+	headers      []*types.Header
+	receipts     map[types.Hash][]*types.Receipt
+	subscription *blockchain.MockSubscription
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.headers[len(m.headers)-1]
+}
+
+func (m *mockBlockchain) GetHeaderByNumber(num uint64) (*types.Header, bool) {
+	if num >= uint64(len(m.headers)) {
+		return nil, false
+	}
+
+	return m.headers[num], true
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	receipts, ok := m.receipts[hash]
+	if !ok {
+		return nil, storagev2.ErrNotFound
+	}
+
+	return receipts, nil
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.subscription
+}
+
+func (m *mockBlockchain) UnsubscribeEvents(blockchain.Subscription) {}
+
+func newMockBlockchain(numBlocks uint64, logAddrs map[uint64]types.Address) *mockBlockchain {
+	m := &mockBlockchain{
+		headers:      make([]*types.Header, numBlocks),
+		receipts:     make(map[types.Hash][]*types.Receipt),
+		subscription: blockchain.NewMockSubscription(),
+	}
+
+	for i := uint64(0); i < numBlocks; i++ {
+		header := &types.Header{Number: i, TxRoot: types.EmptyRootHash}
+
+		if addr, ok := logAddrs[i]; ok {
+			header.TxRoot = types.StringToHash("0x1")
+			header.ComputeHash()
+
+			m.receipts[header.Hash] = []*types.Receipt{
+				{Logs: []*types.Log{{Address: addr}}},
+			}
+		} else {
+			header.ComputeHash()
+		}
+
+		m.headers[i] = header
+	}
+
+	return m
+}
+
+func TestIndexer_ProcessSections(t *testing.T) {
+	t.Parallel()
+
+	const sectionSize = uint64(16)
+
+	addr := types.StringToAddress("0x1234")
+	chain := newMockBlockchain(2*sectionSize+5, map[uint64]types.Address{
+		3:  addr,
+		20: addr,
+		35: addr, // not part of a complete section
+	})
+
+	db, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	indexer, err := NewIndexer(hclog.NewNullLogger(), chain, db, sectionSize)
+	require.NoError(t, err)
+
+	indexer.Start()
+	defer indexer.Close()
+
+	require.Eventually(t, func() bool {
+		_, sections := indexer.BloomStatus()
+
+		return sections == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = indexer.GetBloomBits(0, 2)
+	require.Error(t, err)
+
+	matches, err := NewMatcher(sectionSize, [][][]byte{{addr.Bytes()}}).
+		Match(0, 2*sectionSize-1, indexer.GetBloomBits)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 20}, matches)
+
+	// the number of indexed sections is persisted
+	restarted, err := NewIndexer(hclog.NewNullLogger(), chain, db, sectionSize)
+	require.NoError(t, err)
+
+	_, sections := restarted.BloomStatus()
+	require.Equal(t, uint64(2), sections)
+
+	// a reorg discards the sections containing the replaced blocks
+	restarted.handleReorg(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		NewChain: []*types.Header{chain.headers[20]},
+	})
+
+	_, sections = restarted.BloomStatus()
+	require.Equal(t, uint64(1), sections)
+
+	sections, ok := db.ReadBloomSections()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), sections)
+}
+
+func TestIndexer_ReorgDuringProcessing(t *testing.T) {
+	t.Parallel()
+
+	const sectionSize = uint64(16)
+
+	chain := newMockBlockchain(2*sectionSize, nil)
+
+	db, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	indexer, err := NewIndexer(hclog.NewNullLogger(), chain, db, sectionSize)
+	require.NoError(t, err)
+
+	require.NoError(t, indexer.processSection(0))
+
+	indexed, err := indexer.completeSection(0)
+	require.NoError(t, err)
+	require.True(t, indexed)
+
+	// the first section is discarded by a reorg while the second one is processed
+	require.NoError(t, indexer.processSection(1))
+
+	indexer.handleReorg(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		NewChain: []*types.Header{chain.headers[3]},
+	})
+
+	indexed, err = indexer.completeSection(1)
+	require.NoError(t, err)
+	require.False(t, indexed)
+
+	_, sections := indexer.BloomStatus()
+	require.Equal(t, uint64(0), sections)
+
+	sections, ok := db.ReadBloomSections()
+	require.True(t, ok)
+	require.Equal(t, uint64(0), sections)
+}
+
+func TestIndexer_MissingReceipts(t *testing.T) {
+	t.Parallel()
+
+	const sectionSize = uint64(16)
+
+	addr := types.StringToAddress("0x1234")
+	chain := newMockBlockchain(sectionSize, map[uint64]types.Address{3: addr})
+
+	delete(chain.receipts, chain.headers[3].Hash)
+
+	db, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	indexer, err := NewIndexer(hclog.NewNullLogger(), chain, db, sectionSize)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, indexer.processSections(), storagev2.ErrNotFound)
+
+	_, sections := indexer.BloomStatus()
+	require.Equal(t, uint64(0), sections)
+
+	_, ok := db.ReadBloomSections()
+	require.False(t, ok)
+}
+
+// storageBlockchain serves the chain written into the storage, as the blockchain does
+type storageBlockchain struct {
+	db           *storagev2.Storage
+	subscription *blockchain.MockSubscription
+}
+
+func (m *storageBlockchain) Header() *types.Header {
+	header, _ := m.GetHeaderByNumber(m.headNumber())
+
+	return header
+}
+
+func (m *storageBlockchain) headNumber() uint64 {
+	head, _ := m.db.ReadHeadNumber()
+
+	return head
+}
+
+func (m *storageBlockchain) GetHeaderByNumber(num uint64) (*types.Header, bool) {
+	hash, ok := m.db.ReadCanonicalHash(num)
+	if !ok {
+		return nil, false
+	}
+
+	header, err := m.db.ReadHeader(num, hash)
+	if err != nil {
+		return nil, false
+	}
+
+	header.ComputeHash()
+
+	return header, true
+}
+
+func (m *storageBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	num, err := m.db.ReadBlockLookup(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.db.ReadReceipts(num, hash)
+}
+
+func (m *storageBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.subscription
+}
+
+func (m *storageBlockchain) UnsubscribeEvents(blockchain.Subscription) {}
+
+func TestIndexer_ConcurrentHistoryPruning(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sectionSize = uint64(16)
+		sections    = uint64(4)
+		retention   = uint64(4)
+	)
+
+	// the memory storage can't be used concurrently
+	db, err := leveldb.NewLevelDBStorage(t.TempDir(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	addr := types.StringToAddress("0x1234")
+
+	// every section has a block with a log
+	var logBlocks []uint64
+
+	writeBlock := func(num uint64) {
+		header := &types.Header{Number: num, TxRoot: types.EmptyRootHash}
+		receipts := []*types.Receipt{}
+
+		if num%sectionSize == 5 {
+			header.TxRoot = types.StringToHash("0x1")
+			receipts = append(receipts, &types.Receipt{Logs: []*types.Log{{Address: addr}}})
+			logBlocks = append(logBlocks, num)
+		}
+
+		header.ComputeHash()
+
+		w := db.NewWriter()
+		w.PutCanonicalHeader(header, big.NewInt(int64(num)))
+		w.PutReceipts(num, header.Hash, receipts)
+		require.NoError(t, w.WriteBatch())
+	}
+
+	writeBlock(0)
+
+	chain := &storageBlockchain{
+		db:           db,
+		subscription: blockchain.NewMockSubscription(),
+	}
+
+	indexer, err := NewIndexer(hclog.NewNullLogger(), chain, db, sectionSize)
+	require.NoError(t, err)
+
+	indexer.Start()
+	defer indexer.Close()
+
+	done := make(chan struct{})
+	pruneErrCh := make(chan error, 1)
+
+	// the history is pruned as fast as possible while the chain grows and the sections are indexed
+	go func() {
+		defer close(pruneErrCh)
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			if _, err := db.PruneHistory(retention, sectionSize); err != nil {
+				pruneErrCh <- err
+
+				return
+			}
+		}
+	}()
+
+	for num := uint64(1); num < sections*sectionSize; num++ {
+		writeBlock(num)
+
+		chain.subscription.Push(&blockchain.Event{Type: blockchain.EventHead})
+
+		time.Sleep(time.Millisecond)
+	}
+
+	require.Eventually(t, func() bool {
+		_, indexed := indexer.BloomStatus()
+
+		return indexed == sections
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(done)
+	require.NoError(t, <-pruneErrCh)
+
+	// the pruner catches up with the retention once the blocks are indexed
+	_, err = db.PruneHistory(retention, sectionSize)
+	require.NoError(t, err)
+	require.Equal(t, sections*sectionSize-retention, db.ReadHistoryTail())
+
+	matches, err := NewMatcher(sectionSize, [][][]byte{{addr.Bytes()}}).
+		Match(0, sections*sectionSize-1, indexer.GetBloomBits)
+	require.NoError(t, err)
+	require.Equal(t, logBlocks, matches)
+}
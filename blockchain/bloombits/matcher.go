@@ -0,0 +1,158 @@
+package bloombits
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+)
+
+// BitsRetriever returns the (decompressed) bit vector of the given bloom bit for the given section
+type BitsRetriever func(bit uint, section uint64) ([]byte, error)
+
+// bloomIndexes represents the bit indexes inside the bloom filter that belong
+// to some key.
+type bloomIndexes [3]uint
+
+// calcBloomIndexes returns the bloom filter bit indexes belonging to the given key.
+func calcBloomIndexes(b []byte) bloomIndexes {
+	b = crypto.Keccak256(b)
+
+	var idxs bloomIndexes
+	for i := 0; i < len(idxs); i++ {
+		idxs[i] = (uint(b[2*i+1]) + (uint(b[2*i]) << 8)) & (BloomBitLength - 1)
+	}
+
+	return idxs
+}
+
+// Matcher is a bloom bits based filter which returns the blocks (of a given block range)
+// whose bloom filters might contain all the given criteria.
+type Matcher struct {
+	sectionSize uint64
+
+	// filters is a list of groups, where each group is a list of alternative keys (addresses or topics).
+	// A block matches when for each group at least one of the alternatives matches.
+	filters [][]bloomIndexes
+}
+
+// NewMatcher creates a new matcher for the given filters. Each filter group is a set of
+// alternative keys (addresses or topics), out of which at least one has to be present
+// in the block bloom. Empty groups are treated as wildcards and are ignored.
+func NewMatcher(sectionSize uint64, filters [][][]byte) *Matcher {
+	m := &Matcher{
+		sectionSize: sectionSize,
+		filters:     make([][]bloomIndexes, 0, len(filters)),
+	}
+
+	for _, filter := range filters {
+		if len(filter) == 0 {
+			continue
+		}
+
+		group := make([]bloomIndexes, len(filter))
+		for i, key := range filter {
+			group[i] = calcBloomIndexes(key)
+		}
+
+		m.filters = append(m.filters, group)
+	}
+
+	return m
+}
+
+// Match returns the numbers of blocks in the [from, to] range which potentially
+// match the filters of the matcher. The range has to be covered by indexed sections.
+func (m *Matcher) Match(from, to uint64, retriever BitsRetriever) ([]uint64, error) {
+	var matches []uint64
+
+	for section := from / m.sectionSize; section <= to/m.sectionSize; section++ {
+		bitset, err := m.matchSection(section, retriever)
+		if err != nil {
+			return nil, err
+		}
+
+		first := section * m.sectionSize
+
+		for i := uint64(0); i < m.sectionSize; i++ {
+			number := first + i
+			if number < from || number > to {
+				continue
+			}
+
+			if bitset == nil || bitset[i/8]&(1<<byte(7-i%8)) != 0 {
+				matches = append(matches, number)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// matchSection returns the bit vector of potentially matching blocks of the given section.
+// Nil result means that there were no filters and every block of the section matches.
+func (m *Matcher) matchSection(section uint64, retriever BitsRetriever) ([]byte, error) {
+	var (
+		result []byte
+		cache  = make(map[uint][]byte)
+	)
+
+	getBits := func(bit uint) ([]byte, error) {
+		if bits, ok := cache[bit]; ok {
+			return bits, nil
+		}
+
+		bits, err := retriever(bit, section)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(len(bits)) != m.sectionSize/8 {
+			return nil, fmt.Errorf("invalid bit vector length %d for bit %d in section %d", len(bits), bit, section)
+		}
+
+		cache[bit] = bits
+
+		return bits, nil
+	}
+
+	for _, group := range m.filters {
+		groupResult := make([]byte, m.sectionSize/8)
+
+		for _, idxs := range group {
+			// all the bloom bits of the key need to be set
+			keyResult := make([]byte, m.sectionSize/8)
+			for i := range keyResult {
+				keyResult[i] = 0xff
+			}
+
+			for _, bit := range idxs {
+				bits, err := getBits(bit)
+				if err != nil {
+					return nil, err
+				}
+
+				for i := range keyResult {
+					keyResult[i] &= bits[i]
+				}
+			}
+
+			// any of the keys in the group can be present
+			for i := range groupResult {
+				groupResult[i] |= keyResult[i]
+			}
+		}
+
+		// all the groups need to be matched
+		if result == nil {
+			result = groupResult
+
+			continue
+		}
+
+		for i := range result {
+			result[i] &= groupResult[i]
+		}
+	}
+
+	return result, nil
+}
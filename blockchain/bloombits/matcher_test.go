@@ -0,0 +1,139 @@
+package bloombits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestGenerator_AddBloom(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewGenerator(10)
+	require.ErrorIs(t, err, errSectionSize)
+
+	gen, err := NewGenerator(8)
+	require.NoError(t, err)
+
+	require.Error(t, gen.AddBloom(1, types.Bloom{}))
+
+	_, err = gen.Bitset(0)
+	require.ErrorIs(t, err, errSectionNotFull)
+
+	for i := uint64(0); i < 8; i++ {
+		require.NoError(t, gen.AddBloom(i, types.Bloom{}))
+	}
+
+	require.ErrorIs(t, gen.AddBloom(8, types.Bloom{}), errSectionOutOfBounds)
+
+	_, err = gen.Bitset(BloomBitLength)
+	require.ErrorIs(t, err, errSectionOutOfBounds)
+}
+
+func TestMatcher_Match(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sectionSize = uint64(16)
+		numSections = 3
+	)
+
+	var (
+		addr1  = types.StringToAddress("0x1")
+		addr2  = types.StringToAddress("0x2")
+		topic1 = types.StringToHash("0x3")
+		topic2 = types.StringToHash("0x4")
+	)
+
+	// block number -> logs of the block
+	blockLogs := map[uint64][]*types.Log{
+		3:  {{Address: addr1, Topics: []types.Hash{topic1}}},
+		17: {{Address: addr2, Topics: []types.Hash{topic2}}},
+		18: {{Address: addr1, Topics: []types.Hash{topic2}}},
+		40: {{Address: addr2, Topics: []types.Hash{topic1, topic2}}},
+	}
+
+	sections := make([]*Generator, numSections)
+
+	for s := range sections {
+		gen, err := NewGenerator(sectionSize)
+		require.NoError(t, err)
+
+		for i := uint64(0); i < sectionSize; i++ {
+			receipts := []*types.Receipt{{Logs: blockLogs[uint64(s)*sectionSize+i]}}
+			require.NoError(t, gen.AddBloom(i, types.CreateBloom(receipts)))
+		}
+
+		sections[s] = gen
+	}
+
+	retriever := func(bit uint, section uint64) ([]byte, error) {
+		return sections[section].Bitset(bit)
+	}
+
+	cases := []struct {
+		name     string
+		from, to uint64
+		filters  [][][]byte
+		expected []uint64
+	}{
+		{
+			"single address",
+			0, 47,
+			[][][]byte{{addr1.Bytes()}},
+			[]uint64{3, 18},
+		},
+		{
+			"any of the addresses",
+			0, 47,
+			[][][]byte{{addr1.Bytes(), addr2.Bytes()}},
+			[]uint64{3, 17, 18, 40},
+		},
+		{
+			"address and topic",
+			0, 47,
+			[][][]byte{{addr2.Bytes()}, {topic1.Bytes()}},
+			[]uint64{40},
+		},
+		{
+			"wildcard topic",
+			0, 47,
+			[][][]byte{{addr1.Bytes()}, {}},
+			[]uint64{3, 18},
+		},
+		{
+			"limited range",
+			4, 39,
+			[][][]byte{{topic2.Bytes()}},
+			[]uint64{17, 18},
+		},
+		{
+			"no match",
+			0, 47,
+			[][][]byte{{types.StringToAddress("0x5").Bytes()}},
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			matches, err := NewMatcher(sectionSize, c.filters).Match(c.from, c.to, retriever)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, matches)
+		})
+	}
+
+	t.Run("no filters", func(t *testing.T) {
+		t.Parallel()
+
+		matches, err := NewMatcher(sectionSize, nil).Match(5, 20, retriever)
+		require.NoError(t, err)
+		require.Len(t, matches, 16)
+	})
+}
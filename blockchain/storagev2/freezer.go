@@ -0,0 +1,208 @@
+package storagev2
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// freezerHashesTable is the name of the table of the frozen block hashes
+const freezerHashesTable = "hashes"
+
+// freezerTables are the tables moved to the freezer, along with the names of their files
+var freezerTables = map[uint8]string{
+	HEADER:   "headers",
+	BODY:     "bodies",
+	RECEIPTS: "receipts",
+}
+
+// Freezer is the append-only store of the finalized canonical blocks, moved out of the main database.
+// Each table keeps the items of the blocks indexed by the block number, starting with the genesis
+type Freezer struct {
+	lock   sync.RWMutex
+	hashes *freezerTable
+	tables map[uint8]*freezerTable
+	frozen uint64 // number of the frozen blocks
+}
+
+// frozenBlock is the canonical block moved to the freezer
+type frozenBlock struct {
+	number uint64
+	hash   types.Hash
+	items  map[uint8][]byte
+}
+
+// OpenFreezer opens the freezer in the directory, creating it if needed
+func OpenFreezer(dir string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{tables: make(map[uint8]*freezerTable, len(freezerTables))}
+
+	var err error
+
+	if f.hashes, err = openFreezerTable(dir, freezerHashesTable, false); err != nil {
+		return nil, err
+	}
+
+	f.frozen = f.hashes.items
+
+	for t, name := range freezerTables {
+		table, err := openFreezerTable(dir, name, true)
+		if err != nil {
+			_ = f.Close()
+
+			return nil, err
+		}
+
+		f.tables[t] = table
+		f.frozen = min(f.frozen, table.items)
+	}
+
+	// the blocks partially frozen before a crash are dropped
+	if err := f.truncate(f.frozen); err != nil {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Frozen returns the number of the frozen blocks, i.e. the number of the first block not frozen
+func (f *Freezer) Frozen() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.frozen
+}
+
+// read returns the item of the table for the frozen block,
+// or false if the block is not frozen or has no such item
+func (f *Freezer) read(t uint8, bn uint64, bh types.Hash) ([]byte, bool, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	table, ok := f.tables[t]
+	if !ok || bn >= f.frozen {
+		return nil, false, nil
+	}
+
+	hash, err := f.hashes.get(bn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the block with the number is frozen, but it is not the canonical one
+	if types.BytesToHash(hash) != bh {
+		return nil, false, nil
+	}
+
+	item, err := table.get(bn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return item, len(item) > 0, nil
+}
+
+// freeze appends the consecutive blocks following the frozen ones and persists them
+func (f *Freezer) freeze(blocks []*frozenBlock) (err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	defer func() {
+		// the tables are not left partially appended
+		if err != nil {
+			if truncateErr := f.truncate(f.frozen); truncateErr != nil {
+				err = fmt.Errorf("%w, failed to truncate the freezer: %w", err, truncateErr)
+			}
+		}
+	}()
+
+	for i, block := range blocks {
+		if block.number != f.frozen+uint64(i) {
+			return fmt.Errorf("block %d is not following the frozen blocks", block.number)
+		}
+
+		if err := f.hashes.append(block.hash.Bytes()); err != nil {
+			return err
+		}
+
+		for t, table := range f.tables {
+			if err := table.append(block.items[t]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, table := range f.allTables() {
+		if err := table.sync(); err != nil {
+			return err
+		}
+	}
+
+	f.frozen += uint64(len(blocks))
+
+	return nil
+}
+
+// rewind drops the frozen blocks from the number on
+func (f *Freezer) rewind(frozen uint64) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if frozen >= f.frozen {
+		return nil
+	}
+
+	return f.truncate(frozen)
+}
+
+// truncate drops the frozen blocks from the number on, must be called with the lock held
+func (f *Freezer) truncate(frozen uint64) error {
+	for _, table := range f.allTables() {
+		if table.items > frozen {
+			if err := table.truncate(frozen); err != nil {
+				return err
+			}
+		}
+	}
+
+	f.frozen = frozen
+
+	return nil
+}
+
+// Close closes the files of the freezer
+func (f *Freezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var firstErr error
+
+	for _, table := range f.allTables() {
+		if err := table.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (f *Freezer) allTables() []*freezerTable {
+	tables := make([]*freezerTable, 0, len(f.tables)+1)
+
+	if f.hashes != nil {
+		tables = append(tables, f.hashes)
+	}
+
+	for _, table := range f.tables {
+		tables = append(tables, table)
+	}
+
+	return tables
+}
@@ -0,0 +1,201 @@
+package storagev2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/snappy"
+)
+
+// freezerIndexEntrySize is the size of the index entry, the end offset of the item in the data file
+const freezerIndexEntrySize = 8
+
+// freezerTable is an append-only flat file table of the items indexed by their position.
+// The data file holds the items back to back, the index file holds the end offset of each item
+type freezerTable struct {
+	name     string
+	compress bool
+
+	index *os.File
+	data  *os.File
+
+	items    uint64 // number of the items in the table
+	dataSize uint64 // size of the data file
+}
+
+func openFreezerTable(dir string, name string, compress bool) (*freezerTable, error) {
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.OpenFile(filepath.Join(dir, name+".dat"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		_ = index.Close()
+
+		return nil, err
+	}
+
+	t := &freezerTable{
+		name:     name,
+		compress: compress,
+		index:    index,
+		data:     data,
+	}
+
+	if err := t.repair(); err != nil {
+		_ = t.close()
+
+		return nil, fmt.Errorf("failed to repair freezer table %s: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// repair drops the items partially written before a crash,
+// so that the index and the data file are consistent with each other
+func (t *freezerTable) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	t.items = uint64(indexStat.Size()) / freezerIndexEntrySize
+	dataSize := uint64(dataStat.Size())
+
+	// the index entries written ahead of the data are dropped
+	for t.items > 0 {
+		end, err := t.offset(t.items - 1)
+		if err != nil {
+			return err
+		}
+
+		if end <= dataSize {
+			break
+		}
+
+		t.items--
+	}
+
+	return t.truncate(t.items)
+}
+
+// offset returns the end offset of the item in the data file
+func (t *freezerTable) offset(i uint64) (uint64, error) {
+	var buf [freezerIndexEntrySize]byte
+	if _, err := t.index.ReadAt(buf[:], int64(i*freezerIndexEntrySize)); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// get returns the item at the position
+func (t *freezerTable) get(i uint64) ([]byte, error) {
+	if i >= t.items {
+		return nil, ErrNotFound
+	}
+
+	start := uint64(0)
+
+	if i > 0 {
+		var err error
+		if start, err = t.offset(i - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	end, err := t.offset(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if end < start {
+		return nil, ErrInvalidData
+	}
+
+	item := make([]byte, end-start)
+	if _, err := t.data.ReadAt(item, int64(start)); err != nil {
+		return nil, err
+	}
+
+	if t.compress && len(item) > 0 {
+		return snappy.Decode(nil, item)
+	}
+
+	return item, nil
+}
+
+// append writes the item at the end of the table. The item is persisted once the table is synced
+func (t *freezerTable) append(item []byte) error {
+	if t.compress && len(item) > 0 {
+		item = snappy.Encode(nil, item)
+	}
+
+	if _, err := t.data.WriteAt(item, int64(t.dataSize)); err != nil {
+		return err
+	}
+
+	var buf [freezerIndexEntrySize]byte
+	binary.BigEndian.PutUint64(buf[:], t.dataSize+uint64(len(item)))
+
+	if _, err := t.index.WriteAt(buf[:], int64(t.items*freezerIndexEntrySize)); err != nil {
+		return err
+	}
+
+	t.dataSize += uint64(len(item))
+	t.items++
+
+	return nil
+}
+
+// truncate drops the items from the position on
+func (t *freezerTable) truncate(items uint64) error {
+	dataSize := uint64(0)
+
+	if items > 0 {
+		var err error
+		if dataSize, err = t.offset(items - 1); err != nil {
+			return err
+		}
+	}
+
+	if err := t.index.Truncate(int64(items * freezerIndexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(dataSize)); err != nil {
+		return err
+	}
+
+	t.items, t.dataSize = items, dataSize
+
+	return nil
+}
+
+// sync flushes the table to the disk, the data file first
+func (t *freezerTable) sync() error {
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+
+	return t.index.Sync()
+}
+
+func (t *freezerTable) close() error {
+	indexErr := t.index.Close()
+	dataErr := t.data.Close()
+
+	if indexErr != nil {
+		return indexErr
+	}
+
+	return dataErr
+}
@@ -1,10 +1,13 @@
 package leveldb
 
 import (
+	"bytes"
+
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // levelDB is the leveldb implementation of the kv storage
@@ -23,6 +26,30 @@ var tableMapper = map[uint8][]byte{
 	storagev2.HEAD_NUMBER:  {},          // DB key = HEAD_NUMBER_KEY + mapper, value = head number
 	storagev2.BLOCK_LOOKUP: {},          // DB key = block hash + mapper, value = block number
 	storagev2.TX_LOOKUP:    {},          // DB key = tx hash + mapper, value = block number
+
+	storagev2.BLOOM_BITS:     []byte("l"), // DB key = bloom bit + section + mapper, value = compressed bit vector
+	storagev2.BLOOM_SECTIONS: {},          // DB key = BLOOM_SECTIONS_KEY + mapper, value = indexed sections
+	storagev2.HISTORY_TAIL:   {},          // DB key = HISTORY_TAIL_KEY + mapper, value = first unpruned block number
+}
+
+// tableKeyLength is the length of the keys of the tables, without the mapper.
+// The tables sharing the key length and the mapper can't be iterated
+var tableKeyLength = map[uint8]int{
+	storagev2.BODY:       40,
+	storagev2.DIFFICULTY: 40,
+	storagev2.HEADER:     40,
+	storagev2.RECEIPTS:   40,
+	storagev2.CANONICAL:  8,
+	storagev2.BLOOM_BITS: 10,
+}
+
+// singleKeyTables are the tables holding only one key
+var singleKeyTables = map[uint8][]byte{
+	storagev2.FORK:           storagev2.FORK_KEY,
+	storagev2.HEAD_HASH:      storagev2.HEAD_HASH_KEY,
+	storagev2.HEAD_NUMBER:    storagev2.HEAD_NUMBER_KEY,
+	storagev2.BLOOM_SECTIONS: storagev2.BLOOM_SECTIONS_KEY,
+	storagev2.HISTORY_TAIL:   storagev2.HISTORY_TAIL_KEY,
 }
 
 // NewLevelDBStorage creates the new storage reference with leveldb default options
@@ -73,6 +100,67 @@ func (l *levelDB) Get(t uint8, k []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Delete removes the key-value pair from leveldb storage
+func (l *levelDB) Delete(t uint8, k []byte) error {
+	mc := tableMapper[t]
+
+	return l.db.Delete(append(k, mc...), nil)
+}
+
+// Iterate iterates over the key-value pairs of the table in leveldb storage.
+// The tables are not separated in leveldb, so the keys are told apart by their length and mapper,
+// which doesn't work for the block and transaction lookups
+func (l *levelDB) Iterate(t uint8, start []byte, fn func(k, v []byte) bool) error {
+	if key, ok := singleKeyTables[t]; ok {
+		if bytes.Compare(key, start) < 0 {
+			return nil
+		}
+
+		data, ok, err := l.Get(t, key)
+		if err != nil || !ok {
+			return err
+		}
+
+		fn(key, data)
+
+		return nil
+	}
+
+	length, ok := tableKeyLength[t]
+	if !ok {
+		return storagev2.ErrIterationNotSupported
+	}
+
+	mc := tableMapper[t]
+
+	it := l.db.NewIterator(&util.Range{Start: start}, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != length+len(mc) || !bytes.HasSuffix(key, mc) || isSingleKey(key) {
+			continue
+		}
+
+		if !fn(key[:length], it.Value()) {
+			break
+		}
+	}
+
+	return it.Error()
+}
+
+// isSingleKey checks if the key is the one of the single key tables, sharing the length with the canonical hashes
+func isSingleKey(key []byte) bool {
+	for _, k := range singleKeyTables {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDB) Close() error {
 	return l.db.Close()
@@ -1,7 +1,9 @@
 package mdbx
 
 import (
+	"bytes"
 	"os"
+	"runtime"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
 	"github.com/erigontech/mdbx-go/mdbx"
@@ -43,8 +45,16 @@ var tableMapper = map[uint8]string{
 	storagev2.HEAD_NUMBER:  "HeadNumber",
 	storagev2.BLOCK_LOOKUP: "BlockLookup",
 	storagev2.TX_LOOKUP:    "TxLookup",
+
+	storagev2.BLOOM_BITS:     "BloomBits",
+	storagev2.BLOOM_SECTIONS: "BloomSections",
+	storagev2.HISTORY_TAIL:   "HistoryTail",
 }
 
+// iterateBatchSize is the maximal number of the entries read in one transaction while iterating,
+// so that the function called for the entries can read the database itself
+const iterateBatchSize = 1024
+
 // NewMdbxStorage creates the new storage reference for mdbx database
 func NewMdbxStorage(path string, logger hclog.Logger) (*storagev2.Storage, error) {
 	var dbs [2]storagev2.Database
@@ -178,6 +188,82 @@ func (db *MdbxDB) Get(t uint8, k []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Delete removes the key-value pair from mdbx storage
+func (db *MdbxDB) Delete(t uint8, k []byte) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	return db.update(func(tx *mdbx.Txn) error {
+		if err := tx.Del(db.dbi[t], k, nil); err != nil && !mdbx.IsNotFound(err) {
+			tx.Abort()
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Iterate iterates over the key-value pairs of the table in mdbx storage
+func (db *MdbxDB) Iterate(t uint8, start []byte, fn func(k, v []byte) bool) error {
+	for {
+		keys, values, err := db.readRange(t, start)
+		if err != nil {
+			return err
+		}
+
+		for i := range keys {
+			if !fn(keys[i], values[i]) {
+				return nil
+			}
+		}
+
+		if len(keys) < iterateBatchSize {
+			return nil
+		}
+
+		// continue right after the last key
+		start = append(keys[len(keys)-1], 0)
+	}
+}
+
+// readRange copies a batch of the key-value pairs of the table starting with the key
+func (db *MdbxDB) readRange(t uint8, start []byte) ([][]byte, [][]byte, error) {
+	keys := make([][]byte, 0, iterateBatchSize)
+	values := make([][]byte, 0, iterateBatchSize)
+
+	err := db.view(func(tx *mdbx.Txn) error {
+		defer tx.Abort()
+
+		cursor, err := tx.OpenCursor(db.dbi[t])
+		if err != nil {
+			return err
+		}
+
+		defer cursor.Close()
+
+		op := uint(mdbx.SetRange)
+		if len(start) == 0 {
+			op = mdbx.First
+		}
+
+		k, v, err := cursor.Get(start, nil, op)
+
+		for ; err == nil && len(keys) < iterateBatchSize; k, v, err = cursor.Get(nil, nil, mdbx.Next) {
+			keys = append(keys, bytes.Clone(k))
+			values = append(values, bytes.Clone(v))
+		}
+
+		if err != nil && !mdbx.IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	})
+
+	return keys, values, err
+}
+
 // Close closes the mdbx storage instance
 func (db *MdbxDB) Close() error {
 	db.env.Close()
@@ -1,13 +1,19 @@
 package memory
 
 import (
-	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 )
 
+// memoryOp is the write operation of the batch, deleting the key if the value is nil
+type memoryOp struct {
+	t uint8
+	k []byte
+	v []byte
+}
+
 type batchMemory struct {
-	db          []memoryKV
-	valuesToPut [storagev2.MAX_TABLES][][2][]byte
+	db  []memoryKV
+	ops []memoryOp
 }
 
 func newBatchMemory(db []memoryKV) *batchMemory {
@@ -15,13 +21,23 @@ func newBatchMemory(db []memoryKV) *batchMemory {
 }
 
 func (b *batchMemory) Put(t uint8, k []byte, v []byte) {
-	b.valuesToPut[t] = append(b.valuesToPut[t], [2][]byte{k, v})
+	if v == nil {
+		v = []byte{}
+	}
+
+	b.ops = append(b.ops, memoryOp{t: t, k: k, v: v})
+}
+
+func (b *batchMemory) Delete(t uint8, k []byte) {
+	b.ops = append(b.ops, memoryOp{t: t, k: k})
 }
 
 func (b *batchMemory) Write() error {
-	for i, j := range b.valuesToPut {
-		for _, x := range j {
-			b.db[i].kv[hex.EncodeToHex(x[0])] = x[1]
+	for _, op := range b.ops {
+		if op.v == nil {
+			delete(b.db[op.t].kv, hex.EncodeToHex(op.k))
+		} else {
+			b.db[op.t].kv[hex.EncodeToHex(op.k)] = op.v
 		}
 	}
 
@@ -1,6 +1,9 @@
 package memory
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 )
@@ -39,6 +42,40 @@ func (m *memoryDB) Get(t uint8, k []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryDB) Delete(t uint8, k []byte) error {
+	delete(m.db[t].kv, hex.EncodeToHex(k))
+
+	return nil
+}
+
+func (m *memoryDB) Iterate(t uint8, start []byte, fn func(k, v []byte) bool) error {
+	keys := make([][]byte, 0, len(m.db[t].kv))
+
+	for key := range m.db[t].kv {
+		k, err := hex.DecodeHex(key)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Compare(k, start) >= 0 {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	for _, k := range keys {
+		v, ok := m.db[t].kv[hex.EncodeToHex(k)]
+		if ok && !fn(k, v) {
+			break
+		}
+	}
+
+	return nil
+}
+
 func (m *memoryDB) Close() error {
 	return nil
 }
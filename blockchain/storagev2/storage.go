@@ -10,6 +10,11 @@ import (
 type Database interface {
 	Close() error
 	Get(t uint8, k []byte) ([]byte, bool, error)
+	Delete(t uint8, k []byte) error
+	// Iterate calls the function for the entries of the table in the ascending order of the keys,
+	// starting with the first key not less than the start, until the function returns false.
+	// The key and the value are only valid during the call
+	Iterate(t uint8, start []byte, fn func(k, v []byte) bool) error
 	NewBatch() Batch
 }
 
@@ -17,11 +22,19 @@ type Database interface {
 type Batch interface {
 	Write() error
 	Put(t uint8, k []byte, v []byte)
+	Delete(t uint8, k []byte)
 }
 
 type Storage struct {
 	logger hclog.Logger
 	db     [2]Database
+
+	freezer     *Freezer
+	freezerStop chan struct{}
+	freezerDone chan struct{}
+
+	historyStop chan struct{}
+	historyDone chan struct{}
 }
 
 type Writer struct {
@@ -46,6 +59,11 @@ const (
 	HEAD_NUMBER  = uint8(4) | LOOKUP_INDEX
 	BLOCK_LOOKUP = uint8(6) | LOOKUP_INDEX
 	TX_LOOKUP    = uint8(8) | LOOKUP_INDEX
+
+	BLOOM_BITS     = uint8(10) | LOOKUP_INDEX
+	BLOOM_SECTIONS = uint8(12) | LOOKUP_INDEX
+
+	HISTORY_TAIL = uint8(14) | LOOKUP_INDEX
 )
 
 //nolint:stylecheck // needed because linter considers _ in name as an error
@@ -64,16 +82,36 @@ var (
 	FORK_KEY        = []byte("0000000f")
 	HEAD_HASH_KEY   = []byte("0000000h")
 	HEAD_NUMBER_KEY = []byte("0000000n")
+
+	BLOOM_SECTIONS_KEY = []byte("0000000s")
+
+	HISTORY_TAIL_KEY = []byte("0000000t")
 )
 
 var ErrNotFound = fmt.Errorf("not found")
 var ErrInvalidData = fmt.Errorf("invalid data")
 
+// ErrHistoryPruned is returned for the bodies and receipts of the blocks removed by the history pruning
+var ErrHistoryPruned = fmt.Errorf("history pruned")
+
+// ErrIterationNotSupported is returned by the databases which can't tell the entries of the table apart
+var ErrIterationNotSupported = fmt.Errorf("iteration not supported")
+
 func Open(logger hclog.Logger, db [2]Database) (*Storage, error) {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
 	return &Storage{logger: logger, db: db}, nil
 }
 
 func (s *Storage) Close() error {
+	s.stopHistoryPruner()
+
+	if err := s.stopFreezer(); err != nil {
+		return err
+	}
+
 	for i, db := range s.db {
 		if db != nil {
 			err := db.Close()
@@ -0,0 +1,181 @@
+package storagev2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// freezerBatchSize is the maximal number of the blocks moved to the freezer at once
+	freezerBatchSize = uint64(1000)
+
+	// freezerInterval is the interval of checking for the blocks to move to the freezer
+	freezerInterval = 10 * time.Second
+)
+
+// StartFreezer moves the canonical headers, bodies and receipts of the blocks older than the threshold
+// from the main database to the freezer in the background. The reads of the moved blocks are served
+// from the freezer. Must be called before the storage is used, the freezer is closed along with the storage
+func (s *Storage) StartFreezer(freezer *Freezer, threshold uint64) {
+	s.freezer = freezer
+	s.freezerStop = make(chan struct{})
+	s.freezerDone = make(chan struct{})
+
+	go s.runFreezer(threshold)
+}
+
+// AttachFreezer serves the reads of the frozen blocks from the freezer, without moving any more blocks to it.
+// Used by the offline tools, the freezer is closed along with the storage
+func (s *Storage) AttachFreezer(freezer *Freezer) {
+	s.freezer = freezer
+}
+
+// Freezer returns the freezer of the storage, or nil if the blocks are not frozen
+func (s *Storage) Freezer() *Freezer {
+	return s.freezer
+}
+
+func (s *Storage) runFreezer(threshold uint64) {
+	defer close(s.freezerDone)
+
+	// the main database entries of the last frozen batch may be left over from a restart
+	frozen := s.freezer.Frozen()
+	if err := s.deleteFrozen(frozen-min(frozen, freezerBatchSize), frozen); err != nil {
+		s.logger.Error("failed to delete the frozen blocks from the database", "err", err)
+	}
+
+	ticker := time.NewTicker(freezerInterval)
+	defer ticker.Stop()
+
+	for {
+		// catch up with the head in batches, unless stopped
+		for {
+			moved, err := s.freeze(threshold)
+			if err != nil {
+				s.logger.Error("failed to move the blocks to the freezer", "err", err)
+			}
+
+			if err != nil || moved < freezerBatchSize {
+				break
+			}
+
+			select {
+			case <-s.freezerStop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-s.freezerStop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// freeze moves a batch of the canonical blocks older than the threshold to the freezer,
+// returning the number of the moved blocks
+func (s *Storage) freeze(threshold uint64) (uint64, error) {
+	head, ok := s.ReadHeadNumber()
+	if !ok || head < threshold {
+		return 0, nil
+	}
+
+	from := s.freezer.Frozen()
+	to := min(head-threshold+1, from+freezerBatchSize)
+
+	if from >= to {
+		return 0, nil
+	}
+
+	blocks := make([]*frozenBlock, 0, to-from)
+
+	for bn := from; bn < to; bn++ {
+		hash, ok := s.ReadCanonicalHash(bn)
+		if !ok {
+			return 0, fmt.Errorf("canonical hash of block %d not found", bn)
+		}
+
+		block := &frozenBlock{number: bn, hash: hash, items: make(map[uint8][]byte, len(freezerTables))}
+
+		for t := range freezerTables {
+			data, _, err := s.getDB(t).Get(t, getKey(bn, hash))
+			if err != nil {
+				return 0, err
+			}
+
+			block.items[t] = data
+		}
+
+		if len(block.items[HEADER]) == 0 {
+			return 0, fmt.Errorf("header of block %d not found", bn)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if err := s.freezer.freeze(blocks); err != nil {
+		return 0, err
+	}
+
+	if err := s.deleteFrozen(from, to); err != nil {
+		return 0, err
+	}
+
+	s.logger.Debug("moved blocks to the freezer", "from", from, "to", to-1)
+
+	return to - from, nil
+}
+
+// deleteFrozen deletes the frozen blocks in the range from the main database
+func (s *Storage) deleteFrozen(from, to uint64) error {
+	// the hashes are read before the batch is opened, as some databases
+	// don't allow to read in the middle of a write transaction
+	keys := make([][]byte, 0, to-from)
+
+	for bn := from; bn < to; bn++ {
+		if hash, ok := s.ReadCanonicalHash(bn); ok {
+			keys = append(keys, getKey(bn, hash))
+		}
+	}
+
+	w := s.NewWriter()
+
+	for _, key := range keys {
+		for t := range freezerTables {
+			w.deleteFromTable(t, key)
+		}
+	}
+
+	return w.WriteBatch()
+}
+
+// readFrozen returns the item of the table for the block from the freezer,
+// or false if the block is not frozen
+func (s *Storage) readFrozen(t uint8, bn uint64, bh types.Hash) ([]byte, bool, error) {
+	if s.freezer == nil {
+		return nil, false, nil
+	}
+
+	return s.freezer.read(t, bn, bh)
+}
+
+// stopFreezer stops moving the blocks to the freezer and closes it
+func (s *Storage) stopFreezer() error {
+	if s.freezer == nil {
+		return nil
+	}
+
+	if s.freezerStop != nil {
+		close(s.freezerStop)
+		<-s.freezerDone
+	}
+
+	err := s.freezer.Close()
+	s.freezer = nil
+
+	return err
+}
@@ -0,0 +1,163 @@
+package storagev2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// historyPruneBatchSize is the maximal number of the blocks pruned at once
+	historyPruneBatchSize = uint64(1000)
+
+	// historyPruneInterval is the interval of checking for the blocks to prune
+	historyPruneInterval = 10 * time.Second
+)
+
+// StartHistoryPruner removes the bodies, receipts and transaction lookups of the canonical blocks
+// older than the retention in the background, keeping their headers. The reads of the removed data
+// fail with ErrHistoryPruned. The blocks are pruned only once the bloom bits section (of the given size)
+// they belong to is indexed, since the index is built from the receipts.
+// Must be called before the storage is used, the pruner is stopped along with the storage
+func (s *Storage) StartHistoryPruner(retention, bloomSectionSize uint64) {
+	s.historyStop = make(chan struct{})
+	s.historyDone = make(chan struct{})
+
+	go s.runHistoryPruner(retention, bloomSectionSize)
+}
+
+// ReadHistoryTail returns the number of the first block whose history is not pruned
+func (s *Storage) ReadHistoryTail() uint64 {
+	data, ok := s.get(HISTORY_TAIL, HISTORY_TAIL_KEY)
+	if !ok || len(data) != 8 {
+		return 0
+	}
+
+	return common.EncodeBytesToUint64(data)
+}
+
+// PutHistoryTail writes the number of the first block whose history is not pruned
+func (w *Writer) PutHistoryTail(bn uint64) {
+	w.putIntoTable(HISTORY_TAIL, HISTORY_TAIL_KEY, common.EncodeUint64ToBytes(bn))
+}
+
+func (s *Storage) runHistoryPruner(retention, bloomSectionSize uint64) {
+	defer close(s.historyDone)
+
+	ticker := time.NewTicker(historyPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		// catch up with the head in batches, unless stopped
+		for {
+			pruned, err := s.PruneHistory(retention, bloomSectionSize)
+			if err != nil {
+				s.logger.Error("failed to prune the history", "err", err)
+			}
+
+			if err != nil || pruned < historyPruneBatchSize {
+				break
+			}
+
+			select {
+			case <-s.historyStop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-s.historyStop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PruneHistory removes the history of a batch of the canonical blocks older than the retention,
+// which are already covered by the bloom bits index, returning the number of the pruned blocks
+func (s *Storage) PruneHistory(retention, bloomSectionSize uint64) (uint64, error) {
+	head, ok := s.ReadHeadNumber()
+	if !ok || head < retention {
+		return 0, nil
+	}
+
+	// the receipts of the blocks which are not indexed yet are still needed
+	sections, _ := s.ReadBloomSections()
+
+	from := s.ReadHistoryTail()
+	to := min(head-retention+1, from+historyPruneBatchSize, sections*bloomSectionSize)
+
+	if from >= to {
+		return 0, nil
+	}
+
+	// the entries are collected before the batch is opened, as some databases
+	// don't allow to read in the middle of a write transaction
+	var (
+		keys     = make([][]byte, 0, to-from)
+		txHashes []types.Hash
+	)
+
+	for bn := from; bn < to; bn++ {
+		hash, ok := s.ReadCanonicalHash(bn)
+		if !ok {
+			return 0, fmt.Errorf("canonical hash of block %d not found", bn)
+		}
+
+		keys = append(keys, getKey(bn, hash))
+
+		body, err := s.ReadBody(bn, hash)
+		if err != nil {
+			// the block has no body
+			continue
+		}
+
+		for _, tx := range body.Transactions {
+			// the lookup may point to the other block including the same transaction
+			if lookup, err := s.ReadTxLookup(tx.Hash()); err == nil && lookup == bn {
+				txHashes = append(txHashes, tx.Hash())
+			}
+		}
+	}
+
+	w := s.NewWriter()
+
+	for _, key := range keys {
+		w.deleteFromTable(BODY, key)
+		w.deleteFromTable(RECEIPTS, key)
+	}
+
+	for _, hash := range txHashes {
+		w.deleteFromTable(TX_LOOKUP, hash.Bytes())
+	}
+
+	w.PutHistoryTail(to)
+
+	if err := w.WriteBatch(); err != nil {
+		return 0, err
+	}
+
+	s.logger.Debug("pruned history", "from", from, "to", to-1, "txs", len(txHashes))
+
+	return to - from, nil
+}
+
+// historyPruned checks if the history of the block is pruned
+func (s *Storage) historyPruned(bn uint64) bool {
+	return bn < s.ReadHistoryTail()
+}
+
+// stopHistoryPruner stops removing the history of the blocks
+func (s *Storage) stopHistoryPruner() {
+	if s.historyStop == nil {
+		return
+	}
+
+	close(s.historyStop)
+	<-s.historyDone
+
+	s.historyStop = nil
+}
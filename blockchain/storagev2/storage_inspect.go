@@ -0,0 +1,163 @@
+package storagev2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// TableNames are the names of the tables, used when inspecting the database
+var TableNames = map[uint8]string{
+	BODY:           "body",
+	CANONICAL:      "canonical",
+	DIFFICULTY:     "difficulty",
+	HEADER:         "header",
+	RECEIPTS:       "receipts",
+	FORK:           "fork",
+	HEAD_HASH:      "head_hash",
+	HEAD_NUMBER:    "head_number",
+	BLOCK_LOOKUP:   "block_lookup",
+	TX_LOOKUP:      "tx_lookup",
+	BLOOM_BITS:     "bloom_bits",
+	BLOOM_SECTIONS: "bloom_sections",
+	HISTORY_TAIL:   "history_tail",
+}
+
+// TableStats is the number of the entries of the table and their total size
+type TableStats struct {
+	Table       uint8
+	Name        string
+	Keys        uint64
+	Size        uint64 // total size of the keys and the values
+	Unsupported bool   // the database can't iterate the table
+}
+
+// VerifyResult is the outcome of the consistency check of the canonical chain
+type VerifyResult struct {
+	Head         uint64
+	Blocks       uint64   // number of the checked blocks
+	Transactions uint64   // number of the checked transactions
+	IssueCount   uint64   // number of the found issues
+	Issues       []string // descriptions of the found issues, up to the limit
+}
+
+func (r *VerifyResult) addIssue(maxIssues int, format string, args ...interface{}) {
+	r.IssueCount++
+
+	if len(r.Issues) < maxIssues {
+		r.Issues = append(r.Issues, fmt.Sprintf(format, args...))
+	}
+}
+
+// InspectTables counts the entries of the tables and their total size.
+// The tables the database can't iterate are reported as unsupported
+func (s *Storage) InspectTables() ([]*TableStats, error) {
+	stats := make([]*TableStats, 0, len(TableNames))
+
+	for t := uint8(0); t < MAX_TABLES; t++ {
+		name, ok := TableNames[t]
+		if !ok {
+			continue
+		}
+
+		table := &TableStats{Table: t, Name: name}
+
+		err := s.getDB(t).Iterate(t, nil, func(k, v []byte) bool {
+			table.Keys++
+			table.Size += uint64(len(k) + len(v))
+
+			return true
+		})
+		if errors.Is(err, ErrIterationNotSupported) {
+			table.Unsupported = true
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to iterate table %s: %w", name, err)
+		}
+
+		stats = append(stats, table)
+	}
+
+	return stats, nil
+}
+
+// VerifyChain checks that the canonical chain is continuous from the genesis up to the head,
+// and that the block and transaction lookups match the canonical blocks.
+// The bodies removed by the history pruning are not checked
+func (s *Storage) VerifyChain(maxIssues int) (*VerifyResult, error) {
+	head, ok := s.ReadHeadNumber()
+	if !ok {
+		return nil, fmt.Errorf("head number: %w", ErrNotFound)
+	}
+
+	res := &VerifyResult{Head: head}
+
+	if headHash, ok := s.ReadHeadHash(); !ok {
+		res.addIssue(maxIssues, "head hash not found")
+	} else if hash, ok := s.ReadCanonicalHash(head); ok && hash != headHash {
+		res.addIssue(maxIssues, "head hash %s differs from canonical hash %s of block %d", headHash, hash, head)
+	}
+
+	var parentHash types.Hash
+
+	for bn := uint64(0); bn <= head; bn++ {
+		res.Blocks++
+
+		hash, ok := s.ReadCanonicalHash(bn)
+		if !ok {
+			res.addIssue(maxIssues, "canonical hash of block %d not found", bn)
+
+			parentHash = types.ZeroHash
+
+			continue
+		}
+
+		header, err := s.ReadHeader(bn, hash)
+		if err != nil {
+			res.addIssue(maxIssues, "header of block %d (%s) not readable: %v", bn, hash, err)
+		} else {
+			if header.Number != bn {
+				res.addIssue(maxIssues, "header of block %d (%s) has number %d", bn, hash, header.Number)
+			}
+
+			if bn > 0 && parentHash != types.ZeroHash && header.ParentHash != parentHash {
+				res.addIssue(maxIssues, "block %d (%s) doesn't follow block %d (%s)", bn, hash, bn-1, parentHash)
+			}
+		}
+
+		if lookup, err := s.ReadBlockLookup(hash); err != nil {
+			res.addIssue(maxIssues, "block lookup of block %d (%s) not readable: %v", bn, hash, err)
+		} else if lookup != bn {
+			res.addIssue(maxIssues, "block lookup of block %d (%s) points to block %d", bn, hash, lookup)
+		}
+
+		parentHash = hash
+
+		body, err := s.ReadBody(bn, hash)
+		if err != nil {
+			// the genesis and the pruned blocks have no body
+			if bn > 0 && !errors.Is(err, ErrHistoryPruned) {
+				res.addIssue(maxIssues, "body of block %d (%s) not readable: %v", bn, hash, err)
+			}
+
+			continue
+		}
+
+		for _, tx := range body.Transactions {
+			res.Transactions++
+
+			if lookup, err := s.ReadTxLookup(tx.Hash()); err != nil {
+				res.addIssue(maxIssues, "lookup of transaction %s of block %d not readable: %v", tx.Hash(), bn, err)
+			} else if lookup != bn {
+				res.addIssue(maxIssues, "lookup of transaction %s of block %d points to block %d", tx.Hash(), bn, lookup)
+			}
+		}
+	}
+
+	// the canonical blocks above the head are left over from an interrupted write or rewind
+	if hash, ok := s.ReadCanonicalHash(head + 1); ok {
+		res.addIssue(maxIssues, "canonical hash %s of block %d found above the head", hash, head+1)
+	}
+
+	return res, nil
+}
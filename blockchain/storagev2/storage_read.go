@@ -72,7 +72,7 @@ func (s *Storage) ReadTotalDifficulty(bn uint64, bh types.Hash) (*big.Int, bool)
 // ReadHeader reads the header
 func (s *Storage) ReadHeader(bn uint64, bh types.Hash) (*types.Header, error) {
 	header := &types.Header{}
-	err := s.readRLP(HEADER, getKey(bn, bh), header)
+	err := s.readBlockRLP(HEADER, bn, bh, header)
 
 	return header, err
 }
@@ -82,13 +82,13 @@ func (s *Storage) ReadHeader(bn uint64, bh types.Hash) (*types.Header, error) {
 // ReadBody reads the body
 func (s *Storage) ReadBody(bn uint64, bh types.Hash) (*types.Body, error) {
 	body := &types.Body{}
-	if err := s.readRLP(BODY, getKey(bn, bh), body); err != nil {
+	if err := s.readBlockRLP(BODY, bn, bh, body); err != nil {
 		return nil, err
 	}
 
 	// must read header because block number is needed in order to calculate each tx hash
 	header := &types.Header{}
-	if err := s.readRLP(HEADER, getKey(bn, bh), header); err != nil {
+	if err := s.readBlockRLP(HEADER, bn, bh, header); err != nil {
 		return nil, err
 	}
 
@@ -104,7 +104,7 @@ func (s *Storage) ReadBody(bn uint64, bh types.Hash) (*types.Body, error) {
 // ReadReceipts reads the receipts
 func (s *Storage) ReadReceipts(bn uint64, bh types.Hash) ([]*types.Receipt, error) {
 	receipts := &types.Receipts{}
-	err := s.readRLP(RECEIPTS, getKey(bn, bh), receipts)
+	err := s.readBlockRLP(RECEIPTS, bn, bh, receipts)
 
 	return *receipts, err
 }
@@ -123,6 +123,27 @@ func (s *Storage) ReadBlockLookup(hash types.Hash) (uint64, error) {
 	return s.readLookup(BLOCK_LOOKUP, hash)
 }
 
+// BLOOM BITS //
+
+// ReadBloomBits reads the (compressed) bit vector of the given bloom bit for the given section
+func (s *Storage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	return s.get(BLOOM_BITS, getBloomBitsKey(bit, section))
+}
+
+// ReadBloomSections reads the number of sections processed by the bloom bits indexer
+func (s *Storage) ReadBloomSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_SECTIONS, BLOOM_SECTIONS_KEY)
+	if !ok {
+		return 0, false
+	}
+
+	if len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
 func (s *Storage) readLookup(t uint8, hash types.Hash) (uint64, error) {
 	data, ok := s.get(t, hash.Bytes())
 	if !ok {
@@ -147,6 +168,36 @@ func (s *Storage) readRLP(t uint8, k []byte, raw types.RLPUnmarshaler) error {
 		return ErrNotFound
 	}
 
+	return unmarshalRLP(data, raw)
+}
+
+// readBlockRLP reads the item of the block from the main database,
+// falling back to the freezer for the blocks moved there
+// and telling apart the bodies and receipts removed by the history pruning
+func (s *Storage) readBlockRLP(t uint8, bn uint64, bh types.Hash, raw types.RLPUnmarshaler) error {
+	data, ok, err := s.getDB(t).Get(t, getKey(bn, bh))
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		if data, ok, err = s.readFrozen(t, bn, bh); err != nil {
+			return err
+		}
+
+		if !ok {
+			if t != HEADER && s.historyPruned(bn) {
+				return ErrHistoryPruned
+			}
+
+			return ErrNotFound
+		}
+	}
+
+	return unmarshalRLP(data, raw)
+}
+
+func unmarshalRLP(data []byte, raw types.RLPUnmarshaler) error {
 	if obj, ok := raw.(types.RLPStoreUnmarshaler); ok {
 		// decode in the store format
 		if err := obj.UnmarshalStoreRLP(data); err != nil {
@@ -0,0 +1,193 @@
+package storagev2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// repairBatchSize is the maximal number of the blocks rewritten at once when repairing the database
+const repairBatchSize = uint64(1000)
+
+// Rewind sets the head of the chain back to the canonical block with the number, removing the newer
+// canonical blocks along with their lookups. The forks above the block are dropped.
+// It can be repeated, if it was interrupted
+func (s *Storage) Rewind(number uint64) error {
+	head, ok := s.ReadHeadNumber()
+	if !ok {
+		return fmt.Errorf("head number: %w", ErrNotFound)
+	}
+
+	if number > head {
+		return fmt.Errorf("block %d is above the head %d", number, head)
+	}
+
+	hash, ok := s.ReadCanonicalHash(number)
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d: %w", number, ErrNotFound)
+	}
+
+	forks, err := s.ReadForks()
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	keptForks := make([]types.Hash, 0, len(forks))
+
+	for _, fork := range forks {
+		if bn, err := s.ReadBlockLookup(fork); err == nil && bn <= number {
+			keptForks = append(keptForks, fork)
+		}
+	}
+
+	tail := s.ReadHistoryTail()
+
+	// the head is moved first, so that the removed blocks are never referenced
+	w := s.NewWriter()
+	w.PutHeadHash(hash)
+	w.PutHeadNumber(number)
+	w.PutForks(keptForks)
+
+	if tail > number+1 {
+		w.PutHistoryTail(number + 1)
+	}
+
+	if err := w.WriteBatch(); err != nil {
+		return err
+	}
+
+	// the blocks are removed until the first missing one,
+	// covering the blocks left over from an interrupted rewind
+	for from := number + 1; ; from += repairBatchSize {
+		removed, err := s.removeCanonicalBlocks(from, from+repairBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if removed < repairBatchSize {
+			break
+		}
+	}
+
+	if s.freezer != nil {
+		return s.freezer.rewind(number + 1)
+	}
+
+	return nil
+}
+
+// removeCanonicalBlocks removes the consecutive canonical blocks in the range, along with their lookups,
+// returning the number of the removed blocks
+func (s *Storage) removeCanonicalBlocks(from, to uint64) (uint64, error) {
+	// the entries are collected before the batch is opened, as some databases
+	// don't allow to read in the middle of a write transaction
+	var (
+		blocks   = make(map[uint64]types.Hash, to-from)
+		txHashes []types.Hash
+	)
+
+	for bn := from; bn < to; bn++ {
+		hash, ok := s.ReadCanonicalHash(bn)
+		if !ok {
+			break
+		}
+
+		blocks[bn] = hash
+
+		if body, err := s.ReadBody(bn, hash); err == nil {
+			for _, tx := range body.Transactions {
+				txHashes = append(txHashes, tx.Hash())
+			}
+		}
+	}
+
+	if len(blocks) == 0 {
+		return 0, nil
+	}
+
+	w := s.NewWriter()
+
+	for bn, hash := range blocks {
+		key := getKey(bn, hash)
+
+		for _, t := range []uint8{HEADER, BODY, RECEIPTS, DIFFICULTY} {
+			w.deleteFromTable(t, key)
+		}
+
+		w.deleteFromTable(CANONICAL, common.EncodeUint64ToBytes(bn))
+		w.deleteFromTable(BLOCK_LOOKUP, hash.Bytes())
+	}
+
+	for _, hash := range txHashes {
+		w.deleteFromTable(TX_LOOKUP, hash.Bytes())
+	}
+
+	if err := w.WriteBatch(); err != nil {
+		return 0, err
+	}
+
+	return uint64(len(blocks)), nil
+}
+
+// RebuildLookups rewrites the block and transaction lookups of the canonical chain from the bodies,
+// returning the number of the blocks and the transactions. The pruned bodies are skipped
+func (s *Storage) RebuildLookups() (uint64, uint64, error) {
+	head, ok := s.ReadHeadNumber()
+	if !ok {
+		return 0, 0, fmt.Errorf("head number: %w", ErrNotFound)
+	}
+
+	var blocks, txs uint64
+
+	for from := uint64(0); from <= head; from += repairBatchSize {
+		to := min(from+repairBatchSize, head+1)
+
+		// the entries are collected before the batch is opened, see removeCanonicalBlocks
+		hashes := make(map[uint64]types.Hash, to-from)
+		lookups := make(map[types.Hash]uint64)
+
+		for bn := from; bn < to; bn++ {
+			hash, ok := s.ReadCanonicalHash(bn)
+			if !ok {
+				return blocks, txs, fmt.Errorf("canonical hash of block %d: %w", bn, ErrNotFound)
+			}
+
+			hashes[bn] = hash
+
+			body, err := s.ReadBody(bn, hash)
+			if err != nil {
+				// the genesis and the pruned blocks have no body
+				if bn > 0 && !errors.Is(err, ErrHistoryPruned) {
+					return blocks, txs, fmt.Errorf("body of block %d: %w", bn, err)
+				}
+
+				continue
+			}
+
+			for _, tx := range body.Transactions {
+				lookups[tx.Hash()] = bn
+			}
+		}
+
+		w := s.NewWriter()
+
+		for bn, hash := range hashes {
+			w.PutBlockLookup(hash, bn)
+		}
+
+		for hash, bn := range lookups {
+			w.PutTxLookup(hash, bn)
+		}
+
+		if err := w.WriteBatch(); err != nil {
+			return blocks, txs, err
+		}
+
+		blocks += uint64(len(hashes))
+		txs += uint64(len(lookups))
+	}
+
+	return blocks, txs, nil
+}
@@ -1,6 +1,7 @@
 package storagev2
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -60,6 +61,14 @@ func (w *Writer) PutForks(forks []types.Hash) {
 	w.putRlp(FORK, FORK_KEY, &fs)
 }
 
+func (w *Writer) PutBloomBits(bit uint, section uint64, bits []byte) {
+	w.putIntoTable(BLOOM_BITS, getBloomBitsKey(bit, section), bits)
+}
+
+func (w *Writer) PutBloomSections(sections uint64) {
+	w.putIntoTable(BLOOM_SECTIONS, BLOOM_SECTIONS_KEY, common.EncodeUint64ToBytes(sections))
+}
+
 func (w *Writer) putRlp(t uint8, k []byte, raw types.RLPMarshaler) {
 	var data []byte
 
@@ -76,6 +85,10 @@ func (w *Writer) putIntoTable(t uint8, k []byte, data []byte) {
 	w.getBatch(t).Put(t, k, data)
 }
 
+func (w *Writer) deleteFromTable(t uint8, k []byte) {
+	w.getBatch(t).Delete(t, k)
+}
+
 func (w *Writer) WriteBatch() error {
 	for i, b := range w.batch {
 		if b != nil {
@@ -105,3 +118,11 @@ func getKey(n uint64, h types.Hash) []byte {
 
 	return append(append(make([]byte, 0, len(a)+len(b)), a...), b...)
 }
+
+func getBloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 10)
+	binary.BigEndian.PutUint16(key[0:2], uint16(bit))
+	binary.BigEndian.PutUint64(key[2:], section)
+
+	return key
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +53,21 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testFreezer", func(t *testing.T) {
+		testFreezer(t, m)
+	})
+	t.Run("testDeleteAndIterate", func(t *testing.T) {
+		testDeleteAndIterate(t, m)
+	})
+	t.Run("testHistoryPruning", func(t *testing.T) {
+		testHistoryPruning(t, m)
+	})
+	t.Run("testInspectAndVerify", func(t *testing.T) {
+		testInspectAndVerify(t, m)
+	})
+	t.Run("testRewind", func(t *testing.T) {
+		testRewind(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -436,6 +452,367 @@ func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testFreezer(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn, _ := m(t)
+	defer closeFn()
+
+	blocks := writeTestBlocks(t, s, 10)
+
+	dir := t.TempDir()
+
+	freezer, err := OpenFreezer(dir)
+	require.NoError(t, err)
+
+	s.freezer = freezer
+
+	// the blocks older than the 4 most recent ones are moved
+	moved, err := s.freeze(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), moved)
+	assert.Equal(t, uint64(6), freezer.Frozen())
+
+	moved, err = s.freeze(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), moved)
+
+	assertBlocks := func() {
+		t.Helper()
+
+		for _, block := range blocks {
+			header, err := s.ReadHeader(block.Block.Number(), block.Block.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, block.Block.Header, header)
+
+			body, err := s.ReadBody(block.Block.Number(), block.Block.Hash())
+			require.NoError(t, err)
+			require.Len(t, body.Transactions, 1)
+			assert.Equal(t, block.Block.Transactions[0].Hash(), body.Transactions[0].Hash())
+
+			receipts, err := s.ReadReceipts(block.Block.Number(), block.Block.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, block.Receipts, receipts)
+		}
+	}
+
+	assertBlocks()
+
+	// the moved blocks are deleted from the main database
+	_, ok, err := s.getDB(HEADER).Get(HEADER, getKey(2, blocks[2].Block.Hash()))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = s.getDB(HEADER).Get(HEADER, getKey(6, blocks[6].Block.Hash()))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the frozen blocks are looked up by the hash
+	_, err = s.ReadHeader(2, blocks[3].Block.Hash())
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, freezer.Close())
+
+	// the partially frozen block is dropped on the reopening
+	table, err := openFreezerTable(dir, freezerTables[HEADER], true)
+	require.NoError(t, err)
+	require.NoError(t, table.append([]byte{0x1}))
+	require.NoError(t, table.sync())
+	require.NoError(t, table.close())
+
+	freezer, err = OpenFreezer(dir)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), freezer.Frozen())
+
+	s.freezer = freezer
+
+	assertBlocks()
+}
+
+func testDeleteAndIterate(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn, _ := m(t)
+	defer closeFn()
+
+	blocks := writeTestBlocks(t, s, 5)
+
+	db := s.getDB(CANONICAL)
+
+	iterate := func(table uint8, start []byte) [][]byte {
+		var keys [][]byte
+
+		require.NoError(t, db.Iterate(table, start, func(k, v []byte) bool {
+			keys = append(keys, append([]byte{}, k...))
+
+			return true
+		}))
+
+		return keys
+	}
+
+	// the canonical hashes are iterated in the order of the numbers, without the head entries
+	keys := iterate(CANONICAL, nil)
+	require.Len(t, keys, 5)
+
+	for i, key := range keys {
+		assert.Equal(t, common.EncodeUint64ToBytes(uint64(i)), key)
+	}
+
+	keys = iterate(HEADER, getKey(2, types.ZeroHash))
+	require.Len(t, keys, 3)
+	assert.Equal(t, getKey(2, blocks[2].Block.Hash()), keys[0])
+
+	keys = iterate(HEAD_NUMBER, nil)
+	assert.Equal(t, [][]byte{HEAD_NUMBER_KEY}, keys)
+
+	// the iteration stops once the function returns false
+	count := 0
+
+	require.NoError(t, db.Iterate(BODY, nil, func(k, v []byte) bool {
+		count++
+
+		return false
+	}))
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, db.Delete(HEADER, getKey(3, blocks[3].Block.Hash())))
+
+	_, err := s.ReadHeader(3, blocks[3].Block.Hash())
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Len(t, iterate(HEADER, nil), 4)
+
+	// deleting the missing key is not an error
+	require.NoError(t, db.Delete(HEADER, getKey(3, blocks[3].Block.Hash())))
+}
+
+func testHistoryPruning(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn, _ := m(t)
+	defer closeFn()
+
+	blocks := writeTestBlocks(t, s, 10)
+
+	putBloomSections := func(sections uint64) {
+		w := s.NewWriter()
+		w.PutBloomSections(sections)
+		require.NoError(t, w.WriteBatch())
+	}
+
+	// the history of the blocks which are not covered by the bloom bits index is kept
+	pruned, err := s.PruneHistory(4, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), pruned)
+
+	putBloomSections(1)
+
+	pruned, err = s.PruneHistory(4, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), pruned)
+	assert.Equal(t, uint64(4), s.ReadHistoryTail())
+
+	// the history of the blocks older than the 4 most recent ones is removed
+	putBloomSections(2)
+
+	pruned, err = s.PruneHistory(4, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), pruned)
+	assert.Equal(t, uint64(6), s.ReadHistoryTail())
+
+	pruned, err = s.PruneHistory(4, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), pruned)
+
+	for _, block := range blocks {
+		num, hash := block.Block.Number(), block.Block.Hash()
+
+		_, err := s.ReadHeader(num, hash)
+		require.NoError(t, err)
+
+		_, bodyErr := s.ReadBody(num, hash)
+		_, receiptsErr := s.ReadReceipts(num, hash)
+		_, lookupErr := s.ReadTxLookup(block.Block.Transactions[0].Hash())
+
+		if num < 6 {
+			assert.ErrorIs(t, bodyErr, ErrHistoryPruned)
+			assert.ErrorIs(t, receiptsErr, ErrHistoryPruned)
+			assert.ErrorIs(t, lookupErr, ErrNotFound)
+		} else {
+			assert.NoError(t, bodyErr)
+			assert.NoError(t, receiptsErr)
+			assert.NoError(t, lookupErr)
+		}
+	}
+
+	// the blocks missing the history for other reasons are not reported as pruned
+	_, err = s.ReadBody(6, blocks[5].Block.Hash())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func testInspectAndVerify(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn, _ := m(t)
+	defer closeFn()
+
+	blocks := writeTestBlocks(t, s, 5)
+
+	stats, err := s.InspectTables()
+	require.NoError(t, err)
+
+	tables := make(map[uint8]*TableStats, len(stats))
+	for _, table := range stats {
+		tables[table.Table] = table
+	}
+
+	require.Len(t, tables, len(TableNames))
+	assert.Equal(t, uint64(5), tables[HEADER].Keys)
+	assert.Equal(t, uint64(5), tables[CANONICAL].Keys)
+	assert.Equal(t, uint64(5*(8+32)), tables[CANONICAL].Size)
+	assert.Equal(t, uint64(1), tables[HEAD_NUMBER].Keys)
+	assert.Equal(t, uint64(0), tables[FORK].Keys)
+
+	res, err := s.VerifyChain(10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), res.Head)
+	assert.Equal(t, uint64(5), res.Blocks)
+	assert.Equal(t, uint64(5), res.Transactions)
+	assert.Empty(t, res.Issues)
+
+	// the broken lookups are reported
+	tx := blocks[2].Block.Transactions[0]
+
+	batch := s.NewWriter()
+	batch.PutTxLookup(tx.Hash(), 3)
+	batch.deleteFromTable(TX_LOOKUP, blocks[3].Block.Transactions[0].Hash().Bytes())
+	batch.deleteFromTable(BLOCK_LOOKUP, blocks[1].Block.Hash().Bytes())
+	require.NoError(t, batch.WriteBatch())
+
+	res, err = s.VerifyChain(2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), res.IssueCount)
+	assert.Len(t, res.Issues, 2)
+
+	// the lookups are repaired by rebuilding them
+	blockCount, txCount, err := s.RebuildLookups()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), blockCount)
+	assert.Equal(t, uint64(5), txCount)
+
+	res, err = s.VerifyChain(10)
+	require.NoError(t, err)
+	assert.Empty(t, res.Issues)
+
+	// the broken chain is reported
+	batch = s.NewWriter()
+	batch.deleteFromTable(CANONICAL, common.EncodeUint64ToBytes(2))
+	require.NoError(t, batch.WriteBatch())
+
+	res, err = s.VerifyChain(10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), res.IssueCount)
+}
+
+func testRewind(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn, _ := m(t)
+	defer closeFn()
+
+	blocks := writeTestBlocks(t, s, 10)
+
+	batch := s.NewWriter()
+	batch.PutForks([]types.Hash{blocks[2].Block.Hash(), blocks[8].Block.Hash()})
+	require.NoError(t, batch.WriteBatch())
+
+	require.Error(t, s.Rewind(10))
+	require.NoError(t, s.Rewind(5))
+
+	head, ok := s.ReadHeadNumber()
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), head)
+
+	headHash, ok := s.ReadHeadHash()
+	require.True(t, ok)
+	assert.Equal(t, blocks[5].Block.Hash(), headHash)
+
+	forks, err := s.ReadForks()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{blocks[2].Block.Hash()}, forks)
+
+	for _, block := range blocks {
+		num, hash := block.Block.Number(), block.Block.Hash()
+
+		_, canonical := s.ReadCanonicalHash(num)
+		_, headerErr := s.ReadHeader(num, hash)
+		_, blockLookupErr := s.ReadBlockLookup(hash)
+		_, txLookupErr := s.ReadTxLookup(block.Block.Transactions[0].Hash())
+
+		if num <= 5 {
+			assert.True(t, canonical)
+			assert.NoError(t, headerErr)
+			assert.NoError(t, blockLookupErr)
+			assert.NoError(t, txLookupErr)
+		} else {
+			assert.False(t, canonical)
+			assert.ErrorIs(t, headerErr, ErrNotFound)
+			assert.ErrorIs(t, blockLookupErr, ErrNotFound)
+			assert.ErrorIs(t, txLookupErr, ErrNotFound)
+		}
+	}
+
+	res, err := s.VerifyChain(10)
+	require.NoError(t, err)
+	assert.Empty(t, res.Issues)
+}
+
+// writeTestBlocks writes the canonical chain of the blocks with a transaction and a receipt each
+func writeTestBlocks(t *testing.T, s *Storage, count int) []*types.FullBlock {
+	t.Helper()
+
+	blocks := make([]*types.FullBlock, count)
+
+	for i := range blocks {
+		header := &types.Header{
+			Number:    uint64(i),
+			ExtraData: []byte{byte(i)},
+		}
+
+		if i > 0 {
+			header.ParentHash = blocks[i-1].Block.Hash()
+		}
+
+		header.ComputeHash()
+
+		tx := types.NewTx(types.NewLegacyTx(
+			types.WithNonce(uint64(i)),
+			types.WithTo(&addr1),
+			types.WithValue(big.NewInt(1)),
+			types.WithGas(21000),
+			types.WithGasPrice(big.NewInt(1)),
+			types.WithSignatureValues(big.NewInt(1), nil, nil),
+		))
+		tx.ComputeHash()
+
+		blocks[i] = &types.FullBlock{
+			Block: &types.Block{Header: header, Transactions: []*types.Transaction{tx}},
+			Receipts: []*types.Receipt{
+				{CumulativeGasUsed: 21000, TxHash: tx.Hash(), Logs: []*types.Log{{Address: addr2, Topics: []types.Hash{hash1}}}},
+			},
+		}
+
+		batch := s.NewWriter()
+		batch.PutCanonicalHeader(header, big.NewInt(int64(i)))
+		batch.PutBody(header.Number, header.Hash, blocks[i].Block.Body())
+		batch.PutReceipts(header.Number, header.Hash, blocks[i].Receipts)
+		batch.PutTxLookup(tx.Hash(), header.Number)
+		require.NoError(t, batch.WriteBatch())
+	}
+
+	return blocks
+}
+
 func generateTxs(t *testing.T, startNonce, count int, from types.Address, to *types.Address) []*types.Transaction {
 	t.Helper()
 
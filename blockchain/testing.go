@@ -301,8 +301,11 @@ func (m *MockVerifier) HookPreCommitState(fn preStateCommitDelegate) {
 
 type processBlockDelegate func(types.Hash, *types.Block, types.Address) (*state.Transition, error)
 
+type hasStateDelegate func(types.Hash) bool
+
 type mockExecutor struct {
 	processBlockFn processBlockDelegate
+	hasStateFn     hasStateDelegate
 }
 
 func (m *mockExecutor) ProcessBlock(
@@ -321,6 +324,18 @@ func (m *mockExecutor) HookProcessBlock(fn processBlockDelegate) {
 	m.processBlockFn = fn
 }
 
+func (m *mockExecutor) HasState(root types.Hash) bool {
+	if m.hasStateFn != nil {
+		return m.hasStateFn(root)
+	}
+
+	return true
+}
+
+func (m *mockExecutor) HookHasState(fn hasStateDelegate) {
+	m.hasStateFn = fn
+}
+
 type mockSigner struct {
 	txFromByTxHash map[types.Hash]types.Address
 }
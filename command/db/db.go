@@ -0,0 +1,38 @@
+package db
+
+import (
+	"github.com/spf13/cobra"
+
+	dbHelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+	"github.com/0xPolygon/polygon-edge/command/db/inspect"
+	lookups "github.com/0xPolygon/polygon-edge/command/db/rebuild-lookups"
+	"github.com/0xPolygon/polygon-edge/command/db/rewind"
+	"github.com/0xPolygon/polygon-edge/command/db/verify"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use: "db",
+		Short: "Top level command for inspecting and repairing the blockchain database of a stopped node. " +
+			"Only accepts subcommands.",
+	}
+
+	dbHelper.RegisterDataDirFlag(dbCmd)
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db inspect
+		inspect.GetCommand(),
+		// db verify
+		verify.GetCommand(),
+		// db rewind
+		rewind.GetCommand(),
+		// db rebuild-lookups
+		lookups.GetCommand(),
+	)
+}
@@ -0,0 +1,139 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+const (
+	DataDirFlag = "data-dir"
+)
+
+// RegisterDataDirFlag registers the data directory flag for all the db subcommands
+func RegisterDataDirFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		DataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	_ = cmd.MarkPersistentFlagRequired(DataDirFlag)
+}
+
+// GetDataDir extracts the set data directory
+func GetDataDir(cmd *cobra.Command) string {
+	return cmd.Flag(DataDirFlag).Value.String()
+}
+
+// OpenStorage opens the blockchain database of the stopped node,
+// along with the freezer holding its finalized blocks, if there is one
+func OpenStorage(dataDir string, name string) (*storagev2.Storage, error) {
+	path := filepath.Join(dataDir, "blockchain")
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("blockchain database not found in '%s'", dataDir)
+	}
+
+	return OpenOrCreateStorage(dataDir, name)
+}
+
+// OpenOrCreateStorage opens the blockchain database of the stopped node like OpenStorage does,
+// creating it if the data directory doesn't have one yet
+func OpenOrCreateStorage(dataDir string, name string) (*storagev2.Storage, error) {
+	path := filepath.Join(dataDir, "blockchain")
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  name,
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := leveldb.NewLevelDBStorage(path, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blockchain database: %w", err)
+	}
+
+	ancientPath := filepath.Join(path, "ancient")
+
+	if _, err := os.Stat(ancientPath); err == nil {
+		freezer, err := storagev2.OpenFreezer(ancientPath)
+		if err != nil {
+			_ = db.Close()
+
+			return nil, fmt.Errorf("failed to open freezer: %w", err)
+		}
+
+		db.AttachFreezer(freezer)
+	}
+
+	return db, nil
+}
+
+// TrimBloomSections drops the sections of the bloom bits index covering the blocks after the given one,
+// so that they are indexed again once the node is started
+func TrimBloomSections(db *storagev2.Storage, number uint64) error {
+	sections, ok := db.ReadBloomSections()
+	if !ok || sections*bloombits.DefaultSectionSize <= number+1 {
+		return nil
+	}
+
+	writer := db.NewWriter()
+	writer.PutBloomSections((number + 1) / bloombits.DefaultSectionSize)
+
+	return writer.WriteBatch()
+}
+
+// RewindChain sets the head of the chain of the stopped node back to the block with the given number,
+// once its state is found in the trie database. The bloom bits index and the consensus data
+// derived from the removed blocks are dropped along with them
+func RewindChain(dataDir string, db *storagev2.Storage, number uint64) error {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d: %w", number, storagev2.ErrNotFound)
+	}
+
+	header, err := db.ReadHeader(number, hash)
+	if err != nil {
+		return fmt.Errorf("header of block %d: %w", number, err)
+	}
+
+	path := filepath.Join(dataDir, "trie")
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("trie database not found in '%s'", dataDir)
+	}
+
+	stateStorage, err := itrie.NewLevelDBStorage(path, hclog.NewNullLogger())
+	if err != nil {
+		return fmt.Errorf("failed to open trie database: %w", err)
+	}
+	defer stateStorage.Close()
+
+	if _, err := itrie.NewState(stateStorage).NewSnapshot(header.StateRoot); err != nil {
+		return fmt.Errorf("state %s of block %d: %w", header.StateRoot, number, err)
+	}
+
+	if err := db.Rewind(number); err != nil {
+		return err
+	}
+
+	if err := TrimBloomSections(db, number); err != nil {
+		return err
+	}
+
+	if err := polybft.ResetState(filepath.Join(dataDir, "consensus"), number); err != nil {
+		return fmt.Errorf("failed to reset consensus state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package inspect
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/command"
+	dbHelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+)
+
+var (
+	errHeadNotFound = errors.New("unable to read the head of the chain")
+)
+
+func GetCommand() *cobra.Command {
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Prints the head, the forks and the number and the size of the entries of each table",
+		Run:   runCommand,
+	}
+
+	return inspectCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	res, err := inspect(dbHelper.GetDataDir(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(res)
+}
+
+func inspect(dataDir string) (*InspectResult, error) {
+	db, err := dbHelper.OpenStorage(dataDir, "db-inspect")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	headNumber, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	headHash, ok := db.ReadHeadHash()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	forks, err := db.ReadForks()
+	if err != nil && !errors.Is(err, storagev2.ErrNotFound) {
+		return nil, err
+	}
+
+	tables, err := db.InspectTables()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &InspectResult{
+		HeadNumber:  headNumber,
+		HeadHash:    headHash.String(),
+		Forks:       make([]string, len(forks)),
+		HistoryTail: db.ReadHistoryTail(),
+		Tables:      make([]*TableResult, len(tables)),
+	}
+
+	for i, fork := range forks {
+		res.Forks[i] = fork.String()
+	}
+
+	if freezer := db.Freezer(); freezer != nil {
+		res.Frozen = freezer.Frozen()
+	}
+
+	for i, table := range tables {
+		res.Tables[i] = &TableResult{
+			Name:        table.Name,
+			Keys:        table.Keys,
+			Size:        table.Size,
+			Unsupported: table.Unsupported,
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,67 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type InspectResult struct {
+	HeadNumber  uint64         `json:"head_number"`
+	HeadHash    string         `json:"head_hash"`
+	Forks       []string       `json:"forks"`
+	HistoryTail uint64         `json:"history_tail"`
+	Frozen      uint64         `json:"frozen"`
+	Tables      []*TableResult `json:"tables"`
+}
+
+type TableResult struct {
+	Name        string `json:"name"`
+	Keys        uint64 `json:"keys"`
+	Size        uint64 `json:"size"`
+	Unsupported bool   `json:"unsupported,omitempty"`
+}
+
+func (r *InspectResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB INSPECT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head number|%d", r.HeadNumber),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("History tail|%d", r.HistoryTail),
+		fmt.Sprintf("Frozen blocks|%d", r.Frozen),
+	}))
+
+	buffer.WriteString("\n\n[FORKS]\n")
+
+	if len(r.Forks) == 0 {
+		buffer.WriteString("No forks found")
+	} else {
+		rows := make([]string, len(r.Forks))
+		for i, fork := range r.Forks {
+			rows[i] = fmt.Sprintf("[%d]|%s", i, fork)
+		}
+
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n\n[TABLES]\n")
+
+	rows := make([]string, 0, len(r.Tables)+1)
+	rows = append(rows, "Table|Keys|Size")
+
+	for _, table := range r.Tables {
+		if table.Unsupported {
+			rows = append(rows, fmt.Sprintf("%s|n/a|n/a", table.Name))
+		} else {
+			rows = append(rows, fmt.Sprintf("%s|%d|%d", table.Name, table.Keys, table.Size))
+		}
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,43 @@
+package lookups
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	dbHelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+)
+
+func GetCommand() *cobra.Command {
+	rebuildCmd := &cobra.Command{
+		Use:   "rebuild-lookups",
+		Short: "Rewrites the block and transaction lookups of the canonical chain from the stored blocks",
+		Run:   runCommand,
+	}
+
+	return rebuildCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	db, err := dbHelper.OpenStorage(dbHelper.GetDataDir(cmd), "db-rebuild-lookups")
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+	defer db.Close()
+
+	blocks, txs, err := db.RebuildLookups()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&RebuildLookupsResult{
+		Blocks:       blocks,
+		Transactions: txs,
+	})
+}
@@ -0,0 +1,27 @@
+package lookups
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RebuildLookupsResult struct {
+	Blocks       uint64 `json:"blocks"`
+	Transactions uint64 `json:"transactions"`
+}
+
+func (r *RebuildLookupsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB REBUILD LOOKUPS]\n")
+	buffer.WriteString("Lookups rebuilt successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,19 @@
+package rewind
+
+const (
+	numberFlag = "number"
+)
+
+var (
+	params = &rewindParams{}
+)
+
+type rewindParams struct {
+	number uint64
+}
+
+func (p *rewindParams) getRequiredFlags() []string {
+	return []string{
+		numberFlag,
+	}
+}
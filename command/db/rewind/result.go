@@ -0,0 +1,31 @@
+package rewind
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RewindResult struct {
+	PreviousHead uint64 `json:"previous_head"`
+	Head         uint64 `json:"head"`
+	HeadHash     string `json:"head_hash"`
+	StateRoot    string `json:"state_root"`
+}
+
+func (r *RewindResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB REWIND]\n")
+	buffer.WriteString("Chain rewound successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Previous head|%d", r.PreviousHead),
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,88 @@
+package rewind
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	dbHelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+var (
+	errHeadNotFound = errors.New("unable to read the head of the chain")
+)
+
+func GetCommand() *cobra.Command {
+	rewindCmd := &cobra.Command{
+		Use: "rewind",
+		Short: "Sets the head of the chain back to the block with the given number, removing the newer blocks " +
+			"and resetting the consensus data to match. The state of the block must be available. " +
+			"The removed blocks are synced again once the node is started",
+		Run: runCommand,
+	}
+
+	setFlags(rewindCmd)
+	helper.SetRequiredFlags(rewindCmd, params.getRequiredFlags())
+
+	return rewindCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.number,
+		numberFlag,
+		0,
+		"the number of the block to become the new head",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	res, err := rewind(dbHelper.GetDataDir(cmd), params.number)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(res)
+}
+
+func rewind(dataDir string, number uint64) (*RewindResult, error) {
+	db, err := dbHelper.OpenStorage(dataDir, "db-rewind")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	if err := dbHelper.RewindChain(dataDir, db, number); err != nil {
+		return nil, err
+	}
+
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := db.ReadHeader(number, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of block %d: %w", number, err)
+	}
+
+	return &RewindResult{
+		PreviousHead: head,
+		Head:         number,
+		HeadHash:     hash.String(),
+		StateRoot:    header.StateRoot.String(),
+	}, nil
+}
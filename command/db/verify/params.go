@@ -0,0 +1,13 @@
+package verify
+
+const (
+	maxIssuesFlag = "max-issues"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+type verifyParams struct {
+	maxIssues int
+}
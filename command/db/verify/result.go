@@ -0,0 +1,47 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type VerifyResult struct {
+	Valid        bool     `json:"valid"`
+	Head         uint64   `json:"head"`
+	Blocks       uint64   `json:"blocks"`
+	Transactions uint64   `json:"transactions"`
+	IssueCount   uint64   `json:"issue_count"`
+	Issues       []string `json:"issues"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB VERIFY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Valid|%t", r.Valid),
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Checked blocks|%d", r.Blocks),
+		fmt.Sprintf("Checked transactions|%d", r.Transactions),
+		fmt.Sprintf("Issues|%d", r.IssueCount),
+	}))
+
+	if len(r.Issues) > 0 {
+		buffer.WriteString("\n\n[ISSUES]\n")
+
+		for _, issue := range r.Issues {
+			buffer.WriteString(issue)
+			buffer.WriteString("\n")
+		}
+
+		if missing := r.IssueCount - uint64(len(r.Issues)); missing > 0 {
+			buffer.WriteString(fmt.Sprintf("... and %d more\n", missing))
+		}
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
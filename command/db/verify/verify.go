@@ -0,0 +1,66 @@
+package verify
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	dbHelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+)
+
+// defaultMaxIssues is the default number of the reported issues
+const defaultMaxIssues = 100
+
+func GetCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies that the canonical chain is continuous up to the head, " +
+			"and that the block and transaction lookups match the canonical blocks",
+		Run: runCommand,
+	}
+
+	setFlags(verifyCmd)
+
+	return verifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&params.maxIssues,
+		maxIssuesFlag,
+		defaultMaxIssues,
+		"the maximal number of the reported issues, the rest of them are only counted",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	db, err := dbHelper.OpenStorage(dbHelper.GetDataDir(cmd), "db-verify")
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+	defer db.Close()
+
+	res, err := db.VerifyChain(params.maxIssues)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if res.Issues == nil {
+		res.Issues = []string{}
+	}
+
+	outputter.SetCommandResult(&VerifyResult{
+		Valid:        res.IssueCount == 0,
+		Head:         res.Head,
+		Blocks:       res.Blocks,
+		Transactions: res.Transactions,
+		IssueCount:   res.IssueCount,
+		Issues:       res.Issues,
+	})
+}
@@ -0,0 +1,70 @@
+package exportchain
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	exportChainCmd := &cobra.Command{
+		Use: "export-chain",
+		Short: "Exports the blocks of a stopped node to a file of concatenated RLP encoded blocks, " +
+			"gzip compressed if the file name ends with .gz",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportChainCmd)
+	helper.SetRequiredFlags(exportChainCmd, params.getRequiredFlags())
+
+	return exportChainCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the path of the chain file to create",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the first block to export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to export, the head of the chain if not set",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
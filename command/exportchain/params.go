@@ -0,0 +1,117 @@
+package exportchain
+
+import (
+	"errors"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	dbhelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	outFlag     = "out"
+	fromFlag    = "from"
+	toFlag      = "to"
+)
+
+var (
+	params = &exportChainParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+)
+
+type exportChainParams struct {
+	dataDir string
+	out     string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	last uint64
+}
+
+func (p *exportChainParams) validateFlags() error {
+	var parseErr error
+
+	if p.from, parseErr = common.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = common.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *exportChainParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		outFlag,
+	}
+}
+
+// exportChain writes the canonical blocks in the range to the chain file,
+// which is removed if the export doesn't complete
+func (p *exportChainParams) exportChain() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "export-chain",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := dbhelper.OpenStorage(p.dataDir, "export-chain")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	writer, err := archive.CreateChainFile(p.out)
+	if err != nil {
+		return err
+	}
+
+	last, err := archive.ExportChain(db, writer, p.from, p.to, logger)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if removeErr := os.Remove(p.out); removeErr != nil {
+			logger.Error("an error occurred while removing file", "err", removeErr)
+		}
+
+		return err
+	}
+
+	p.last = last
+
+	return nil
+}
+
+func (p *exportChainParams) getResult() *ExportChainResult {
+	return &ExportChainResult{
+		From: p.from,
+		To:   p.last,
+		Out:  p.out,
+	}
+}
@@ -0,0 +1,28 @@
+package exportchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ExportChainResult struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	Out  string `json:"out"`
+}
+
+func (r *ExportChainResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[EXPORT CHAIN]\n")
+	buffer.WriteString("Exported chain file successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,59 @@
+package importchain
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+)
+
+func GetCommand() *cobra.Command {
+	importChainCmd := &cobra.Command{
+		Use: "import-chain",
+		Short: "Executes and imports the blocks of a file of concatenated RLP encoded blocks into a stopped node, " +
+			"resuming from the head of its chain",
+		Run: runCommand,
+	}
+
+	setFlags(importChainCmd)
+	helper.SetRequiredFlags(importChainCmd, params.getRequiredFlags())
+
+	return importChainCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		genesisPathFlag,
+		config.DefaultConfig().GenesisPath,
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the path of the chain file to import, decompressed with gzip if it ends with .gz",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.importChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,75 @@
+package importchain
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/chain"
+	dbhelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+const (
+	dataDirFlag     = "data-dir"
+	genesisPathFlag = "chain"
+	fileFlag        = "file"
+)
+
+var (
+	params = &importChainParams{}
+)
+
+type importChainParams struct {
+	dataDir     string
+	genesisPath string
+	file        string
+
+	result *archive.ImportResult
+}
+
+func (p *importChainParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		fileFlag,
+	}
+}
+
+// importChain executes the blocks of the chain file on top of the local chain of the stopped node.
+// The data directory is initialized with the genesis if it is empty
+func (p *importChainParams) importChain() error {
+	genesis, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file: %w", err)
+	}
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "import-chain",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := dbhelper.OpenOrCreateStorage(p.dataDir, "import-chain")
+	if err != nil {
+		return err
+	}
+
+	res, err := server.ImportChain(p.dataDir, db, genesis, p.file, logger)
+	if err != nil {
+		return err
+	}
+
+	p.result = res
+
+	return nil
+}
+
+func (p *importChainParams) getResult() *ImportChainResult {
+	return &ImportChainResult{
+		File:        p.file,
+		Skipped:     p.result.Skipped,
+		Imported:    p.result.Imported,
+		Head:        p.result.Head,
+		Interrupted: p.result.Interrupted,
+	}
+}
@@ -0,0 +1,37 @@
+package importchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ImportChainResult struct {
+	File        string `json:"file"`
+	Skipped     uint64 `json:"skipped"`
+	Imported    uint64 `json:"imported"`
+	Head        uint64 `json:"head"`
+	Interrupted bool   `json:"interrupted"`
+}
+
+func (r *ImportChainResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[IMPORT CHAIN]\n")
+
+	if r.Interrupted {
+		buffer.WriteString("Chain import interrupted, run the command again to resume it:\n")
+	} else {
+		buffer.WriteString("Imported chain file successfully:\n")
+	}
+
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Skipped blocks|%d", r.Skipped),
+		fmt.Sprintf("Imported blocks|%d", r.Imported),
+		fmt.Sprintf("Head|%d", r.Head),
+	}))
+
+	return buffer.String()
+}
@@ -32,7 +32,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	}
 
 	outputter.SetCommandResult(
-		newPeersListResult(peersList.Peers),
+		newPeersListResult(peersList.Peers, peersList.BannedPeers),
 	)
 }
 
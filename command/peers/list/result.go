@@ -3,23 +3,45 @@ package list
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 )
 
 type PeersListResult struct {
-	Peers []string `json:"peers"`
+	Peers       []string          `json:"peers"`
+	Scores      map[string]int64  `json:"scores"`
+	BannedPeers []*BannedPeerInfo `json:"bannedPeers"`
 }
 
-func newPeersListResult(peers []*proto.Peer) *PeersListResult {
+// BannedPeerInfo holds the ID of a banned peer and the unix time its ban expires
+type BannedPeerInfo struct {
+	ID          string `json:"id"`
+	BannedUntil int64  `json:"bannedUntil"`
+}
+
+func newPeersListResult(peers []*proto.Peer, bannedPeers []*proto.Peer) *PeersListResult {
 	resultPeers := make([]string, len(peers))
+	scores := make(map[string]int64, len(peers))
+
 	for i, p := range peers {
 		resultPeers[i] = p.Id
+		scores[p.Id] = p.Score
+	}
+
+	resultBannedPeers := make([]*BannedPeerInfo, len(bannedPeers))
+	for i, p := range bannedPeers {
+		resultBannedPeers[i] = &BannedPeerInfo{
+			ID:          p.Id,
+			BannedUntil: p.BannedUntil,
+		}
 	}
 
 	return &PeersListResult{
-		Peers: resultPeers,
+		Peers:       resultPeers,
+		Scores:      scores,
+		BannedPeers: resultBannedPeers,
 	}
 }
 
@@ -35,7 +57,7 @@ func (r *PeersListResult) GetOutput() string {
 
 		rows := make([]string, len(r.Peers))
 		for i, p := range r.Peers {
-			rows[i] = fmt.Sprintf("[%d]|%s", i, p)
+			rows[i] = fmt.Sprintf("[%d]|%s|score: %d", i, p, r.Scores[p])
 		}
 
 		buffer.WriteString(helper.FormatKV(rows))
@@ -43,5 +65,18 @@ func (r *PeersListResult) GetOutput() string {
 
 	buffer.WriteString("\n")
 
+	if len(r.BannedPeers) > 0 {
+		buffer.WriteString("\n[BANNED PEERS]\n")
+
+		rows := make([]string, len(r.BannedPeers))
+		for i, p := range r.BannedPeers {
+			rows[i] = fmt.Sprintf("[%d]|%s|until: %s",
+				i, p.ID, time.Unix(p.BannedUntil, 0).UTC().Format(time.RFC3339))
+		}
+
+		buffer.WriteString(helper.FormatKV(rows))
+		buffer.WriteString("\n")
+	}
+
 	return buffer.String()
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/peers/add"
 	"github.com/0xPolygon/polygon-edge/command/peers/list"
+	"github.com/0xPolygon/polygon-edge/command/peers/reload"
 	"github.com/0xPolygon/polygon-edge/command/peers/status"
 	"github.com/spf13/cobra"
 )
@@ -29,5 +30,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		list.GetCommand(),
 		// peers add
 		add.GetCommand(),
+		// peers reload
+		reload.GetCommand(),
 	)
 }
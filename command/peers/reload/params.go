@@ -0,0 +1,96 @@
+package reload
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+var (
+	params = &reloadParams{}
+)
+
+var (
+	errMissingConfig = errors.New("the server configuration file is required")
+)
+
+const (
+	configFlag = "config"
+)
+
+type reloadParams struct {
+	configPath string
+
+	systemClient proto.SystemClient
+
+	staticPeers  []string
+	trustedPeers []string
+	allowedPeers []string
+}
+
+func (p *reloadParams) getRequiredFlags() []string {
+	return []string{
+		configFlag,
+	}
+}
+
+func (p *reloadParams) validateFlags() error {
+	if p.configPath == "" {
+		return errMissingConfig
+	}
+
+	return nil
+}
+
+func (p *reloadParams) readPeers() error {
+	rawConfig, err := config.ReadConfigFile(p.configPath)
+	if err != nil {
+		return err
+	}
+
+	if rawConfig.Network == nil {
+		return nil
+	}
+
+	p.staticPeers = rawConfig.Network.StaticPeers
+	p.trustedPeers = rawConfig.Network.TrustedPeers
+	p.allowedPeers = rawConfig.Network.AllowedPeers
+
+	return nil
+}
+
+func (p *reloadParams) initSystemClient(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.systemClient = systemClient
+
+	return nil
+}
+
+func (p *reloadParams) reloadPeers() error {
+	_, err := p.systemClient.PeersReload(
+		context.Background(),
+		&proto.PeersReloadRequest{
+			Static:  p.staticPeers,
+			Trusted: p.trustedPeers,
+			Allowed: p.allowedPeers,
+		},
+	)
+
+	return err
+}
+
+func (p *reloadParams) getResult() command.CommandResult {
+	return &PeersReloadResult{
+		StaticPeers:  p.staticPeers,
+		TrustedPeers: p.trustedPeers,
+		AllowedPeers: p.allowedPeers,
+	}
+}
@@ -0,0 +1,60 @@
+package reload
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersReloadCmd := &cobra.Command{
+		Use: "reload",
+		Short: "Reloads the static, trusted and allowed peers of a running node " +
+			"from the network section of its configuration file",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(peersReloadCmd)
+	helper.SetRequiredFlags(peersReloadCmd, params.getRequiredFlags())
+
+	return peersReloadCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the server configuration file holding the peer lists",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.readPeers(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.initSystemClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.reloadPeers(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,44 @@
+package reload
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PeersReloadResult struct {
+	StaticPeers  []string `json:"static_peers"`
+	TrustedPeers []string `json:"trusted_peers"`
+	AllowedPeers []string `json:"allowed_peers"`
+}
+
+func (r *PeersReloadResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEERS RELOADED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Static peers|%d", len(r.StaticPeers)),
+		fmt.Sprintf("Trusted peers|%d", len(r.TrustedPeers)),
+		fmt.Sprintf("Allowed peers|%d", len(r.AllowedPeers)),
+	}))
+
+	if len(r.StaticPeers) > 0 {
+		buffer.WriteString("\n\n[STATIC PEERS]\n")
+		buffer.WriteString(helper.FormatList(r.StaticPeers))
+	}
+
+	if len(r.TrustedPeers) > 0 {
+		buffer.WriteString("\n\n[TRUSTED PEERS]\n")
+		buffer.WriteString(helper.FormatList(r.TrustedPeers))
+	}
+
+	if len(r.AllowedPeers) > 0 {
+		buffer.WriteString("\n\n[ALLOWED PEERS]\n")
+		buffer.WriteString(helper.FormatList(r.AllowedPeers))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -51,8 +51,10 @@ func (p *statusParams) initPeerInfo(grpcAddress string) error {
 
 func (p *statusParams) getResult() command.CommandResult {
 	return &PeersStatusResult{
-		ID:        p.peerStatus.Id,
-		Protocols: p.peerStatus.Protocols,
-		Addresses: p.peerStatus.Addrs,
+		ID:          p.peerStatus.Id,
+		Protocols:   p.peerStatus.Protocols,
+		Addresses:   p.peerStatus.Addrs,
+		Score:       p.peerStatus.Score,
+		BannedUntil: p.peerStatus.BannedUntil,
 	}
 }
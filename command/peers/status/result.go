@@ -3,25 +3,35 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
 
 type PeersStatusResult struct {
-	ID        string   `json:"id"`
-	Protocols []string `json:"protocols"`
-	Addresses []string `json:"addresses"`
+	ID          string   `json:"id"`
+	Protocols   []string `json:"protocols"`
+	Addresses   []string `json:"addresses"`
+	Score       int64    `json:"score"`
+	BannedUntil int64    `json:"bannedUntil,omitempty"`
 }
 
 func (r *PeersStatusResult) GetOutput() string {
 	var buffer bytes.Buffer
 
-	buffer.WriteString("\n[PEER STATUS]\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	rows := []string{
 		fmt.Sprintf("ID|%s", r.ID),
 		fmt.Sprintf("Protocols|%s", r.Protocols),
 		fmt.Sprintf("Addresses|%s", r.Addresses),
-	}))
+		fmt.Sprintf("Score|%d", r.Score),
+	}
+
+	if r.BannedUntil != 0 {
+		rows = append(rows, fmt.Sprintf("Banned Until|%s", time.Unix(r.BannedUntil, 0).UTC().Format(time.RFC3339)))
+	}
+
+	buffer.WriteString("\n[PEER STATUS]\n")
+	buffer.WriteString(helper.FormatKV(rows))
 	buffer.WriteString("\n")
 
 	return buffer.String()
@@ -0,0 +1,117 @@
+package prunestate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	dbhelper "github.com/0xPolygon/polygon-edge/command/db/helper"
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	stateRetentionFlag = "state-retention"
+)
+
+var (
+	params = &pruneStateParams{}
+)
+
+var (
+	errHeadNotFound = errors.New("unable to read the head of the chain")
+)
+
+type pruneStateParams struct {
+	dataDir        string
+	stateRetention uint64
+
+	head   uint64
+	result *itrie.PruneResult
+}
+
+func (p *pruneStateParams) validateFlags() error {
+	if p.stateRetention < config.MinStateRetention {
+		return fmt.Errorf("state retention must be at least %d blocks", config.MinStateRetention)
+	}
+
+	return nil
+}
+
+func (p *pruneStateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+// pruneState removes the states of the blocks out of the retention window from the trie database.
+// The node must not be running, since both the blockchain and the trie databases are opened
+func (p *pruneStateParams) pruneState() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "prune-state",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	// the headers of the finalized blocks might have been moved to the freezer
+	chainDB, err := dbhelper.OpenStorage(p.dataDir, "prune-state")
+	if err != nil {
+		return err
+	}
+	defer chainDB.Close()
+
+	head, ok := chainDB.ReadHeadNumber()
+	if !ok {
+		return errHeadNotFound
+	}
+
+	roots, err := itrie.RetainedStateRoots(head, p.stateRetention, func(number uint64) (types.Hash, bool) {
+		hash, ok := chainDB.ReadCanonicalHash(number)
+		if !ok {
+			return types.ZeroHash, false
+		}
+
+		header, err := chainDB.ReadHeader(number, hash)
+		if err != nil {
+			return types.ZeroHash, false
+		}
+
+		return header.StateRoot, true
+	})
+	if err != nil {
+		return err
+	}
+
+	trieDB, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open trie database: %w", err)
+	}
+	defer trieDB.Close()
+
+	res, err := itrie.NewPruner(trieDB, logger).Prune(roots)
+	if err != nil {
+		return fmt.Errorf("failed to prune state: %w", err)
+	}
+
+	// reclaim the space of the removed nodes
+	if err := trieDB.Compact(nil, nil); err != nil {
+		return fmt.Errorf("failed to compact trie database: %w", err)
+	}
+
+	p.head = head
+	p.result = res
+
+	return nil
+}
+
+func (p *pruneStateParams) getResult() *PruneStateResult {
+	return &PruneStateResult{
+		Head:           p.head,
+		StateRetention: p.stateRetention,
+		RetainedNodes:  p.result.Retained,
+		DeletedNodes:   p.result.Deleted,
+	}
+}
@@ -0,0 +1,57 @@
+package prunestate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+)
+
+func GetCommand() *cobra.Command {
+	pruneStateCmd := &cobra.Command{
+		Use: "prune-state",
+		Short: "Removes the states of the blocks older than the retention window from the trie database " +
+			"of a stopped node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(pruneStateCmd)
+	helper.SetRequiredFlags(pruneStateCmd, params.getRequiredFlags())
+
+	return pruneStateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.stateRetention,
+		stateRetentionFlag,
+		config.MinStateRetention,
+		"the number of the most recent blocks whose state is kept",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.pruneState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,30 @@
+package prunestate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PruneStateResult struct {
+	Head           uint64 `json:"head"`
+	StateRetention uint64 `json:"state_retention"`
+	RetainedNodes  uint64 `json:"retained_nodes"`
+	DeletedNodes   uint64 `json:"deleted_nodes"`
+}
+
+func (r *PruneStateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PRUNE STATE]\n")
+	buffer.WriteString("State pruned successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Retained blocks|%d", r.StateRetention),
+		fmt.Sprintf("Retained trie nodes|%d", r.RetainedNodes),
+		fmt.Sprintf("Deleted trie nodes|%d", r.DeletedNodes),
+	}))
+
+	return buffer.String()
+}
@@ -9,12 +9,16 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/accounts"
 	"github.com/0xPolygon/polygon-edge/command/backup"
 	"github.com/0xPolygon/polygon-edge/command/bridge"
+	"github.com/0xPolygon/polygon-edge/command/db"
+	"github.com/0xPolygon/polygon-edge/command/exportchain"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/importchain"
 	"github.com/0xPolygon/polygon-edge/command/loadtest"
 	"github.com/0xPolygon/polygon-edge/command/mint"
 	"github.com/0xPolygon/polygon-edge/command/monitor"
 	"github.com/0xPolygon/polygon-edge/command/peers"
+	"github.com/0xPolygon/polygon-edge/command/prunestate"
 	"github.com/0xPolygon/polygon-edge/command/regenesis"
 	"github.com/0xPolygon/polygon-edge/command/sanitycheck"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
@@ -63,6 +67,10 @@ func (rc *RootCommand) registerSubCommands() {
 		loadtest.GetCommand(),
 		sanitycheck.GetCommand(),
 		accounts.GetCommand(),
+		prunestate.GetCommand(),
+		db.GetCommand(),
+		exportchain.GetCommand(),
+		importchain.GetCommand(),
 	)
 }
 
@@ -41,6 +41,22 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
+	JSONRPCIPCPath          string `json:"jsonrpc_ipc_path" yaml:"jsonrpc_ipc_path"`
+	StateRetention          uint64 `json:"state_retention" yaml:"state_retention"`
+	StateSync               bool   `json:"state_sync" yaml:"state_sync"`
+	FreezerThreshold        uint64 `json:"freezer_threshold" yaml:"freezer_threshold"`
+	HistoryRetention        uint64 `json:"history_retention" yaml:"history_retention"`
+
+	JSONRPCNamespaces      []string `json:"jsonrpc_namespaces" yaml:"jsonrpc_namespaces"`
+	JSONRPCJWTSecret       string   `json:"jsonrpc_jwt_secret" yaml:"jsonrpc_jwt_secret"`
+	JSONRPCAdminAddr       string   `json:"jsonrpc_admin_addr" yaml:"jsonrpc_admin_addr"`
+	JSONRPCAdminNamespaces []string `json:"jsonrpc_admin_namespaces" yaml:"jsonrpc_admin_namespaces"`
+	JSONRPCAdminJWTSecret  string   `json:"jsonrpc_admin_jwt_secret" yaml:"jsonrpc_admin_jwt_secret"`
+
+	JSONRPCRateLimits         []string `json:"jsonrpc_rate_limits" yaml:"jsonrpc_rate_limits"`
+	JSONRPCRateLimitKeyHeader string   `json:"jsonrpc_rate_limit_key_header" yaml:"jsonrpc_rate_limit_key_header"`
+	JSONRPCTrustedProxies     []string `json:"jsonrpc_trusted_proxies" yaml:"jsonrpc_trusted_proxies"`
+	JSONRPCMaxResponseSize    uint64   `json:"jsonrpc_max_response_size" yaml:"jsonrpc_max_response_size"`
 
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
 
@@ -62,6 +78,10 @@ type Network struct {
 	MaxOutboundPeers  int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers   int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
 	GossipMessageSize int    `json:"gossip_msg_size" yaml:"gossip_msg_size"`
+
+	StaticPeers  []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
+	TrustedPeers []string `json:"trusted_peers,omitempty" yaml:"trusted_peers,omitempty"`
+	AllowedPeers []string `json:"allowed_peers,omitempty" yaml:"allowed_peers,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
@@ -70,6 +90,9 @@ type TxPool struct {
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
 	TxGossipBatchSize  uint64 `json:"tx_gossip_batch_size" yaml:"tx_gossip_batch_size"`
+
+	Journal         bool          `json:"journal" yaml:"journal"`
+	JournalRotation time.Duration `json:"journal_rotation" yaml:"journal_rotation"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -100,6 +123,22 @@ const (
 	// the connection sends a close message to the peer and returns ErrReadLimit to the application.
 	DefaultWebSocketReadLimit uint64 = 8192
 
+	// MinStateRetention is the minimal number of the most recent blocks whose state is kept,
+	// when the state pruning is enabled
+	MinStateRetention uint64 = 128
+
+	// MinFreezerThreshold is the minimal number of the most recent blocks kept in the main database,
+	// when the older blocks are moved to the freezer
+	MinFreezerThreshold uint64 = 128
+
+	// MinHistoryRetention is the minimal number of the most recent blocks keeping their bodies,
+	// receipts and transaction lookups, when the history pruning is enabled.
+	// It matches the size of the bloom bits index section, which is built from the receipts
+	MinHistoryRetention uint64 = 4096
+
+	// DefaultTxPoolJournalRotation is the default interval of rewriting the journal of the local transactions
+	DefaultTxPoolJournalRotation time.Duration = time.Hour
+
 	// DefaultMetricsInterval specifies the time interval after which Prometheus metrics will be generated.
 	// A value of 0 means the metrics are disabled.
 	DefaultMetricsInterval time.Duration = time.Second * 8
@@ -145,6 +184,8 @@ func DefaultConfig() *Config {
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
 			TxGossipBatchSize:  1,
+			Journal:            false,
+			JournalRotation:    DefaultTxPoolJournalRotation,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -5,19 +5,26 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"os"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 
 	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/network/common"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
 )
 
+// minJWTSecretLength is the minimum length in bytes of the json-rpc jwt secrets
+const minJWTSecretLength = 32
+
 var (
 	errDataDirectoryUndefined = errors.New("data directory not defined")
 )
@@ -49,6 +56,18 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initStateRetention(); err != nil {
+		return err
+	}
+
+	if err := p.initFreezerThreshold(); err != nil {
+		return err
+	}
+
+	if err := p.initHistoryRetention(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
@@ -69,6 +88,38 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initStateRetention() error {
+	if p.rawConfig.StateRetention != 0 && p.rawConfig.StateRetention < config.MinStateRetention {
+		return fmt.Errorf("state retention must be 0 (archive node) or at least %d blocks", config.MinStateRetention)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initFreezerThreshold() error {
+	if p.rawConfig.FreezerThreshold != 0 && p.rawConfig.FreezerThreshold < config.MinFreezerThreshold {
+		return fmt.Errorf("freezer threshold must be 0 (disabled) or at least %d blocks", config.MinFreezerThreshold)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initHistoryRetention() error {
+	if p.rawConfig.HistoryRetention == 0 {
+		return nil
+	}
+
+	if p.rawConfig.HistoryRetention < config.MinHistoryRetention {
+		return fmt.Errorf("history retention must be 0 (disabled) or at least %d blocks", config.MinHistoryRetention)
+	}
+
+	if p.rawConfig.FreezerThreshold != 0 {
+		return errors.New("history retention can't be used along with the freezer")
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -220,6 +271,14 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initJSONRPCAdmin(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCRateLimits(); err != nil {
+		return err
+	}
+
 	return p.initGRPCAddress()
 }
 
@@ -296,6 +355,80 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+func (p *serverParams) initJSONRPCAdmin() error {
+	var err error
+
+	if p.jsonRPCJWTSecret, err = readJWTSecret(p.rawConfig.JSONRPCJWTSecret); err != nil {
+		return err
+	}
+
+	if p.rawConfig.JSONRPCAdminAddr == "" {
+		return nil
+	}
+
+	if p.jsonRPCAdminAddress, err = helper.ResolveAddr(
+		p.rawConfig.JSONRPCAdminAddr,
+		helper.LocalHostBinding,
+	); err != nil {
+		return err
+	}
+
+	if p.jsonRPCAdminJWTSecret, err = readJWTSecret(p.rawConfig.JSONRPCAdminJWTSecret); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCRateLimits() error {
+	p.jsonRPCRateLimits = make([]*jsonrpc.RateLimit, len(p.rawConfig.JSONRPCRateLimits))
+
+	for i, raw := range p.rawConfig.JSONRPCRateLimits {
+		limit, err := jsonrpc.ParseRateLimit(raw)
+		if err != nil {
+			return err
+		}
+
+		p.jsonRPCRateLimits[i] = limit
+	}
+
+	p.jsonRPCTrustedProxies = make([]*net.IPNet, len(p.rawConfig.JSONRPCTrustedProxies))
+
+	for i, raw := range p.rawConfig.JSONRPCTrustedProxies {
+		proxy, err := jsonrpc.ParseTrustedProxy(raw)
+		if err != nil {
+			return err
+		}
+
+		p.jsonRPCTrustedProxies[i] = proxy
+	}
+
+	return nil
+}
+
+// readJWTSecret reads the hex encoded JWT secret from the file, nil if the path is empty
+func readJWTSecret(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the jwt secret: %w", err)
+	}
+
+	secret, err := hex.DecodeHex(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the jwt secret: %w", err)
+	}
+
+	if len(secret) < minJWTSecretLength {
+		return nil, fmt.Errorf("the jwt secret must be at least %d bytes long", minJWTSecretLength)
+	}
+
+	return secret, nil
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
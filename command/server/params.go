@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -41,12 +42,31 @@ const (
 	tlsCertFileLocationFlag      = "tls-cert-file"
 	tlsKeyFileLocationFlag       = "tls-key-file"
 	gossipMessageSizeFlag        = "gossip-msg-size"
+	staticPeersFlag              = "static-peers"
+	trustedPeersFlag             = "trusted-peers"
+	allowedPeersFlag             = "allowed-peers"
 	txGossipBatchSizeFlag        = "tx-gossip-batch-size"
+	txPoolJournalFlag            = "txpool-journal"
+	txPoolJournalRotationFlag    = "txpool-journal-rotation"
 
 	relayerFlag = "relayer"
 
 	concurrentRequestsDebugFlag = "concurrent-requests-debug"
 	webSocketReadLimitFlag      = "websocket-read-limit"
+	jsonRPCIPCPathFlag          = "json-rpc-ipc-path"
+	jsonRPCNamespacesFlag       = "json-rpc-namespaces"
+	jsonRPCJWTSecretFlag        = "json-rpc-jwt-secret"
+	jsonRPCAdminAddrFlag        = "json-rpc-admin-addr"
+	jsonRPCAdminNamespacesFlag  = "json-rpc-admin-namespaces"
+	jsonRPCAdminJWTSecretFlag   = "json-rpc-admin-jwt-secret"
+	jsonRPCRateLimitFlag        = "json-rpc-rate-limit"
+	jsonRPCRateLimitKeyFlag     = "json-rpc-rate-limit-key-header"
+	jsonRPCTrustedProxiesFlag   = "json-rpc-trusted-proxies"
+	jsonRPCMaxResponseSizeFlag  = "json-rpc-max-response-size"
+	stateRetentionFlag          = "state-retention"
+	stateSyncFlag               = "state-sync"
+	freezerThresholdFlag        = "freezer-threshold"
+	historyRetentionFlag        = "history-retention"
 
 	metricsIntervalFlag = "metrics-interval"
 
@@ -86,6 +106,12 @@ type serverParams struct {
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
 
+	jsonRPCJWTSecret      []byte
+	jsonRPCAdminAddress   *net.TCPAddr
+	jsonRPCAdminJWTSecret []byte
+	jsonRPCRateLimits     []*jsonrpc.RateLimit
+	jsonRPCTrustedProxies []*net.IPNet
+
 	blockGasTarget uint64
 	devInterval    uint64
 	isDevMode      bool
@@ -161,6 +187,18 @@ func (p *serverParams) generateConfig() *server.Config {
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
 			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
 			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			IPCPath:                  p.rawConfig.JSONRPCIPCPath,
+
+			Namespaces:      p.rawConfig.JSONRPCNamespaces,
+			JWTSecret:       p.jsonRPCJWTSecret,
+			AdminAddr:       p.jsonRPCAdminAddress,
+			AdminNamespaces: p.rawConfig.JSONRPCAdminNamespaces,
+			AdminJWTSecret:  p.jsonRPCAdminJWTSecret,
+
+			RateLimits:              p.jsonRPCRateLimits,
+			RateLimitKeyHeader:      p.rawConfig.JSONRPCRateLimitKeyHeader,
+			RateLimitTrustedProxies: p.jsonRPCTrustedProxies,
+			MaxResponseSize:         p.rawConfig.JSONRPCMaxResponseSize,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -178,8 +216,15 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxOutboundPeers:  p.rawConfig.Network.MaxOutboundPeers,
 			Chain:             p.genesisConfig,
 			GossipMessageSize: p.rawConfig.Network.GossipMessageSize,
+			StaticPeers:       p.rawConfig.Network.StaticPeers,
+			TrustedPeers:      p.rawConfig.Network.TrustedPeers,
+			AllowedPeers:      p.rawConfig.Network.AllowedPeers,
 		},
 		DataDir:            p.rawConfig.DataDir,
+		StateRetention:     p.rawConfig.StateRetention,
+		StateSync:          p.rawConfig.StateSync,
+		FreezerThreshold:   p.rawConfig.FreezerThreshold,
+		HistoryRetention:   p.rawConfig.HistoryRetention,
 		Seal:               p.rawConfig.ShouldSeal,
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
@@ -194,6 +239,9 @@ func (p *serverParams) generateConfig() *server.Config {
 		TLSCertFile:        p.rawConfig.TLSCertFile,
 		TLSKeyFile:         p.rawConfig.TLSKeyFile,
 
+		TxPoolJournal:         p.rawConfig.TxPool.Journal,
+		TxPoolJournalRotation: p.rawConfig.TxPool.JournalRotation,
+
 		Relayer:         p.relayer,
 		MetricsInterval: p.rawConfig.MetricsInterval,
 		EventTracker: &server.EventTracker{
@@ -68,6 +68,41 @@ func setFlags(cmd *cobra.Command) {
 		"the data directory used for storing Blade client data",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateRetention,
+		stateRetentionFlag,
+		defaultConfig.StateRetention,
+		fmt.Sprintf("the number of the most recent blocks whose state is kept, older states are pruned "+
+			"in the background. 0 keeps all the states (archive node), otherwise it must be at least %d",
+			config.MinStateRetention),
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSync,
+		stateSyncFlag,
+		defaultConfig.StateSync,
+		"download the state of a recent block from the peers instead of executing all the blocks, "+
+			"when the local chain is empty",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FreezerThreshold,
+		freezerThresholdFlag,
+		defaultConfig.FreezerThreshold,
+		fmt.Sprintf("the number of the most recent blocks kept in the main database, the headers, bodies "+
+			"and receipts of the older blocks are moved to the append-only freezer files in the background. "+
+			"0 disables the freezer, otherwise it must be at least %d", config.MinFreezerThreshold),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HistoryRetention,
+		historyRetentionFlag,
+		defaultConfig.HistoryRetention,
+		fmt.Sprintf("the number of the most recent blocks keeping their bodies, receipts and transaction lookups, "+
+			"the history of the older blocks is removed in the background and can't be queried anymore. "+
+			"0 keeps the whole history, otherwise it must be at least %d", config.MinHistoryRetention),
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.Libp2pAddr,
 		libp2pAddressFlag,
@@ -169,6 +204,27 @@ func setFlags(cmd *cobra.Command) {
 		"the maximum size of a gossip message",
 	)
 
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeersFlag,
+		nil,
+		"the libp2p addresses of the peers which are always kept connected",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		nil,
+		"the libp2p IDs of the peers which are accepted regardless of the peer limits",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.AllowedPeers,
+		allowedPeersFlag,
+		nil,
+		"the libp2p IDs of the only peers allowed to connect (besides the static and trusted ones), any peer if empty",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -200,6 +256,21 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of transactions in gossip message",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.Journal,
+		txPoolJournalFlag,
+		defaultConfig.TxPool.Journal,
+		"keep the transactions submitted through this node in a journal in the data directory, "+
+			"and restore them to the pool on restart",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.TxPool.JournalRotation,
+		txPoolJournalRotationFlag,
+		defaultConfig.TxPool.JournalRotation,
+		"the interval of rewriting the transaction pool journal with the local transactions still in the pool",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -271,6 +342,82 @@ func setFlags(cmd *cobra.Command) {
 		"maximum size in bytes for a message read from the peer by websocket",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCIPCPath,
+		jsonRPCIPCPathFlag,
+		defaultConfig.JSONRPCIPCPath,
+		"path of the unix socket (named pipe on windows) to serve the JSON-RPC over IPC, disabled if empty",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCNamespaces,
+		jsonRPCNamespacesFlag,
+		defaultConfig.JSONRPCNamespaces,
+		"the JSON-RPC namespaces served on the json-rpc address (e.g. eth,net,web3), all of them if empty",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCJWTSecret,
+		jsonRPCJWTSecretFlag,
+		defaultConfig.JSONRPCJWTSecret,
+		"path to the file with the hex encoded secret of the HS256 bearer tokens required on the json-rpc address, "+
+			"no authentication if empty",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAdminAddr,
+		jsonRPCAdminAddrFlag,
+		defaultConfig.JSONRPCAdminAddr,
+		"the address and port of the additional (admin) JSON-RPC listener, which is the only one serving "+
+			"the methods modifying the chain (e.g. debug_setHead), disabled if empty",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCAdminNamespaces,
+		jsonRPCAdminNamespacesFlag,
+		defaultConfig.JSONRPCAdminNamespaces,
+		"the JSON-RPC namespaces served on the admin address (e.g. debug,personal,txpool), all of them if empty",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAdminJWTSecret,
+		jsonRPCAdminJWTSecretFlag,
+		defaultConfig.JSONRPCAdminJWTSecret,
+		"path to the file with the hex encoded secret of the HS256 bearer tokens required on the admin address, "+
+			"no authentication if empty",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCRateLimits,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCRateLimits,
+		"the per client token bucket limit of the JSON-RPC requests in the <methods>=<requests per second>:<burst> "+
+			"format (e.g. eth_call,eth_getLogs=10:20 or debug_*=1:1), the methods are omitted to limit all of them. "+
+			"A request is limited by the first matching limit only",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCRateLimitKeyHeader,
+		jsonRPCRateLimitKeyFlag,
+		defaultConfig.JSONRPCRateLimitKeyHeader,
+		"the header with the API key identifying the client for the rate limiting, honoured only on the requests "+
+			"from the trusted proxies, the remote IP is used otherwise",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCTrustedProxies,
+		jsonRPCTrustedProxiesFlag,
+		defaultConfig.JSONRPCTrustedProxies,
+		"the IPs or CIDR ranges of the proxies trusted to set the rate limit key header",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCMaxResponseSize,
+		jsonRPCMaxResponseSizeFlag,
+		defaultConfig.JSONRPCMaxResponseSize,
+		"maximum size in bytes of the JSON-RPC response (of all the responses of a batch), value of 0 disables it",
+	)
+
 	cmd.Flags().DurationVar(
 		&params.rawConfig.MetricsInterval,
 		metricsIntervalFlag,
@@ -0,0 +1,73 @@
+package uptime
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+const (
+	epochFlag = "epoch"
+)
+
+type uptimeParams struct {
+	jsonRPC string
+	epoch   uint64
+}
+
+func (p *uptimeParams) validateFlags() error {
+	if _, err := helper.ParseJSONRPCAddress(p.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	return nil
+}
+
+type validatorUptime struct {
+	Address        string `json:"address"`
+	SignedBlocks   uint64 `json:"signedBlocks"`
+	MissedSeals    uint64 `json:"missedSeals"`
+	ProposedBlocks uint64 `json:"proposedBlocks"`
+	SkippedRounds  uint64 `json:"skippedRounds"`
+}
+
+// signedPercentage returns the percentage of the tracked blocks signed by the validator
+func (u *validatorUptime) signedPercentage() float64 {
+	total := u.SignedBlocks + u.MissedSeals
+	if total == 0 {
+		return 0
+	}
+
+	return float64(u.SignedBlocks) * 100 / float64(total)
+}
+
+type uptimeResult struct {
+	Epoch      uint64             `json:"epoch"`
+	Validators []*validatorUptime `json:"validators"`
+}
+
+func (r *uptimeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("\n[VALIDATORS UPTIME - EPOCH %d]\n", r.Epoch))
+
+	rows := make([]string, len(r.Validators)+1)
+	rows[0] = "Validator Address|Signed Blocks|Missed Seals|Uptime|Proposed Blocks|Skipped Rounds"
+
+	for i, v := range r.Validators {
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%.2f%%|%d|%d",
+			v.Address,
+			v.SignedBlocks,
+			v.MissedSeals,
+			v.signedPercentage(),
+			v.ProposedBlocks,
+			v.SkippedRounds,
+		)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,90 @@
+package uptime
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	params uptimeParams
+)
+
+func GetCommand() *cobra.Command {
+	uptimeCmd := &cobra.Command{
+		Use: "uptime",
+		Short: "Reports the signed blocks, missed seals, proposed blocks and skipped rounds " +
+			"of the validators in an epoch",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(uptimeCmd)
+	setFlags(uptimeCmd)
+
+	return uptimeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.epoch,
+		epochFlag,
+		0,
+		"the epoch to report, the current epoch if not set",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	client, err := jsonrpc.NewEthClient(params.jsonRPC)
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	epoch := params.epoch
+	if epoch == 0 {
+		epochInfo, err := client.PolyBFTEpoch(jsonrpc.LatestBlockNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get the current epoch: %w", err)
+		}
+
+		epoch = epochInfo.Number
+	}
+
+	uptime, err := client.PolyBFTValidatorsUptime(epoch)
+	if err != nil {
+		return fmt.Errorf("failed to get the uptime of epoch %d: %w", epoch, err)
+	}
+
+	result := &uptimeResult{
+		Epoch:      epoch,
+		Validators: make([]*validatorUptime, len(uptime)),
+	}
+
+	for i, u := range uptime {
+		result.Validators[i] = &validatorUptime{
+			Address:        u.Address.String(),
+			SignedBlocks:   u.SignedBlocks,
+			MissedSeals:    u.MissedSeals,
+			ProposedBlocks: u.ProposedBlocks,
+			SkippedRounds:  u.SkippedRounds,
+		}
+	}
+
+	outputter.WriteCommandResult(result)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/validator/registration"
 	staking "github.com/0xPolygon/polygon-edge/command/validator/stake"
 	unstaking "github.com/0xPolygon/polygon-edge/command/validator/unstake"
+	"github.com/0xPolygon/polygon-edge/command/validator/uptime"
 	"github.com/0xPolygon/polygon-edge/command/validator/validators"
 	"github.com/0xPolygon/polygon-edge/command/validator/whitelist"
 	"github.com/0xPolygon/polygon-edge/command/validator/withdraw"
@@ -32,6 +33,8 @@ func GetCommand() *cobra.Command {
 		registration.GetCommand(),
 		// rootchain (stake manager) stake command
 		staking.GetCommand(),
+		// child chain (consensus state) command that reports the uptime of the validators
+		uptime.GetCommand(),
 	)
 
 	return polybftCmd
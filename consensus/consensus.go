@@ -3,6 +3,7 @@ package consensus
 import (
 	"context"
 	"log"
+	"math/big"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -11,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -41,9 +43,16 @@ type Consensus interface {
 	// GetBridgeProvider returns an instance of BridgeDataProvider
 	GetBridgeProvider() BridgeDataProvider
 
+	// GetValidatorsProvider returns an instance of ValidatorsDataProvider,
+	// nil if the consensus has no validators
+	GetValidatorsProvider() ValidatorsDataProvider
+
 	// FilterExtra filters extra data in header that is not a part of block hash
 	FilterExtra(extra []byte) ([]byte, error)
 
+	// ResetHead resets the consensus data once the head of the chain is set back to the header
+	ResetHead(header *types.Header) error
+
 	// Initialize initializes the consensus (e.g. setup data)
 	Initialize() error
 
@@ -82,6 +91,8 @@ type Params struct {
 	Network        *network.Server
 	Blockchain     *blockchain.Blockchain
 	Executor       *state.Executor
+	StateStorage   itrie.Storage
+	StateSync      bool
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
@@ -105,6 +116,30 @@ type BridgeDataProvider interface {
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
 }
 
+// ValidatorsDataProvider is an interface providing the validators related data of the chain
+type ValidatorsDataProvider interface {
+	// GetValidatorSet returns the validators which validated the given block
+	GetValidatorSet(blockNumber uint64) ([]*types.ValidatorInfo, error)
+
+	// GetEpochInfo returns the epoch the given block belongs to
+	GetEpochInfo(blockNumber uint64) (*types.EpochInfo, error)
+
+	// GetBlockSigners returns the validators which committed the given block
+	GetBlockSigners(blockNumber uint64) (*types.BlockSigners, error)
+
+	// GetProposer calculates the proposer of the given round of the block being built
+	GetProposer(height, round uint64) (types.Address, error)
+
+	// GetVotingPowers returns the voting powers of the validators which validated the given block
+	GetVotingPowers(blockNumber uint64) (map[types.Address]*big.Int, error)
+
+	// GetValidatorsUptime returns the uptime of the validators in the given epoch
+	GetValidatorsUptime(epoch uint64) ([]*types.ValidatorUptime, error)
+
+	// GetDoubleSignEvidence returns the double sign evidence of the validators in the given range of heights
+	GetDoubleSignEvidence(fromHeight, toHeight uint64) ([]*types.DoubleSignEvidence, error)
+}
+
 type EventTracker struct {
 	NumBlockConfirmations  uint64
 	SyncBatchSize          uint64
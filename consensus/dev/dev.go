@@ -260,6 +260,14 @@ func (d *Dev) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dev) GetValidatorsProvider() consensus.ValidatorsDataProvider {
+	return nil
+}
+
 func (d *Dev) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
+
+func (d *Dev) ResetHead(_ *types.Header) error {
+	return nil
+}
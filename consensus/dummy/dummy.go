@@ -85,10 +85,18 @@ func (d *Dummy) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dummy) GetValidatorsProvider() consensus.ValidatorsDataProvider {
+	return nil
+}
+
 func (d *Dummy) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
 
+func (d *Dummy) ResetHead(_ *types.Header) error {
+	return nil
+}
+
 func (d *Dummy) run() {
 	d.logger.Info("started")
 	// do nothing
@@ -56,3 +56,19 @@ func updateBlockExecutionMetric(start time.Time) {
 	metrics.SetGauge([]string{consensusMetricsPrefix, "block_execution_time"},
 		float32(time.Now().UTC().Sub(start).Seconds()))
 }
+
+// updateValidatorsUptimeMetrics updates the uptime metrics of the validators in the current epoch
+func updateValidatorsUptimeMetrics(uptime []*types.ValidatorUptime) {
+	for _, u := range uptime {
+		labels := []metrics.Label{{Name: "validator", Value: u.Address.String()}}
+
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_signed_blocks"},
+			float32(u.SignedBlocks), labels)
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_missed_seals"},
+			float32(u.MissedSeals), labels)
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_proposed_blocks"},
+			float32(u.ProposedBlocks), labels)
+		metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_skipped_rounds"},
+			float32(u.SkippedRounds), labels)
+	}
+}
@@ -92,6 +92,7 @@ type runtimeConfig struct {
 	polybftBackend  polybftBackend
 	txPool          txPoolInterface
 	bridgeTopic     topic
+	evidenceTopic   topic
 	consensusConfig *consensus.Config
 	eventTracker    *consensus.EventTracker
 }
@@ -134,6 +135,9 @@ type consensusRuntime struct {
 	// also handles updating client configuration based on governance proposals
 	governanceManager GovernanceManager
 
+	// evidenceCollector records the conflicting consensus messages signed by the validators
+	evidenceCollector *evidenceCollector
+
 	// logger instance
 	logger hcf.Logger
 }
@@ -174,6 +178,12 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 
 	runtime.bridgeManager = bridgeManager
 
+	runtime.evidenceCollector = newEvidenceCollector(config.State, config.evidenceTopic,
+		config.polybftBackend, runtime.lastBuiltBlock.Number, log.Named("evidence_collector"))
+	if err := runtime.evidenceCollector.initTransport(); err != nil {
+		return nil, fmt.Errorf("failed to initialize evidence transport layer: %w", err)
+	}
+
 	if err := runtime.initStakeManager(log, dbTx); err != nil {
 		return nil, err
 	}
@@ -286,12 +296,20 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 	// after the block has been written we reset the txpool so that the old transactions are removed
 	c.config.txPool.ResetWithBlock(fullBlock.Block)
 
+	if c.evidenceCollector != nil {
+		c.evidenceCollector.prune(fullBlock.Block.Number())
+	}
+
 	var (
 		epoch = c.epoch
 		err   error
 		// calculation of epoch and sprint end does not consider slashing currently
 
 		isEndOfEpoch = c.isFixedSizeOfEpochMet(fullBlock.Block.Header.Number, epoch)
+		// the blocks imported without their state by the state sync don't go through this hook
+		// (their events are processed from their receipts below), so the epoch is restarted
+		// from the first block inserted after them
+		isAfterGap = c.lastBuiltBlock != nil && fullBlock.Block.Number() > c.lastBuiltBlock.Number+1
 	)
 
 	// begin DB transaction
@@ -328,6 +346,11 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		Forks:               c.config.Forks,
 	}
 
+	// update the uptime of the validators, while the proposer snapshot is still the one of the block
+	if err := c.trackValidatorsUptime(postBlock); err != nil {
+		c.logger.Error("failed to track validators uptime", "block", fullBlock.Block.Number(), "err", err)
+	}
+
 	// update proposer priorities
 	if err := c.proposerCalculator.PostBlock(postBlock); err != nil {
 		c.logger.Error("Could not update proposer calculator", "err", err)
@@ -354,7 +377,7 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("failed to post block in governance manager", "err", err)
 	}
 
-	if isEndOfEpoch {
+	if isEndOfEpoch || isAfterGap {
 		if epoch, err = c.restartEpoch(fullBlock.Block.Header, dbTx); err != nil {
 			c.logger.Error("failed to restart epoch after block inserted", "error", err)
 
@@ -571,6 +594,43 @@ func (c *consensusRuntime) restartEpoch(header *types.Header, dbTx *bolt.Tx) (*e
 	}, nil
 }
 
+// resetHead restarts the proposer calculator and the epoch from the given header,
+// once the head of the chain is set back to it
+func (c *consensusRuntime) resetHead(header *types.Header) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	dbTx, err := c.state.beginDBTransaction(true)
+	if err != nil {
+		return fmt.Errorf("could not begin dbTx to reset consensus runtime: %w", err)
+	}
+
+	defer dbTx.Rollback() //nolint:errcheck
+
+	proposerCalculator, err := NewProposerCalculator(c.config, c.proposerCalculator.logger, dbTx)
+	if err != nil {
+		return fmt.Errorf("failed to reset proposer calculator: %w", err)
+	}
+
+	// the epoch metadata is dropped, so that the epoch is restarted even if its number is unchanged
+	c.epoch = nil
+
+	epoch, err := c.restartEpoch(header, dbTx)
+	if err != nil {
+		return fmt.Errorf("failed to restart epoch: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("could not commit db tx to reset consensus runtime: %w", err)
+	}
+
+	c.proposerCalculator = proposerCalculator
+	c.epoch = epoch
+	c.lastBuiltBlock = header
+
+	return nil
+}
+
 // createCommitEpochInput creates commit epoch input data
 func createCommitEpochInput(
 	currentBlock *types.Header, epoch *epochMetadata) *contractsapi.CommitEpochEpochManagerFn {
@@ -759,6 +819,20 @@ func (c *consensusRuntime) IsValidProposal(rawProposal []byte) bool {
 }
 
 func (c *consensusRuntime) IsValidValidator(msg *proto.IbftMessage) bool {
+	if !c.isValidSender(msg) {
+		return false
+	}
+
+	// conflicting messages are looked for out of the lock, as their evidence is written to the db
+	if c.evidenceCollector != nil {
+		c.evidenceCollector.addMessage(msg)
+	}
+
+	return true
+}
+
+// isValidSender checks that the message is signed by its sender, which is a validator of the current fsm
+func (c *consensusRuntime) isValidSender(msg *proto.IbftMessage) bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
@@ -205,7 +205,7 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
 
 	polybftBackendMock := new(polybftBackendMock)
-	polybftBackendMock.On("GetValidatorsWithTx", mock.Anything, mock.Anything, mock.Anything).Return(validatorSet).Times(3)
+	polybftBackendMock.On("GetValidatorsWithTx", mock.Anything, mock.Anything, mock.Anything).Return(validatorSet).Times(4)
 	polybftBackendMock.On("SetBlockTime", mock.Anything).Once()
 
 	txPool := new(txPoolMock)
@@ -253,6 +253,82 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	systemStateMock.AssertExpectations(t)
 }
 
+func TestConsensusRuntime_OnBlockInserted_AfterGap(t *testing.T) {
+	t.Parallel()
+
+	const (
+		epochSize       = uint64(10)
+		validatorsCount = 7
+		// the blocks in between are imported without their state, skipping the hook
+		lastBuiltBlock = uint64(5)
+		blockNumber    = epochSize + 5
+	)
+
+	validatorSet := validator.NewTestValidators(t, validatorsCount).GetPublicIdentities()
+	header, headerMap := createTestBlocks(t, blockNumber, epochSize, validatorSet)
+	builtBlock := consensus.BuildBlock(consensus.BuildBlockParams{
+		Header: header,
+	})
+
+	newEpochNumber := getEpochNumber(t, blockNumber, epochSize)
+	systemStateMock := new(systemStateMock)
+	systemStateMock.On("GetEpoch").Return(newEpochNumber).Once()
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetStateProviderForBlock", mock.Anything).Return(new(stateProviderMock)).Once()
+	blockchainMock.On("GetSystemState", mock.Anything, mock.Anything).Return(systemStateMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
+
+	polybftBackendMock := new(polybftBackendMock)
+	polybftBackendMock.On("GetValidatorsWithTx", mock.Anything, mock.Anything, mock.Anything).Return(validatorSet)
+	polybftBackendMock.On("SetBlockTime", mock.Anything).Once()
+
+	txPool := new(txPoolMock)
+	txPool.On("ResetWithBlock", mock.Anything).Once()
+
+	snapshot := NewProposerSnapshot(lastBuiltBlock+1, validatorSet)
+	polybftCfg := &PolyBFTConfig{EpochSize: epochSize}
+	config := &runtimeConfig{
+		GenesisConfig: &PolyBFTConfig{
+			EpochSize: epochSize,
+		},
+		genesisParams:  &chain.Params{Engine: map[string]interface{}{ConsensusName: polybftCfg}},
+		blockchain:     blockchainMock,
+		polybftBackend: polybftBackendMock,
+		txPool:         txPool,
+		State:          newTestState(t),
+	}
+	require.NoError(t, config.State.insertLastProcessedEventsBlock(builtBlock.Number()-1, nil))
+
+	runtime := &consensusRuntime{
+		proposerCalculator: NewProposerCalculatorFromSnapshot(snapshot, config, hclog.NewNullLogger()),
+		logger:             hclog.NewNullLogger(),
+		state:              config.State,
+		config:             config,
+		epoch: &epochMetadata{
+			Number:              1,
+			FirstBlockInEpoch:   1,
+			CurrentClientConfig: config.GenesisConfig,
+		},
+		lastBuiltBlock: &types.Header{Number: lastBuiltBlock},
+		bridgeManager:  &dummyBridgeManager{},
+		stakeManager:   &dummyStakeManager{},
+		eventProvider:  NewEventProvider(blockchainMock),
+		governanceManager: &dummyGovernanceManager{
+			getClientConfigFn: func() (*chain.Params, error) {
+				return config.genesisParams, nil
+			}},
+	}
+	runtime.OnBlockInserted(&types.FullBlock{Block: builtBlock})
+
+	// the epoch is restarted even if the block is not the last one of its epoch
+	require.True(t, runtime.state.EpochStore.isEpochInserted(newEpochNumber))
+	require.Equal(t, newEpochNumber, runtime.epoch.Number)
+	require.Equal(t, blockNumber, runtime.lastBuiltBlock.Number)
+
+	systemStateMock.AssertExpectations(t)
+}
+
 func TestConsensusRuntime_OnBlockInserted_MiddleOfEpoch(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,362 @@
+package polybft
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/go-ibft/messages"
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	hcf "github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	protobuf "google.golang.org/protobuf/proto"
+
+	polybftProto "github.com/0xPolygon/polygon-edge/consensus/polybft/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// evidenceHeightsWindow is the number of heights below the last inserted block
+	// whose consensus messages are kept to detect double signing
+	evidenceHeightsWindow = uint64(4)
+
+	// evidenceFutureHeights is the number of heights above the one being built
+	// whose consensus messages are kept to detect double signing
+	evidenceFutureHeights = uint64(2)
+
+	// evidenceRoundsWindow is the number of rounds below the highest round seen at a height
+	// whose consensus messages are kept to detect double signing
+	evidenceRoundsWindow = uint64(8)
+
+	// maxEvidenceMessagesPerHeight is the maximal number of consensus messages kept for a height
+	maxEvidenceMessagesPerHeight = 4096
+)
+
+var errNotConflictingMessages = errors.New("messages are not conflicting")
+
+// evidenceMessageKey identifies the consensus messages which a validator is allowed to sign only once
+type evidenceMessageKey struct {
+	height  uint64
+	round   uint64
+	msgType proto.MessageType
+	signer  types.Address
+}
+
+// evidenceCollector watches the consensus messages of the validators and records, as double sign evidence,
+// the messages of the same type signed by a validator for different proposals at the same height and round.
+// The evidence is gossiped to the rest of the network
+type evidenceCollector struct {
+	state   *State
+	topic   topic
+	backend polybftBackend
+	logger  hcf.Logger
+
+	// lock protects the messages seen so far and the last inserted block
+	lock      sync.Mutex
+	heights   map[uint64]*evidenceHeightMessages
+	lastBlock uint64
+}
+
+// evidenceHeightMessages are the consensus messages seen at a height
+type evidenceHeightMessages struct {
+	maxRound uint64
+	messages map[evidenceMessageKey]*proto.IbftMessage
+}
+
+// newEvidenceCollector creates a new instance of evidence collector, watching the heights after the given block
+func newEvidenceCollector(state *State, topic topic, backend polybftBackend, lastBlock uint64,
+	logger hcf.Logger) *evidenceCollector {
+	return &evidenceCollector{
+		state:     state,
+		topic:     topic,
+		backend:   backend,
+		logger:    logger,
+		heights:   make(map[uint64]*evidenceHeightMessages),
+		lastBlock: lastBlock,
+	}
+}
+
+// initTransport subscribes to the evidence topic (getting the evidence collected by the other nodes)
+func (e *evidenceCollector) initTransport() error {
+	if e.topic == nil {
+		return nil
+	}
+
+	return e.topic.Subscribe(func(obj interface{}, _ peer.ID) {
+		msg, ok := obj.(*polybftProto.TransportMessage)
+		if !ok {
+			e.logger.Warn("failed to deliver evidence, invalid msg", "obj", obj)
+
+			return
+		}
+
+		var evidence *types.DoubleSignEvidence
+
+		if err := json.Unmarshal(msg.Data, &evidence); err != nil {
+			e.logger.Warn("failed to deliver evidence", "error", err)
+
+			return
+		}
+
+		if err := e.saveEvidence(evidence); err != nil {
+			e.logger.Warn("failed to deliver evidence", "error", err)
+		}
+	})
+}
+
+// addMessage checks the given consensus message against the one signed by the same validator
+// for the same height, round and message type, and records the evidence if they conflict.
+// The signature of the message must have been verified already
+func (e *evidenceCollector) addMessage(msg *proto.IbftMessage) {
+	if msg.View == nil {
+		return
+	}
+
+	hash := ibftMessageProposalHash(msg)
+	if hash == nil {
+		// round change messages are not bound to a single proposal
+		return
+	}
+
+	key := evidenceMessageKey{
+		height:  msg.View.Height,
+		round:   msg.View.Round,
+		msgType: msg.Type,
+		signer:  types.BytesToAddress(msg.From),
+	}
+
+	first, exists := e.storeMessage(key, msg)
+	if !exists || bytes.Equal(ibftMessageProposalHash(first), hash) {
+		return
+	}
+
+	evidence, err := newDoubleSignEvidence(first, msg)
+	if err != nil {
+		e.logger.Error("failed to create double sign evidence", "signer", key.signer, "error", err)
+
+		return
+	}
+
+	inserted, err := e.state.EvidenceStore.insertDoubleSignEvidence(evidence, nil)
+	if err != nil {
+		e.logger.Error("failed to save double sign evidence", "signer", key.signer, "error", err)
+
+		return
+	}
+
+	if !inserted {
+		return
+	}
+
+	e.logger.Warn("validator signed conflicting messages",
+		"signer", key.signer, "height", key.height, "round", key.round, "type", key.msgType)
+
+	e.multicast(evidence)
+}
+
+// storeMessage stores the given message under its key, unless another message is already stored there,
+// which is returned instead. Only the messages of the heights and rounds around the current ones are stored,
+// up to a limit per height, so that the messages signed by the validators for arbitrary views are ignored
+func (e *evidenceCollector) storeMessage(key evidenceMessageKey,
+	msg *proto.IbftMessage) (*proto.IbftMessage, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if key.height > e.lastBlock+1+evidenceFutureHeights || key.height+evidenceHeightsWindow <= e.lastBlock {
+		return nil, false
+	}
+
+	height, ok := e.heights[key.height]
+	if !ok {
+		height = &evidenceHeightMessages{messages: make(map[evidenceMessageKey]*proto.IbftMessage)}
+		e.heights[key.height] = height
+	}
+
+	if key.round+evidenceRoundsWindow <= height.maxRound {
+		return nil, false
+	}
+
+	if key.round > height.maxRound {
+		height.maxRound = key.round
+
+		for k := range height.messages {
+			if k.round+evidenceRoundsWindow <= key.round {
+				delete(height.messages, k)
+			}
+		}
+	}
+
+	if first, exists := height.messages[key]; exists {
+		return first, true
+	}
+
+	if len(height.messages) < maxEvidenceMessagesPerHeight {
+		height.messages[key] = msg
+	}
+
+	return nil, false
+}
+
+// saveEvidence verifies the evidence received from the network and saves it
+func (e *evidenceCollector) saveEvidence(evidence *types.DoubleSignEvidence) error {
+	if err := verifyDoubleSignEvidence(evidence); err != nil {
+		return fmt.Errorf("invalid double sign evidence: %w", err)
+	}
+
+	if evidence.Height == 0 {
+		return errGenesisNotSigned
+	}
+
+	// the signer must be a validator of the height, that is of the parent block
+	validators, err := e.backend.GetValidators(evidence.Height-1, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get validators of height %d: %w", evidence.Height, err)
+	}
+
+	if !validators.ContainsAddress(evidence.Signer) {
+		return fmt.Errorf("signer %s is not a validator of height %d", evidence.Signer, evidence.Height)
+	}
+
+	inserted, err := e.state.EvidenceStore.insertDoubleSignEvidence(evidence, nil)
+	if err != nil {
+		return err
+	}
+
+	if inserted {
+		e.logger.Warn("received double sign evidence",
+			"signer", evidence.Signer, "height", evidence.Height, "round", evidence.Round, "type", evidence.MessageType)
+	}
+
+	return nil
+}
+
+// prune moves the window of the watched heights to the given inserted block,
+// dropping the messages of the heights below it
+func (e *evidenceCollector) prune(blockNumber uint64) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.lastBlock = blockNumber
+
+	for height := range e.heights {
+		if height+evidenceHeightsWindow <= blockNumber {
+			delete(e.heights, height)
+		}
+	}
+}
+
+// multicast publishes the given evidence to the rest of the network
+func (e *evidenceCollector) multicast(evidence *types.DoubleSignEvidence) {
+	if e.topic == nil {
+		return
+	}
+
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		e.logger.Warn("failed to marshal double sign evidence", "err", err)
+
+		return
+	}
+
+	if err := e.topic.Publish(&polybftProto.TransportMessage{Data: data}); err != nil {
+		e.logger.Warn("failed to gossip double sign evidence", "err", err)
+	}
+}
+
+// newDoubleSignEvidence creates the evidence of the given conflicting messages
+func newDoubleSignEvidence(first, second *proto.IbftMessage) (*types.DoubleSignEvidence, error) {
+	firstRaw, err := protobuf.Marshal(first)
+	if err != nil {
+		return nil, err
+	}
+
+	secondRaw, err := protobuf.Marshal(second)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DoubleSignEvidence{
+		Height:        first.View.Height,
+		Round:         first.View.Round,
+		MessageType:   first.Type.String(),
+		Signer:        types.BytesToAddress(first.From),
+		FirstMessage:  firstRaw,
+		SecondMessage: secondRaw,
+	}, nil
+}
+
+// verifyDoubleSignEvidence verifies that both the messages of the evidence are signed by its signer,
+// for the height, round and message type of the evidence, and that they are for different proposals
+func verifyDoubleSignEvidence(evidence *types.DoubleSignEvidence) error {
+	var hashes [2][]byte
+
+	for i, raw := range [][]byte{evidence.FirstMessage, evidence.SecondMessage} {
+		msg := &proto.IbftMessage{}
+		if err := protobuf.Unmarshal(raw, msg); err != nil {
+			return fmt.Errorf("failed to decode message: %w", err)
+		}
+
+		if msg.View == nil || msg.View.Height != evidence.Height || msg.View.Round != evidence.Round ||
+			msg.Type.String() != evidence.MessageType {
+			return fmt.Errorf("message doesn't match height %d, round %d and type %s",
+				evidence.Height, evidence.Round, evidence.MessageType)
+		}
+
+		signerAddress, err := recoverIbftMessageSigner(msg)
+		if err != nil {
+			return err
+		}
+
+		if signerAddress != evidence.Signer {
+			return fmt.Errorf("message is not signed by %s", evidence.Signer)
+		}
+
+		if hashes[i] = ibftMessageProposalHash(msg); hashes[i] == nil {
+			return fmt.Errorf("message of type %s is not bound to a proposal", evidence.MessageType)
+		}
+	}
+
+	if bytes.Equal(hashes[0], hashes[1]) {
+		return errNotConflictingMessages
+	}
+
+	return nil
+}
+
+// recoverIbftMessageSigner returns the address which signed the given consensus message,
+// failing if it isn't the sender of the message
+func recoverIbftMessageSigner(msg *proto.IbftMessage) (types.Address, error) {
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	signerAddress, err := wallet.RecoverAddressFromSignature(msg.Signature, msgNoSig)
+	if err != nil {
+		return types.ZeroAddress, fmt.Errorf("failed to recover address from signature: %w", err)
+	}
+
+	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
+		return types.ZeroAddress, fmt.Errorf("message is not signed by its sender %s", types.BytesToAddress(msg.From))
+	}
+
+	return signerAddress, nil
+}
+
+// ibftMessageProposalHash returns the hash of the proposal the given message is for,
+// or nil if the message is not bound to a proposal
+func ibftMessageProposalHash(msg *proto.IbftMessage) []byte {
+	switch msg.Type {
+	case proto.MessageType_PREPREPARE:
+		return messages.ExtractProposalHash(msg)
+	case proto.MessageType_PREPARE:
+		return messages.ExtractPrepareHash(msg)
+	case proto.MessageType_COMMIT:
+		return messages.ExtractCommitHash(msg)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package polybft
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	polybftProto "github.com/0xPolygon/polygon-edge/consensus/polybft/proto"
+)
+
+func TestEvidenceCollector_DoubleSign(t *testing.T) {
+	t.Parallel()
+
+	const (
+		height = uint64(10)
+		round  = uint64(1)
+	)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+
+	signPrepare := func(t *testing.T, alias string, proposalHash []byte) *proto.IbftMessage {
+		t.Helper()
+
+		account := validators.GetValidator(alias)
+		msg, err := account.Key().SignIBFTMessage(&proto.IbftMessage{
+			View: &proto.View{Height: height, Round: round},
+			From: account.Address().Bytes(),
+			Type: proto.MessageType_PREPARE,
+			Payload: &proto.IbftMessage_PrepareData{
+				PrepareData: &proto.PrepareMessage{ProposalHash: proposalHash},
+			},
+		})
+		require.NoError(t, err)
+
+		return msg
+	}
+
+	topic := &mockTopic{}
+	collector := newEvidenceCollector(newTestState(t), topic, nil, height-1, hclog.NewNullLogger())
+
+	// the same message received twice and the messages of different signers are no evidence
+	collector.addMessage(signPrepare(t, "A", []byte{1}))
+	collector.addMessage(signPrepare(t, "A", []byte{1}))
+	collector.addMessage(signPrepare(t, "B", []byte{2}))
+
+	evidence, err := collector.state.EvidenceStore.getDoubleSignEvidence(0, height, nil)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+	require.Nil(t, topic.consume())
+
+	// A signs a prepare of a different proposal
+	collector.addMessage(signPrepare(t, "A", []byte{3}))
+
+	evidence, err = collector.state.EvidenceStore.getDoubleSignEvidence(0, height, nil)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	require.Equal(t, validators.GetValidator("A").Address(), evidence[0].Signer)
+	require.Equal(t, proto.MessageType_PREPARE.String(), evidence[0].MessageType)
+	require.NoError(t, verifyDoubleSignEvidence(evidence[0]))
+
+	// the evidence is gossiped
+	published, ok := topic.consume().(*polybftProto.TransportMessage)
+	require.True(t, ok)
+
+	var gossiped *types.DoubleSignEvidence
+
+	require.NoError(t, json.Unmarshal(published.Data, &gossiped))
+	require.Equal(t, evidence[0], gossiped)
+
+	// a third conflicting message doesn't record the evidence again
+	collector.addMessage(signPrepare(t, "A", []byte{4}))
+	require.Nil(t, topic.consume())
+
+	// the messages of the heights out of the window are dropped
+	collector.prune(height + evidenceHeightsWindow)
+	require.Empty(t, collector.heights)
+}
+
+func TestEvidenceCollector_storeMessage(t *testing.T) {
+	t.Parallel()
+
+	const lastBlock = uint64(10)
+
+	collector := newEvidenceCollector(newTestState(t), nil, nil, lastBlock, hclog.NewNullLogger())
+
+	store := func(height, round uint64, signer byte) bool {
+		key := evidenceMessageKey{
+			height:  height,
+			round:   round,
+			msgType: proto.MessageType_PREPARE,
+			signer:  types.Address{signer},
+		}
+
+		collector.storeMessage(key, &proto.IbftMessage{})
+		_, stored := collector.storeMessage(key, &proto.IbftMessage{})
+
+		return stored
+	}
+
+	// the heights out of the window are ignored
+	require.False(t, store(lastBlock-evidenceHeightsWindow, 0, 1))
+	require.True(t, store(lastBlock+1-evidenceHeightsWindow, 0, 1))
+	require.True(t, store(lastBlock+1+evidenceFutureHeights, 0, 1))
+	require.False(t, store(lastBlock+2+evidenceFutureHeights, 0, 1))
+	require.False(t, store(math.MaxUint64, 0, 1))
+
+	// the rounds far below the highest one of the height are dropped and ignored
+	const height = lastBlock + 1
+
+	require.True(t, store(height, 0, 1))
+	require.True(t, store(height, evidenceRoundsWindow, 1))
+	require.Len(t, collector.heights[height].messages, 1)
+	require.False(t, store(height, 0, 2))
+	require.True(t, store(height, 1, 2))
+
+	// the messages of a height are capped
+	for signer := 0; len(collector.heights[height].messages) < maxEvidenceMessagesPerHeight; signer++ {
+		collector.storeMessage(evidenceMessageKey{
+			height:  height,
+			round:   evidenceRoundsWindow,
+			msgType: proto.MessageType_COMMIT,
+			signer:  types.BytesToAddress([]byte{byte(signer >> 8), byte(signer)}),
+		}, &proto.IbftMessage{})
+	}
+
+	require.False(t, store(height, evidenceRoundsWindow, 0xff))
+	require.Len(t, collector.heights[height].messages, maxEvidenceMessagesPerHeight)
+}
+
+func TestEvidenceCollector_saveEvidence(t *testing.T) {
+	t.Parallel()
+
+	const height = uint64(5)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"})
+
+	signCommit := func(t *testing.T, alias string, proposalHash []byte) []byte {
+		t.Helper()
+
+		account := validators.GetValidator(alias)
+		msg, err := account.Key().SignIBFTMessage(&proto.IbftMessage{
+			View: &proto.View{Height: height},
+			From: account.Address().Bytes(),
+			Type: proto.MessageType_COMMIT,
+			Payload: &proto.IbftMessage_CommitData{
+				CommitData: &proto.CommitMessage{ProposalHash: proposalHash, CommittedSeal: []byte{1}},
+			},
+		})
+		require.NoError(t, err)
+
+		raw, err := protobuf.Marshal(msg)
+		require.NoError(t, err)
+
+		return raw
+	}
+
+	backendMock := new(polybftBackendMock)
+	backendMock.On("GetValidators", height-1, mock.Anything).
+		Return(validators.GetPublicIdentities("A", "B"))
+
+	collector := newEvidenceCollector(newTestState(t), nil, backendMock, height-1, hclog.NewNullLogger())
+
+	newEvidence := func(alias string, first, second []byte) *types.DoubleSignEvidence {
+		return &types.DoubleSignEvidence{
+			Height:        height,
+			MessageType:   proto.MessageType_COMMIT.String(),
+			Signer:        validators.GetValidator(alias).Address(),
+			FirstMessage:  first,
+			SecondMessage: second,
+		}
+	}
+
+	// the messages are for the same proposal
+	err := collector.saveEvidence(newEvidence("A", signCommit(t, "A", []byte{1}), signCommit(t, "A", []byte{1})))
+	require.ErrorIs(t, err, errNotConflictingMessages)
+
+	// the messages are not signed by the signer of the evidence
+	err = collector.saveEvidence(newEvidence("A", signCommit(t, "A", []byte{1}), signCommit(t, "B", []byte{2})))
+	require.ErrorContains(t, err, "is not signed by")
+
+	// the signer is not a validator of the height
+	err = collector.saveEvidence(newEvidence("C", signCommit(t, "C", []byte{1}), signCommit(t, "C", []byte{2})))
+	require.ErrorContains(t, err, "is not a validator")
+
+	require.NoError(t,
+		collector.saveEvidence(newEvidence("B", signCommit(t, "B", []byte{1}), signCommit(t, "B", []byte{2}))))
+
+	evidence, err := collector.state.EvidenceStore.getDoubleSignEvidence(height, height, nil)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	require.Equal(t, validators.GetValidator("B").Address(), evidence[0].Signer)
+}
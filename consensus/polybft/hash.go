@@ -8,9 +8,9 @@ import (
 
 var setupHeaderHashFuncOnce sync.Once
 
-// polyBFTHeaderHash defines the custom implementation for getting the header hash,
-// because of the extraData field
-func setupHeaderHashFunc() {
+// SetupHeaderHashFunc overrides the header hash function with the PolyBFT one,
+// which leaves the seals out of the extraData field
+func SetupHeaderHashFunc() {
 	setupHeaderHashFuncOnce.Do(func() {
 		originalHeaderHash := types.HeaderHash
 
@@ -11,7 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_setupHeaderHashFunc(t *testing.T) {
+func Test_SetupHeaderHashFunc(t *testing.T) {
 	extra := &Extra{
 		Validators: &validator.ValidatorSetDelta{Removed: bitmap.Bitmap{1}},
 		Parent:     createSignature(t, []*wallet.Account{generateTestAccount(t)}, types.ZeroHash, signer.DomainCheckpointManager),
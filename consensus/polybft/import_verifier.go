@@ -0,0 +1,102 @@
+package polybft
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var _ blockchain.Verifier = (*ImportVerifier)(nil)
+
+// ImportVerifier verifies the blocks imported into the chain of a stopped node.
+// The headers and the seals of the blocks are verified like the ones of the synced blocks,
+// against the validator sets kept in a temporary consensus state,
+// so the consensus state of the node is left untouched
+type ImportVerifier struct {
+	polybft        *Polybft
+	blockTimeDrift uint64
+	stateDir       string
+}
+
+// NewImportVerifier creates the verifier of the blocks imported into the given blockchain
+func NewImportVerifier(bc *blockchain.Blockchain, config *chain.Params, logger hclog.Logger) (*ImportVerifier, error) {
+	logger = logger.Named("import_verifier")
+
+	return newImportVerifier(&blockchainWrapper{logger: logger, blockchain: bc}, config, logger)
+}
+
+func newImportVerifier(backend blockchainBackend, config *chain.Params, logger hclog.Logger) (*ImportVerifier, error) {
+	polyBFTConfig, err := GetPolyBFTConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	stateDir, err := os.MkdirTemp("", "polybft-import")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consensus state directory: %w", err)
+	}
+
+	stt, err := newState(filepath.Join(stateDir, stateFileName), make(chan struct{}))
+	if err != nil {
+		_ = os.RemoveAll(stateDir)
+
+		return nil, fmt.Errorf("failed to create consensus state: %w", err)
+	}
+
+	return &ImportVerifier{
+		polybft: &Polybft{
+			blockchain:      backend,
+			state:           stt,
+			validatorsCache: newValidatorsSnapshotCache(logger, stt, backend),
+			logger:          logger,
+		},
+		blockTimeDrift: polyBFTConfig.BlockTimeDrift,
+		stateDir:       stateDir,
+	}, nil
+}
+
+// VerifyHeader verifies the header of the imported block, along with the seals of the block and of its parent
+func (v *ImportVerifier) VerifyHeader(header *types.Header) error {
+	parent, ok := v.polybft.blockchain.GetHeaderByHash(header.ParentHash)
+	if !ok {
+		return fmt.Errorf("unable to get parent header by hash for block number %d", header.Number)
+	}
+
+	return v.polybft.verifyHeaderImpl(parent, header, v.blockTimeDrift, nil)
+}
+
+// ProcessHeaders is an implementation of blockchain.Verifier interface
+func (v *ImportVerifier) ProcessHeaders(headers []*types.Header) error {
+	return v.polybft.ProcessHeaders(headers)
+}
+
+// GetBlockCreator is an implementation of blockchain.Verifier interface
+func (v *ImportVerifier) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return v.polybft.GetBlockCreator(header)
+}
+
+// PreCommitState verifies the commitment state transactions of the imported block
+func (v *ImportVerifier) PreCommitState(block *types.Block, txn *state.Transition) error {
+	return v.polybft.PreCommitState(block, txn)
+}
+
+// GetLatestChainConfig is an implementation of blockchain.Verifier interface
+func (v *ImportVerifier) GetLatestChainConfig() (*chain.Params, error) {
+	return nil, nil
+}
+
+// Close closes the temporary consensus state and removes it
+func (v *ImportVerifier) Close() error {
+	if err := v.polybft.state.db.Close(); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(v.stateDir)
+}
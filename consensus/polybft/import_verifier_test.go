@@ -0,0 +1,108 @@
+package polybft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportVerifier_VerifyHeader(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidators(t, 4)
+	forgers := validator.NewTestValidators(t, 4)
+
+	polyBftConfig := PolyBFTConfig{
+		InitialValidatorSet: validators.GetParamValidators(),
+		EpochSize:           10,
+		SprintSize:          5,
+		BlockTimeDrift:      10,
+	}
+
+	headersMap := &testHeadersMap{}
+	timestamp := uint64(time.Now().UTC().Unix())
+
+	genesisDelta, err := validator.CreateValidatorSetDelta(nil, validators.GetPublicIdentities())
+	require.NoError(t, err)
+
+	genesisExtra := &Extra{Validators: genesisDelta, Checkpoint: &CheckpointData{}, Committed: &Signature{}}
+	genesis := &types.Header{Number: 0, Timestamp: timestamp - 10, ExtraData: genesisExtra.MarshalRLPTo(nil)}
+	genesis.ComputeHash()
+	headersMap.addHeader(genesis)
+
+	// createHeader creates the child of the given header, sealed by the given accounts
+	createHeader := func(parent *types.Header, parentSignature *Signature,
+		sealers []*wallet.Account) (*types.Header, *Signature) {
+		extra := &Extra{
+			Parent: parentSignature,
+			Checkpoint: &CheckpointData{
+				EpochNumber:           1,
+				CurrentValidatorsHash: types.StringToHash("Foo"),
+				NextValidatorsHash:    types.StringToHash("Bar"),
+			},
+			Committed: &Signature{},
+		}
+
+		header := &types.Header{
+			Number:     parent.Number + 1,
+			ParentHash: parent.Hash,
+			Timestamp:  parent.Timestamp + 1,
+			MixHash:    PolyBFTMixDigest,
+			Difficulty: 1,
+			ExtraData:  extra.MarshalRLPTo(nil),
+		}
+		header.ComputeHash()
+
+		checkpointHash, err := extra.Checkpoint.Hash(0, header.Number, header.Hash)
+		require.NoError(t, err)
+
+		extra.Committed = createSignature(t, sealers, checkpointHash, signer.DomainCheckpointManager)
+		header.ExtraData = extra.MarshalRLPTo(nil)
+
+		return header, extra.Committed
+	}
+
+	block1, block1Seal := createHeader(genesis, nil, validators.GetPrivateIdentities())
+	headersMap.addHeader(block1)
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headersMap.getHeader)
+	blockchainMock.On("GetHeaderByHash", mock.Anything).Return(headersMap.getHeaderByHash)
+
+	verifier, err := newImportVerifier(
+		blockchainMock,
+		&chain.Params{Engine: map[string]interface{}{ConsensusName: polyBftConfig}},
+		hclog.NewNullLogger(),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, verifier.Close())
+	})
+
+	// the block and its parent are sealed by the validators
+	block2, _ := createHeader(block1, block1Seal, validators.GetPrivateIdentities())
+	require.NoError(t, verifier.VerifyHeader(block2))
+
+	// the forged block is sealed by accounts which aren't validators
+	forged, _ := createHeader(block1, block1Seal, forgers.GetPrivateIdentities())
+	require.ErrorContains(t, verifier.VerifyHeader(forged), "failed to verify signatures for block 2")
+
+	// the forged block claims a parent seal the validators never made
+	forgedParentSeal := createSignature(t, forgers.GetPrivateIdentities(), block1.Hash, signer.DomainCheckpointManager)
+	forged, _ = createHeader(block1, forgedParentSeal, validators.GetPrivateIdentities())
+	require.ErrorContains(t, verifier.VerifyHeader(forged), "failed to verify signatures for parent of block 2")
+
+	// the parent of the block is unknown
+	orphan, _ := createHeader(block2, nil, validators.GetPrivateIdentities())
+	orphan.ParentHash = types.StringToHash("unknown")
+	require.ErrorContains(t, verifier.VerifyHeader(orphan), "unable to get parent header")
+}
@@ -414,5 +414,5 @@ func (tp *syncerMock) UpdateBlockTimeout(time.Duration) {
 
 func init() {
 	// setup custom hash header func
-	setupHeaderHashFunc()
+	SetupHeaderHashFunc()
 }
@@ -30,9 +30,10 @@ import (
 )
 
 const (
-	minSyncPeers = 2
-	pbftProto    = "/pbft/0.2"
-	bridgeProto  = "/bridge/0.2"
+	minSyncPeers  = 2
+	pbftProto     = "/pbft/0.2"
+	bridgeProto   = "/bridge/0.2"
+	evidenceProto = "/evidence/0.1"
 	// baseRoundTimeoutScaleFactor represents scaling factor,
 	// that is used to calculate the round 0 timeout for the go-ibft
 	baseRoundTimeoutScaleFactor = 2
@@ -56,7 +57,7 @@ type polybftBackend interface {
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
 	logger := params.Logger.Named("polybft")
 
-	setupHeaderHashFunc()
+	SetupHeaderHashFunc()
 
 	polybft := &Polybft{
 		config:  params,
@@ -113,6 +114,9 @@ type Polybft struct {
 	// topic for bridge messages
 	bridgeTopic *network.Topic
 
+	// evidenceTopic is the topic for the double sign evidence of the validators
+	evidenceTopic *network.Topic
+
 	// key encapsulates ECDSA address and BLS signing logic
 	key *wallet.Key
 
@@ -471,6 +475,8 @@ func (p *Polybft) Initialize() error {
 		p.config.Logger.Named("syncer"),
 		p.config.Network,
 		p.config.Blockchain,
+		p.config.StateStorage,
+		p.config.StateSync,
 		time.Duration(p.config.BlockTime)*3*time.Second,
 	)
 
@@ -582,6 +588,7 @@ func (p *Polybft) initRuntime() error {
 		polybftBackend:  p,
 		txPool:          p.txPool,
 		bridgeTopic:     p.bridgeTopic,
+		evidenceTopic:   p.evidenceTopic,
 		consensusConfig: p.config.Config,
 		eventTracker:    p.config.EventTracker,
 	}
@@ -848,6 +855,25 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// GetValidatorsProvider is an implementation of Consensus interface
+// Returns an instance of ValidatorsDataProvider
+func (p *Polybft) GetValidatorsProvider() consensus.ValidatorsDataProvider {
+	return p
+}
+
+// ResetHead is an implementation of Consensus interface
+// It drops the consensus data derived from the blocks after the given header,
+// once the head of the chain is set back to it, and restarts the consensus runtime from the header
+func (p *Polybft) ResetHead(header *types.Header) error {
+	if err := p.state.resetToBlock(header.Number); err != nil {
+		return fmt.Errorf("failed to reset consensus state: %w", err)
+	}
+
+	p.validatorsCache.removeSnapshotsAbove(header.Number)
+
+	return p.runtime.resetHead(header)
+}
+
 // FilterExtra is an implementation of Consensus interface
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
 	return GetIbftExtraClean(extra)
@@ -1,7 +1,10 @@
 package polybft
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	bolt "go.etcd.io/bbolt"
@@ -43,6 +46,8 @@ type State struct {
 	ProposerSnapshotStore *ProposerSnapshotStore
 	StakeStore            *StakeStore
 	GovernanceStore       *GovernanceStore
+	UptimeStore           *UptimeStore
+	EvidenceStore         *EvidenceStore
 }
 
 // newState creates new instance of State
@@ -61,6 +66,8 @@ func newState(path string, closeCh chan struct{}) (*State, error) {
 		ProposerSnapshotStore: &ProposerSnapshotStore{db: db},
 		StakeStore:            &StakeStore{db: db},
 		GovernanceStore:       &GovernanceStore{db: db},
+		UptimeStore:           &UptimeStore{db: db},
+		EvidenceStore:         &EvidenceStore{db: db},
 	}
 
 	if err = s.initStorages(); err != nil {
@@ -94,6 +101,14 @@ func (s *State) initStorages() error {
 			return err
 		}
 
+		if err := s.UptimeStore.initialize(tx); err != nil {
+			return err
+		}
+
+		if err := s.EvidenceStore.initialize(tx); err != nil {
+			return err
+		}
+
 		_, err := tx.CreateBucketIfNotExists(edgeEventsLastProcessedBlockBucket)
 		if err != nil {
 			return fmt.Errorf("failed to create bucket=%s: %w", string(edgeEventsLastProcessedBlockBucket), err)
@@ -146,6 +161,66 @@ func (s *State) getLastProcessedEventsBlock(dbTx *bolt.Tx) (uint64, error) {
 	return lastProcessed, err
 }
 
+// resetToBlock drops the consensus data derived from the blocks after the given one,
+// once the head of the chain is set back to it. The epoch of the new head is inserted
+// again on the epoch restart and the events of the following blocks are processed again
+func (s *State) resetToBlock(blockNumber uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := s.EpochStore.cleanEpochsFromDB(tx); err != nil {
+			return fmt.Errorf("failed to clean epochs: %w", err)
+		}
+
+		if err := s.EpochStore.removeValidatorSnapshotsAbove(blockNumber, tx); err != nil {
+			return fmt.Errorf("failed to remove validator snapshots: %w", err)
+		}
+
+		if err := s.ProposerSnapshotStore.removeProposerSnapshotAbove(blockNumber, tx); err != nil {
+			return fmt.Errorf("failed to remove proposer snapshot: %w", err)
+		}
+
+		if err := s.UptimeStore.removeValidatorsUptimeAbove(blockNumber, tx); err != nil {
+			return fmt.Errorf("failed to remove validators uptime: %w", err)
+		}
+
+		if err := s.ExitStore.removeExitEventsAbove(blockNumber, tx); err != nil {
+			return fmt.Errorf("failed to remove exit events: %w", err)
+		}
+
+		lastProcessed, err := s.getLastProcessedEventsBlock(tx)
+		if err != nil {
+			return err
+		}
+
+		if lastProcessed > blockNumber {
+			return s.insertLastProcessedEventsBlock(blockNumber, tx)
+		}
+
+		return nil
+	})
+}
+
+// ResetState drops the consensus data derived from the blocks after the given one from the state
+// in the consensus directory. Used by the offline tools once the head of the chain is set back,
+// nothing is done if the state doesn't exist
+func ResetState(consensusDir string, blockNumber uint64) error {
+	path := filepath.Join(consensusDir, "polybft", stateFileName)
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	s, err := newState(path, make(chan struct{}))
+	if err != nil {
+		return err
+	}
+
+	defer s.db.Close()
+
+	return s.resetToBlock(blockNumber)
+}
+
 // beginDBTransaction creates and begins a transaction on BoltDB
 // Note that transaction needs to be manually rollback or committed
 func (s *State) beginDBTransaction(isWriteTx bool) (*bolt.Tx, error) {
@@ -266,6 +266,48 @@ func (s *EpochStore) removeAllValidatorSnapshots() error {
 	})
 }
 
+// removeValidatorSnapshotsAbove removes the validator snapshots of the epochs ending after the given block
+func (s *EpochStore) removeValidatorSnapshotsAbove(blockNumber uint64, dbTx *bolt.Tx) error {
+	removeFn := func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorSnapshotsBucket)
+
+		// the keys are collected first, as the bucket can't be modified while iterating it
+		var keys [][]byte
+
+		err := bucket.ForEach(func(k, v []byte) error {
+			var snapshot *validatorSnapshot
+			if err := json.Unmarshal(v, &snapshot); err != nil {
+				return err
+			}
+
+			if snapshot.EpochEndingBlock > blockNumber {
+				keys = append(keys, k)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if dbTx == nil {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			return removeFn(tx)
+		})
+	}
+
+	return removeFn(dbTx)
+}
+
 // epochsDBStats returns stats of epochs bucket in db
 func (s *EpochStore) epochsDBStats() (*bolt.BucketStats, error) {
 	return bucketStats(epochsBucket, s.db)
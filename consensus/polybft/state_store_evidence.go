@@ -0,0 +1,125 @@
+package polybft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the double sign evidence of the validators
+	doubleSignEvidenceBucket = []byte("doubleSignEvidence")
+)
+
+/*
+Bolt DB schema:
+
+double sign evidence/
+|--> (height, round, message type, signer) -> *DoubleSignEvidence (json marshalled)
+*/
+
+type EvidenceStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *EvidenceStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(doubleSignEvidenceBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(doubleSignEvidenceBucket), err)
+	}
+
+	return nil
+}
+
+// insertDoubleSignEvidence inserts the given evidence, unless the evidence of the same signer, height,
+// round and message type is already present. Returns true if the evidence has been inserted
+func (s *EvidenceStore) insertDoubleSignEvidence(evidence *types.DoubleSignEvidence, dbTx *bolt.Tx) (bool, error) {
+	inserted := false
+
+	insertFn := func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(doubleSignEvidenceBucket)
+		key := doubleSignEvidenceKey(evidence)
+
+		if bucket.Get(key) != nil {
+			return nil
+		}
+
+		raw, err := json.Marshal(evidence)
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put(key, raw); err != nil {
+			return err
+		}
+
+		inserted = true
+
+		return nil
+	}
+
+	var err error
+
+	if dbTx == nil {
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			return insertFn(tx)
+		})
+	} else {
+		err = insertFn(dbTx)
+	}
+
+	return inserted, err
+}
+
+// getDoubleSignEvidence returns the double sign evidence of the heights in the given range (inclusive)
+func (s *EvidenceStore) getDoubleSignEvidence(fromHeight, toHeight uint64,
+	dbTx *bolt.Tx) ([]*types.DoubleSignEvidence, error) {
+	var (
+		result []*types.DoubleSignEvidence
+		err    error
+	)
+
+	getFn := func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(doubleSignEvidenceBucket).Cursor()
+		toKey := common.EncodeUint64ToBytes(toHeight)
+
+		for k, v := cursor.Seek(common.EncodeUint64ToBytes(fromHeight)); k != nil; k, v = cursor.Next() {
+			if bytes.Compare(k[:8], toKey) > 0 {
+				break
+			}
+
+			var evidence *types.DoubleSignEvidence
+			if err := json.Unmarshal(v, &evidence); err != nil {
+				return err
+			}
+
+			result = append(result, evidence)
+		}
+
+		return nil
+	}
+
+	if dbTx == nil {
+		err = s.db.View(func(tx *bolt.Tx) error {
+			return getFn(tx)
+		})
+	} else {
+		err = getFn(dbTx)
+	}
+
+	return result, err
+}
+
+// doubleSignEvidenceKey returns the key of the given evidence, ordered by height and round
+func doubleSignEvidenceKey(evidence *types.DoubleSignEvidence) []byte {
+	key := make([]byte, 0, 16+len(evidence.MessageType)+types.AddressLength)
+	key = append(key, common.EncodeUint64ToBytes(evidence.Height)...)
+	key = append(key, common.EncodeUint64ToBytes(evidence.Round)...)
+	key = append(key, evidence.MessageType...)
+
+	return append(key, evidence.Signer.Bytes()...)
+}
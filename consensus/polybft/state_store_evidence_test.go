@@ -0,0 +1,48 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_insertDoubleSignEvidence_getDoubleSignEvidence(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+
+	for height := uint64(1); height <= 5; height++ {
+		inserted, err := state.EvidenceStore.insertDoubleSignEvidence(&types.DoubleSignEvidence{
+			Height:       height,
+			MessageType:  "COMMIT",
+			Signer:       types.StringToAddress("1"),
+			FirstMessage: []byte{byte(height)},
+		}, nil)
+		require.NoError(t, err)
+		require.True(t, inserted)
+	}
+
+	// the evidence of the same signer, height, round and message type is kept once
+	inserted, err := state.EvidenceStore.insertDoubleSignEvidence(&types.DoubleSignEvidence{
+		Height:       3,
+		MessageType:  "COMMIT",
+		Signer:       types.StringToAddress("1"),
+		FirstMessage: []byte{10},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	evidence, err := state.EvidenceStore.getDoubleSignEvidence(2, 4, nil)
+	require.NoError(t, err)
+	require.Len(t, evidence, 3)
+
+	for i, e := range evidence {
+		require.Equal(t, uint64(i+2), e.Height)
+		require.Equal(t, []byte{byte(i + 2)}, e.FirstMessage)
+	}
+
+	evidence, err = state.EvidenceStore.getDoubleSignEvidence(6, 10, nil)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+}
@@ -192,6 +192,47 @@ func (s *ExitStore) getExitEvents(epoch uint64, filter func(exitEvent *ExitEvent
 	return events, err
 }
 
+// removeExitEventsAbove removes the exit events added in the blocks after the given one, along with their lookups
+func (s *ExitStore) removeExitEventsAbove(blockNumber uint64, dbTx *bolt.Tx) error {
+	removeFn := func(tx *bolt.Tx) error {
+		exitEventBucket := tx.Bucket(exitEventsBucket)
+		lookupBucket := tx.Bucket(exitEventToEpochLookupBucket)
+
+		// the keys are collected first, as the bucket can't be modified while iterating over it
+		var removed [][]byte
+
+		if err := exitEventBucket.ForEach(func(k, _ []byte) error {
+			// the block number is the last part of the key, see generateExitEventKey
+			if common.EncodeBytesToUint64(k[len(k)-8:]) > blockNumber {
+				removed = append(removed, k)
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range removed {
+			if err := exitEventBucket.Delete(k); err != nil {
+				return err
+			}
+
+			// the exit event id follows the epoch in the key
+			if err := lookupBucket.Delete(k[8:16]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if dbTx == nil {
+		return s.db.Update(removeFn)
+	}
+
+	return removeFn(dbTx)
+}
+
 // getAllAvailableRelayerEvents retrieves all Exit RelayerEventData that should be sent as a transactions
 func (s *ExitStore) GetAllAvailableRelayerEvents(limit int) (result []*RelayerEventMetaData, err error) {
 	if err = s.db.View(func(tx *bolt.Tx) error {
@@ -80,3 +80,29 @@ func (s *ProposerSnapshotStore) writeProposerSnapshot(snapshot *ProposerSnapshot
 
 	return insertFn(dbTx)
 }
+
+// removeProposerSnapshotAbove removes the proposer snapshot if it is computed from the blocks after the given one.
+// The snapshot is then computed again from the genesis
+func (s *ProposerSnapshotStore) removeProposerSnapshotAbove(blockNumber uint64, dbTx *bolt.Tx) error {
+	removeFn := func(tx *bolt.Tx) error {
+		snapshot, err := s.getProposerSnapshot(tx)
+		if err != nil {
+			return err
+		}
+
+		// the snapshot height is the next block to be computed
+		if snapshot == nil || snapshot.Height <= blockNumber+1 {
+			return nil
+		}
+
+		return tx.Bucket(proposerSnapshotBucket).Delete(proposerSnapshotKey)
+	}
+
+	if dbTx == nil {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			return removeFn(tx)
+		})
+	}
+
+	return removeFn(dbTx)
+}
@@ -0,0 +1,215 @@
+package polybft
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the uptime of the validators, per epoch
+	validatorUptimeBucket = []byte("validatorUptime")
+	// bucket to store the last block tracked in the uptime of each epoch
+	validatorUptimeLastBlockBucket = []byte("validatorUptimeLastBlock")
+)
+
+/*
+Bolt DB schema:
+
+validatorUptime/
+|--> epochNumber
+	|--> validator address -> *ValidatorUptime (json marshalled)
+
+validatorUptimeLastBlock/
+|--> epochNumber -> last tracked block number
+*/
+
+type UptimeStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *UptimeStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(validatorUptimeBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(validatorUptimeBucket), err)
+	}
+
+	if _, err := tx.CreateBucketIfNotExists(validatorUptimeLastBlockBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(validatorUptimeLastBlockBucket), err)
+	}
+
+	return nil
+}
+
+// getLastUptimeBlock returns the last block tracked in the uptime of the given epoch (0 if none)
+func (s *UptimeStore) getLastUptimeBlock(epoch uint64, dbTx *bolt.Tx) (uint64, error) {
+	var (
+		lastBlock uint64
+		err       error
+	)
+
+	getFn := func(tx *bolt.Tx) {
+		value := tx.Bucket(validatorUptimeLastBlockBucket).Get(common.EncodeUint64ToBytes(epoch))
+		if value != nil {
+			lastBlock = common.EncodeBytesToUint64(value)
+		}
+	}
+
+	if dbTx == nil {
+		err = s.db.View(func(tx *bolt.Tx) error {
+			getFn(tx)
+
+			return nil
+		})
+	} else {
+		getFn(dbTx)
+	}
+
+	return lastBlock, err
+}
+
+// addValidatorsUptime adds the uptime of the validators in the given block to the uptime of its epoch,
+// and returns the updated uptime of the validators of the block
+func (s *UptimeStore) addValidatorsUptime(epoch, blockNumber uint64,
+	blockUptime []*types.ValidatorUptime, dbTx *bolt.Tx) ([]*types.ValidatorUptime, error) {
+	result := make([]*types.ValidatorUptime, 0, len(blockUptime))
+
+	addFn := func(tx *bolt.Tx) error {
+		epochKey := common.EncodeUint64ToBytes(epoch)
+
+		bucket, err := tx.Bucket(validatorUptimeBucket).CreateBucketIfNotExists(epochKey)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range blockUptime {
+			uptime := &types.ValidatorUptime{Address: u.Address}
+
+			if value := bucket.Get(u.Address.Bytes()); value != nil {
+				if err := json.Unmarshal(value, uptime); err != nil {
+					return err
+				}
+			}
+
+			uptime.SignedBlocks += u.SignedBlocks
+			uptime.MissedSeals += u.MissedSeals
+			uptime.ProposedBlocks += u.ProposedBlocks
+			uptime.SkippedRounds += u.SkippedRounds
+
+			raw, err := json.Marshal(uptime)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(u.Address.Bytes(), raw); err != nil {
+				return err
+			}
+
+			result = append(result, uptime)
+		}
+
+		return tx.Bucket(validatorUptimeLastBlockBucket).Put(epochKey, common.EncodeUint64ToBytes(blockNumber))
+	}
+
+	var err error
+
+	if dbTx == nil {
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			return addFn(tx)
+		})
+	} else {
+		err = addFn(dbTx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getValidatorsUptime returns the uptime of the validators in the given epoch, ordered by their addresses
+func (s *UptimeStore) getValidatorsUptime(epoch uint64, dbTx *bolt.Tx) ([]*types.ValidatorUptime, error) {
+	var (
+		result []*types.ValidatorUptime
+		err    error
+	)
+
+	getFn := func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorUptimeBucket).Bucket(common.EncodeUint64ToBytes(epoch))
+		if bucket == nil {
+			return fmt.Errorf("uptime of epoch %d not found", epoch)
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			var uptime *types.ValidatorUptime
+			if err := json.Unmarshal(v, &uptime); err != nil {
+				return err
+			}
+
+			result = append(result, uptime)
+
+			return nil
+		})
+	}
+
+	if dbTx == nil {
+		err = s.db.View(func(tx *bolt.Tx) error {
+			return getFn(tx)
+		})
+	} else {
+		err = getFn(dbTx)
+	}
+
+	return result, err
+}
+
+// removeValidatorsUptimeAbove removes the uptime of the epochs tracked beyond the given block.
+// The counters of an epoch can't be rolled back to a block, so the epoch of the block is dropped as a whole
+func (s *UptimeStore) removeValidatorsUptimeAbove(blockNumber uint64, dbTx *bolt.Tx) error {
+	removeFn := func(tx *bolt.Tx) error {
+		lastBlockBucket := tx.Bucket(validatorUptimeLastBlockBucket)
+		uptimeBucket := tx.Bucket(validatorUptimeBucket)
+
+		// the keys are collected first, as the bucket can't be modified while iterating it
+		var keys [][]byte
+
+		err := lastBlockBucket.ForEach(func(k, v []byte) error {
+			if common.EncodeBytesToUint64(v) > blockNumber {
+				keys = append(keys, k)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := lastBlockBucket.Delete(k); err != nil {
+				return err
+			}
+
+			if uptimeBucket.Bucket(k) == nil {
+				continue
+			}
+
+			if err := uptimeBucket.DeleteBucket(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if dbTx == nil {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			return removeFn(tx)
+		})
+	}
+
+	return removeFn(dbTx)
+}
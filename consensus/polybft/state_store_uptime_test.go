@@ -0,0 +1,77 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_addValidatorsUptime_getValidatorsUptime(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+	)
+
+	state := newTestState(t)
+
+	_, err := state.UptimeStore.getValidatorsUptime(1, nil)
+	require.ErrorContains(t, err, "not found")
+
+	_, err = state.UptimeStore.addValidatorsUptime(1, 1, []*types.ValidatorUptime{
+		{Address: addr2, SignedBlocks: 1, ProposedBlocks: 1},
+		{Address: addr1, MissedSeals: 1},
+	}, nil)
+	require.NoError(t, err)
+
+	uptime, err := state.UptimeStore.addValidatorsUptime(1, 2, []*types.ValidatorUptime{
+		{Address: addr2, SignedBlocks: 1},
+		{Address: addr1, SignedBlocks: 1, ProposedBlocks: 1, SkippedRounds: 1},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*types.ValidatorUptime{
+		{Address: addr2, SignedBlocks: 2, ProposedBlocks: 1},
+		{Address: addr1, SignedBlocks: 1, MissedSeals: 1, ProposedBlocks: 1, SkippedRounds: 1},
+	}, uptime)
+
+	// the uptime is ordered by address
+	uptime, err = state.UptimeStore.getValidatorsUptime(1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*types.ValidatorUptime{
+		{Address: addr1, SignedBlocks: 1, MissedSeals: 1, ProposedBlocks: 1, SkippedRounds: 1},
+		{Address: addr2, SignedBlocks: 2, ProposedBlocks: 1},
+	}, uptime)
+
+	lastBlock, err := state.UptimeStore.getLastUptimeBlock(1, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lastBlock)
+}
+
+func TestState_removeValidatorsUptimeAbove(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	state := newTestState(t)
+
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		_, err := state.UptimeStore.addValidatorsUptime(epoch, epoch*10,
+			[]*types.ValidatorUptime{{Address: addr, SignedBlocks: 1}}, nil)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, state.UptimeStore.removeValidatorsUptimeAbove(15, nil))
+
+	_, err := state.UptimeStore.getValidatorsUptime(1, nil)
+	require.NoError(t, err)
+
+	for epoch := uint64(2); epoch <= 3; epoch++ {
+		_, err := state.UptimeStore.getValidatorsUptime(epoch, nil)
+		require.ErrorContains(t, err, "not found")
+
+		lastBlock, err := state.UptimeStore.getLastUptimeBlock(epoch, nil)
+		require.NoError(t, err)
+		require.Zero(t, lastBlock)
+	}
+}
@@ -0,0 +1,89 @@
+package polybft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestState_resetToBlock(t *testing.T) {
+	t.Parallel()
+
+	const (
+		epochSize   = uint64(10)
+		blockNumber = uint64(25)
+	)
+
+	state := newTestState(t)
+
+	keys, err := bls.CreateRandomBlsKeys(1)
+	require.NoError(t, err)
+
+	snapshot := validator.AccountSet{
+		&validator.ValidatorMetadata{Address: types.BytesToAddress([]byte{0x18}), BlsKey: keys[0].PublicKey()},
+	}
+
+	for epoch := uint64(1); epoch <= 4; epoch++ {
+		require.NoError(t, state.EpochStore.insertEpoch(epoch, nil))
+		require.NoError(t, state.EpochStore.insertValidatorSnapshot(
+			&validatorSnapshot{epoch, epoch * epochSize, snapshot}, nil))
+	}
+
+	require.NoError(t, state.ProposerSnapshotStore.writeProposerSnapshot(&ProposerSnapshot{Height: 41}, nil))
+	require.NoError(t, state.insertLastProcessedEventsBlock(40, nil))
+
+	for i, block := range []uint64{blockNumber - 1, blockNumber, blockNumber + 1, blockNumber + 10} {
+		require.NoError(t, state.ExitStore.insertExitEvent(&ExitEvent{
+			L2StateSyncedEvent: &contractsapi.L2StateSyncedEvent{ID: big.NewInt(int64(i + 1))},
+			EpochNumber:        block / epochSize,
+			BlockNumber:        block,
+		}, nil))
+	}
+
+	require.NoError(t, state.resetToBlock(blockNumber))
+
+	for epoch := uint64(1); epoch <= 4; epoch++ {
+		require.False(t, state.EpochStore.isEpochInserted(epoch))
+
+		snapshot, err := state.EpochStore.getValidatorSnapshot(epoch)
+		require.NoError(t, err)
+		require.Equal(t, epoch*epochSize <= blockNumber, snapshot != nil)
+	}
+
+	proposerSnapshot, err := state.ProposerSnapshotStore.getProposerSnapshot(nil)
+	require.NoError(t, err)
+	require.Nil(t, proposerSnapshot)
+
+	lastProcessed, err := state.getLastProcessedEventsBlock(nil)
+	require.NoError(t, err)
+	require.Equal(t, blockNumber, lastProcessed)
+
+	// the exit events of the blocks after the given one are removed
+	for i := uint64(1); i <= 4; i++ {
+		exitEvent, err := state.ExitStore.getExitEvent(i)
+		if i <= 2 {
+			require.NoError(t, err)
+			require.LessOrEqual(t, exitEvent.BlockNumber, blockNumber)
+		} else {
+			require.Error(t, err)
+		}
+	}
+
+	// the data of the older blocks is kept
+	require.NoError(t, state.ProposerSnapshotStore.writeProposerSnapshot(&ProposerSnapshot{Height: 20}, nil))
+	require.NoError(t, state.resetToBlock(blockNumber))
+
+	proposerSnapshot, err = state.ProposerSnapshotStore.getProposerSnapshot(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), proposerSnapshot.Height)
+
+	lastProcessed, err = state.getLastProcessedEventsBlock(nil)
+	require.NoError(t, err)
+	require.Equal(t, blockNumber, lastProcessed)
+}
@@ -1,6 +1,7 @@
 package polybft
 
 import (
+	"errors"
 	"fmt"
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
@@ -16,7 +17,7 @@ type BridgeTransport interface {
 
 // subscribeToIbftTopic subscribes to ibft topic
 func (p *Polybft) subscribeToIbftTopic() error {
-	return p.consensusTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+	return p.consensusTopic.Subscribe(func(obj interface{}, from peer.ID) {
 		if !p.runtime.IsActiveValidator() {
 			return
 		}
@@ -28,6 +29,13 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		// the invalid messages are rejected by the topic validator before they are delivered
+		if err := validateIbftMessage(msg); err != nil {
+			p.logger.Debug("invalid consensus message", "peer", from, "error", err)
+
+			return
+		}
+
 		p.ibft.AddMessage(msg)
 
 		p.logger.Debug(
@@ -40,6 +48,26 @@ func (p *Polybft) subscribeToIbftTopic() error {
 	})
 }
 
+// validateIbftMessage is the validator of the consensus topic. Honest peers publish and relay
+// only the messages signed by their senders, while the validator set membership is left
+// to the consensus, as the peer may lag behind
+func validateIbftMessage(obj interface{}) error {
+	msg, ok := obj.(*ibftProto.IbftMessage)
+	if !ok {
+		return errors.New("invalid type of consensus message")
+	}
+
+	if msg.View == nil {
+		return errors.New("consensus message without view")
+	}
+
+	if _, err := recoverIbftMessageSigner(msg); err != nil {
+		return fmt.Errorf("invalid consensus message signature: %w", err)
+	}
+
+	return nil
+}
+
 // createTopics create all topics for a PolyBft instance
 func (p *Polybft) createTopics() (err error) {
 	if p.genesisClientConfig.IsBridgeEnabled() {
@@ -54,6 +82,13 @@ func (p *Polybft) createTopics() (err error) {
 		return fmt.Errorf("failed to create consensus topic: %w", err)
 	}
 
+	p.consensusTopic.SetValidator(validateIbftMessage)
+
+	p.evidenceTopic, err = p.config.Network.NewTopic(evidenceProto, &polybftProto.TransportMessage{})
+	if err != nil {
+		return fmt.Errorf("failed to create evidence topic: %w", err)
+	}
+
 	return nil
 }
 
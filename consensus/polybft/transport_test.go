@@ -0,0 +1,48 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+)
+
+func TestValidateIbftMessage(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+
+	sign := func(t *testing.T, alias string, from []byte) *proto.IbftMessage {
+		t.Helper()
+
+		msg, err := validators.GetValidator(alias).Key().SignIBFTMessage(&proto.IbftMessage{
+			View: &proto.View{Height: 10, Round: 1},
+			From: from,
+			Type: proto.MessageType_PREPARE,
+			Payload: &proto.IbftMessage_PrepareData{
+				PrepareData: &proto.PrepareMessage{ProposalHash: []byte{1}},
+			},
+		})
+		require.NoError(t, err)
+
+		return msg
+	}
+
+	require.NoError(t, validateIbftMessage(sign(t, "A", validators.GetValidator("A").Address().Bytes())))
+
+	// the message signed by another validator than its sender
+	require.ErrorContains(t,
+		validateIbftMessage(sign(t, "B", validators.GetValidator("A").Address().Bytes())),
+		"invalid consensus message signature")
+
+	// the message without view
+	msg := sign(t, "A", validators.GetValidator("A").Address().Bytes())
+	msg.View = nil
+
+	require.ErrorContains(t, validateIbftMessage(msg), "consensus message without view")
+
+	// the message of another type
+	require.Error(t, validateIbftMessage(&proto.View{}))
+}
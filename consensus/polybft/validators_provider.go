@@ -0,0 +1,201 @@
+package polybft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errGenesisNotSigned = errors.New("genesis block is not signed")
+
+// GetValidatorSet returns the validators which validated the given block,
+// that is the validator set of its parent. The genesis validators are returned for the genesis block
+func (p *Polybft) GetValidatorSet(blockNumber uint64) ([]*types.ValidatorInfo, error) {
+	validators, err := p.getBlockValidators(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.ValidatorInfo, len(validators))
+
+	for i, v := range validators {
+		result[i] = &types.ValidatorInfo{
+			Address:     v.Address,
+			BlsKey:      v.BlsKey.Marshal(),
+			VotingPower: new(big.Int).Set(v.VotingPower),
+			IsActive:    v.IsActive,
+		}
+	}
+
+	return result, nil
+}
+
+// GetEpochInfo returns the epoch the given block belongs to, along with the sprint of the block.
+// The last block of the current epoch is the one expected by its epoch size
+func (p *Polybft) GetEpochInfo(blockNumber uint64) (*types.EpochInfo, error) {
+	_, extra, err := getBlockData(blockNumber, p.blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	// the genesis block is an epoch of its own
+	if blockNumber == 0 || extra.Checkpoint == nil {
+		return &types.EpochInfo{}, nil
+	}
+
+	data, err := p.runtime.getGuardedData()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		epochNumber = extra.Checkpoint.EpochNumber
+		epochSize   = data.epoch.CurrentClientConfig.EpochSize
+		sprintSize  = data.epoch.CurrentClientConfig.SprintSize
+		info        = &types.EpochInfo{Number: epochNumber, SprintSize: sprintSize}
+	)
+
+	if epochNumber == data.epoch.Number {
+		info.FirstBlock = data.epoch.FirstBlockInEpoch
+		info.LastBlock = info.FirstBlock + epochSize - 1
+	} else {
+		// the epoch is over, its bounds are found among the blocks
+		if info.FirstBlock, err = p.findFirstBlockOfEpoch(epochNumber, blockNumber); err != nil {
+			return nil, err
+		}
+
+		nextFirstBlock, err := p.findFirstBlockOfEpoch(epochNumber+1, p.blockchain.CurrentHeader().Number)
+		if err != nil {
+			return nil, err
+		}
+
+		info.LastBlock = nextFirstBlock - 1
+	}
+
+	if sprintSize > 0 {
+		info.SprintFirstBlock = info.FirstBlock + (blockNumber-info.FirstBlock)/sprintSize*sprintSize
+		info.SprintLastBlock = min(info.SprintFirstBlock+sprintSize-1, info.LastBlock)
+	}
+
+	return info, nil
+}
+
+// GetBlockSigners returns the validators which committed the given block,
+// decoding the bitmap of its committed seal against the validator set of its parent
+func (p *Polybft) GetBlockSigners(blockNumber uint64) (*types.BlockSigners, error) {
+	if blockNumber == 0 {
+		return nil, errGenesisNotSigned
+	}
+
+	_, extra, err := getBlockData(blockNumber, p.blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Committed == nil {
+		return nil, fmt.Errorf("committed seal of block %d not found", blockNumber)
+	}
+
+	validators, err := p.GetValidators(blockNumber-1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signers, err := validators.GetFilteredValidators(extra.Committed.Bitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BlockSigners{
+		Signers:           signers.GetAddresses(),
+		SignedVotingPower: signers.GetTotalVotingPower(),
+		TotalVotingPower:  validators.GetTotalVotingPower(),
+	}, nil
+}
+
+// GetProposer calculates the proposer of the given round of the block being built,
+// from the current proposer snapshot
+func (p *Polybft) GetProposer(height, round uint64) (types.Address, error) {
+	data, err := p.runtime.getGuardedData()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return data.proposerSnapshot.CalcProposer(round, height)
+}
+
+// GetVotingPowers returns the voting powers of the validators which validated the given block
+func (p *Polybft) GetVotingPowers(blockNumber uint64) (map[types.Address]*big.Int, error) {
+	validators, err := p.getBlockValidators(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	votingPowers := make(map[types.Address]*big.Int, len(validators))
+	for _, v := range validators {
+		votingPowers[v.Address] = new(big.Int).Set(v.VotingPower)
+	}
+
+	return votingPowers, nil
+}
+
+// getBlockValidators returns the validator set which validated the given block
+func (p *Polybft) getBlockValidators(blockNumber uint64) (validator.AccountSet, error) {
+	if _, ok := p.blockchain.GetHeaderByNumber(blockNumber); !ok {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+
+	if blockNumber == 0 {
+		return p.GetValidators(0, nil)
+	}
+
+	return p.GetValidators(blockNumber-1, nil)
+}
+
+// findFirstBlockOfEpoch searches the first block of the given epoch among the blocks up to the given one,
+// relying on the epoch numbers of the blocks being in ascending order
+func (p *Polybft) findFirstBlockOfEpoch(epochNumber, lastBlock uint64) (uint64, error) {
+	var searchErr error
+
+	// the genesis block is skipped, it belongs to no epoch
+	i := sort.Search(int(lastBlock), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		_, extra, err := getBlockData(uint64(i)+1, p.blockchain)
+		if err != nil {
+			searchErr = err
+
+			return true
+		}
+
+		return extra.Checkpoint.EpochNumber >= epochNumber
+	})
+
+	if searchErr != nil {
+		return 0, searchErr
+	}
+
+	return uint64(i) + 1, nil
+}
+
+// GetValidatorsUptime returns the uptime of the validators in the given epoch,
+// as tracked by this node from the blocks it has inserted
+func (p *Polybft) GetValidatorsUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	return p.state.UptimeStore.getValidatorsUptime(epoch, nil)
+}
+
+// GetDoubleSignEvidence returns the double sign evidence of the validators in the given range of heights,
+// either collected by this node or received from the network
+func (p *Polybft) GetDoubleSignEvidence(fromHeight, toHeight uint64) ([]*types.DoubleSignEvidence, error) {
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("the beginning of the range (%d) is above its end (%d)", fromHeight, toHeight)
+	}
+
+	return p.state.EvidenceStore.getDoubleSignEvidence(fromHeight, toHeight, nil)
+}
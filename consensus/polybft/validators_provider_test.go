@@ -0,0 +1,168 @@
+package polybft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolybft_ValidatorsProvider(t *testing.T) {
+	t.Parallel()
+
+	const (
+		epochSize  = uint64(10)
+		sprintSize = uint64(5)
+		head       = uint64(14)
+	)
+
+	validators := validator.NewTestValidators(t, 4)
+	validatorSet := validators.GetPublicIdentities()
+
+	polyBftConfig := &PolyBFTConfig{EpochSize: epochSize, SprintSize: sprintSize}
+
+	// signers of all the blocks are the first and the third validator
+	var signersBitmap bitmap.Bitmap
+
+	signersBitmap.Set(0)
+	signersBitmap.Set(2)
+
+	headersMap := &testHeadersMap{}
+
+	for i := uint64(0); i <= head; i++ {
+		delta, err := validator.CreateValidatorSetDelta(validatorSet, validatorSet)
+		require.NoError(t, err)
+
+		extra := &Extra{
+			Validators: delta,
+			Checkpoint: &CheckpointData{EpochNumber: (i + epochSize - 1) / epochSize},
+			Committed:  &Signature{Bitmap: signersBitmap},
+		}
+
+		if i == 0 {
+			extra.Validators, err = validator.CreateValidatorSetDelta(nil, validatorSet)
+			require.NoError(t, err)
+		}
+
+		headersMap.addHeader(&types.Header{Number: i, ExtraData: extra.MarshalRLPTo(nil)})
+	}
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headersMap.getHeader)
+	blockchainMock.On("CurrentHeader").Return(headersMap.getHeader(head))
+
+	polybft := &Polybft{
+		logger:     hclog.NewNullLogger(),
+		blockchain: blockchainMock,
+		validatorsCache: newValidatorsSnapshotCache(
+			hclog.NewNullLogger(),
+			newTestState(t),
+			blockchainMock,
+		),
+		runtime: &consensusRuntime{
+			lastBuiltBlock: headersMap.getHeader(head),
+			epoch: &epochMetadata{
+				Number:              2,
+				FirstBlockInEpoch:   epochSize + 1,
+				CurrentClientConfig: polyBftConfig,
+			},
+			proposerCalculator: NewProposerCalculatorFromSnapshot(
+				NewProposerSnapshot(head+1, validatorSet),
+				&runtimeConfig{State: newTestState(t)},
+				hclog.NewNullLogger(),
+			),
+		},
+	}
+
+	t.Run("validator set", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := polybft.GetValidatorSet(head)
+		require.NoError(t, err)
+		require.Len(t, res, len(validatorSet))
+
+		for i, v := range validatorSet {
+			assert.Equal(t, v.Address, res[i].Address)
+			assert.Equal(t, v.BlsKey.Marshal(), res[i].BlsKey)
+			assert.Equal(t, v.VotingPower, res[i].VotingPower)
+		}
+
+		_, err = polybft.GetValidatorSet(head + 1)
+		require.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("epoch info", func(t *testing.T) {
+		t.Parallel()
+
+		// block of the current epoch
+		res, err := polybft.GetEpochInfo(head)
+		require.NoError(t, err)
+		assert.Equal(t, &types.EpochInfo{
+			Number:           2,
+			FirstBlock:       11,
+			LastBlock:        20,
+			SprintSize:       sprintSize,
+			SprintFirstBlock: 11,
+			SprintLastBlock:  15,
+		}, res)
+
+		// block of a past epoch
+		res, err = polybft.GetEpochInfo(7)
+		require.NoError(t, err)
+		assert.Equal(t, &types.EpochInfo{
+			Number:           1,
+			FirstBlock:       1,
+			LastBlock:        10,
+			SprintSize:       sprintSize,
+			SprintFirstBlock: 6,
+			SprintLastBlock:  10,
+		}, res)
+
+		res, err = polybft.GetEpochInfo(0)
+		require.NoError(t, err)
+		assert.Equal(t, &types.EpochInfo{}, res)
+	})
+
+	t.Run("block signers", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := polybft.GetBlockSigners(head)
+		require.NoError(t, err)
+
+		assert.Equal(t, []types.Address{validatorSet[0].Address, validatorSet[2].Address}, res.Signers)
+		assert.Equal(t, new(big.Int).Add(validatorSet[0].VotingPower, validatorSet[2].VotingPower), res.SignedVotingPower)
+		assert.Equal(t, validatorSet.GetTotalVotingPower(), res.TotalVotingPower)
+
+		_, err = polybft.GetBlockSigners(0)
+		require.ErrorIs(t, err, errGenesisNotSigned)
+	})
+
+	t.Run("proposer", func(t *testing.T) {
+		t.Parallel()
+
+		proposer, err := polybft.GetProposer(head+1, 0)
+		require.NoError(t, err)
+		assert.True(t, validatorSet.ContainsAddress(proposer))
+
+		_, err = polybft.GetProposer(head, 0)
+		require.ErrorContains(t, err, "invalid height")
+	})
+
+	t.Run("voting powers", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := polybft.GetVotingPowers(head)
+		require.NoError(t, err)
+		require.Len(t, res, len(validatorSet))
+
+		for _, v := range validatorSet {
+			assert.Equal(t, v.VotingPower, res[v.Address])
+		}
+	})
+}
@@ -283,6 +283,18 @@ func (v *validatorsSnapshotCache) cleanup(dbTx *bolt.Tx) error {
 	return nil
 }
 
+// removeSnapshotsAbove removes the cached snapshots of the epochs ending after the given block from memory
+func (v *validatorsSnapshotCache) removeSnapshotsAbove(blockNumber uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for epoch, snapshot := range v.snapshots {
+		if snapshot.EpochEndingBlock > blockNumber {
+			delete(v.snapshots, epoch)
+		}
+	}
+}
+
 // getLastCachedSnapshot gets the latest snapshot cached
 // If it doesn't have snapshot cached for desired epoch, it will return the latest one it has
 func (v *validatorsSnapshotCache) getLastCachedSnapshot(currentEpoch uint64,
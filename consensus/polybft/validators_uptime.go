@@ -0,0 +1,161 @@
+package polybft
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+// blockUptime is the uptime of the validators gathered from a single block
+type blockUptime struct {
+	uptime    []*types.ValidatorUptime
+	byAddress map[types.Address]*types.ValidatorUptime
+}
+
+func newBlockUptime(validators validator.AccountSet) *blockUptime {
+	b := &blockUptime{
+		uptime:    make([]*types.ValidatorUptime, len(validators)),
+		byAddress: make(map[types.Address]*types.ValidatorUptime, len(validators)),
+	}
+
+	for i, v := range validators {
+		b.uptime[i] = &types.ValidatorUptime{Address: v.Address}
+		b.byAddress[v.Address] = b.uptime[i]
+	}
+
+	return b
+}
+
+// trackValidatorsUptime adds the given block to the uptime of the validators:
+// its proposer and the proposers of the rounds which failed to produce it are added to its epoch,
+// while the validators which signed its parent or missed the parent seal are added to the epoch of the parent.
+// The committed seal of a block differs from node to node, so the seals are taken from
+// the parent signatures of the next block, which all the nodes agree on.
+// It must be called before the proposer snapshot is moved to the next block
+func (c *consensusRuntime) trackValidatorsUptime(req *PostBlockRequest) error {
+	header := req.FullBlock.Block.Header
+
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return err
+	}
+
+	if extra.Checkpoint == nil {
+		return fmt.Errorf("checkpoint of block %d not found", header.Number)
+	}
+
+	current := newBlockUptime(c.epoch.Validators)
+
+	// genesis has no committed seal, so block 1 has no parent signatures
+	if header.Number > 1 {
+		parentEpoch, parentUptime, err := c.getParentSealsUptime(header, extra, req.DBTx)
+		if err != nil {
+			return err
+		}
+
+		if parentEpoch == req.Epoch {
+			for _, u := range parentUptime.uptime {
+				if uptime, ok := current.byAddress[u.Address]; ok {
+					uptime.SignedBlocks += u.SignedBlocks
+					uptime.MissedSeals += u.MissedSeals
+				}
+			}
+		} else {
+			// the parent is the last block of the previous epoch
+			if err := c.addBlockUptime(parentEpoch, header.Number, parentUptime, req.DBTx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if uptime, ok := current.byAddress[types.BytesToAddress(header.Miner)]; ok {
+		uptime.ProposedBlocks = 1
+	}
+
+	if extra.Checkpoint.BlockRound > 0 {
+		// the snapshot is a copy, calculating the proposers doesn't affect the proposer calculator
+		snapshot, ok := c.proposerCalculator.GetSnapshot()
+		if !ok || snapshot.Height != header.Number {
+			c.logger.Debug("skipped rounds of the block not tracked, proposer snapshot unavailable",
+				"block", header.Number)
+		} else {
+			for round := uint64(0); round < extra.Checkpoint.BlockRound; round++ {
+				proposer, err := snapshot.CalcProposer(round, header.Number)
+				if err != nil {
+					return err
+				}
+
+				if uptime, ok := current.byAddress[proposer]; ok {
+					uptime.SkippedRounds++
+				}
+			}
+		}
+	}
+
+	return c.addBlockUptime(req.Epoch, header.Number, current, req.DBTx)
+}
+
+// getParentSealsUptime returns the epoch of the parent of the given block and the validators
+// which signed the parent or missed its seal, according to the parent signatures of the block
+func (c *consensusRuntime) getParentSealsUptime(header *types.Header, extra *Extra,
+	dbTx *bolt.Tx) (uint64, *blockUptime, error) {
+	if extra.Parent == nil {
+		return 0, nil, fmt.Errorf("parent signatures of block %d not found", header.Number)
+	}
+
+	_, parentExtra, err := getBlockData(header.Number-1, c.config.blockchain)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if parentExtra.Checkpoint == nil {
+		return 0, nil, fmt.Errorf("checkpoint of block %d not found", header.Number-1)
+	}
+
+	// the parent signatures are verified against the validators of the parent (see ValidateParentSignatures)
+	validators, err := c.config.polybftBackend.GetValidatorsWithTx(header.Number-2, nil, dbTx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	signers, err := validators.GetFilteredValidators(extra.Parent.Bitmap)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	parentUptime := newBlockUptime(validators)
+
+	for _, uptime := range parentUptime.uptime {
+		if signers.ContainsAddress(uptime.Address) {
+			uptime.SignedBlocks = 1
+		} else {
+			uptime.MissedSeals = 1
+		}
+	}
+
+	return parentExtra.Checkpoint.EpochNumber, parentUptime, nil
+}
+
+// addBlockUptime adds the uptime gathered from the given block to the uptime of the epoch,
+// unless the block is already part of it
+func (c *consensusRuntime) addBlockUptime(epoch, blockNumber uint64, b *blockUptime, dbTx *bolt.Tx) error {
+	lastBlock, err := c.state.UptimeStore.getLastUptimeBlock(epoch, dbTx)
+	if err != nil {
+		return err
+	}
+
+	if blockNumber <= lastBlock {
+		return nil
+	}
+
+	uptime, err := c.state.UptimeStore.addValidatorsUptime(epoch, blockNumber, b.uptime, dbTx)
+	if err != nil {
+		return err
+	}
+
+	updateValidatorsUptimeMetrics(uptime)
+
+	return nil
+}
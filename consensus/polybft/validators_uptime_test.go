@@ -0,0 +1,144 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusRuntime_trackValidatorsUptime(t *testing.T) {
+	t.Parallel()
+
+	const (
+		epoch       = uint64(2)
+		blockNumber = uint64(12)
+		blockRound  = uint64(2)
+	)
+
+	cases := []struct {
+		name        string
+		parentEpoch uint64
+	}{
+		{name: "parent in the same epoch", parentEpoch: epoch},
+		{name: "parent in the previous epoch", parentEpoch: epoch - 1},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			validators := validator.NewTestValidators(t, 4)
+			validatorSet := validators.GetPublicIdentities()
+			snapshot := NewProposerSnapshot(blockNumber, validatorSet)
+
+			newUptime := func() map[types.Address]*types.ValidatorUptime {
+				uptime := make(map[types.Address]*types.ValidatorUptime, len(validatorSet))
+				for _, v := range validatorSet {
+					uptime[v.Address] = &types.ValidatorUptime{Address: v.Address}
+				}
+
+				return uptime
+			}
+
+			// the proposers of the failed rounds and of the block
+			expected := newUptime()
+
+			for round := uint64(0); round < blockRound; round++ {
+				proposer, err := snapshot.Copy().CalcProposer(round, blockNumber)
+				require.NoError(t, err)
+
+				expected[proposer].SkippedRounds++
+			}
+
+			proposer, err := snapshot.Copy().CalcProposer(blockRound, blockNumber)
+			require.NoError(t, err)
+
+			expected[proposer].ProposedBlocks++
+
+			// the parent is signed by all the validators but the last one,
+			// while the local committed seal of the block is signed by the last one only
+			expectedParent := expected
+			if c.parentEpoch != epoch {
+				expectedParent = newUptime()
+			}
+
+			var parentBitmap, committedBitmap bitmap.Bitmap
+
+			for i, v := range validatorSet {
+				if i < len(validatorSet)-1 {
+					parentBitmap.Set(uint64(i))
+					expectedParent[v.Address].SignedBlocks++
+				} else {
+					committedBitmap.Set(uint64(i))
+					expectedParent[v.Address].MissedSeals++
+				}
+			}
+
+			extra := &Extra{
+				Checkpoint: &CheckpointData{EpochNumber: epoch, BlockRound: blockRound},
+				Parent:     &Signature{Bitmap: parentBitmap},
+				Committed:  &Signature{Bitmap: committedBitmap},
+			}
+			header := &types.Header{Number: blockNumber, Miner: proposer.Bytes(), ExtraData: extra.MarshalRLPTo(nil)}
+
+			parentExtra := &Extra{Checkpoint: &CheckpointData{EpochNumber: c.parentEpoch}}
+			parentHeader := &types.Header{Number: blockNumber - 1, ExtraData: parentExtra.MarshalRLPTo(nil)}
+
+			blockchainMock := new(blockchainMock)
+			blockchainMock.On("GetHeaderByNumber", blockNumber-1).Return(parentHeader, true)
+
+			polybftBackendMock := new(polybftBackendMock)
+			polybftBackendMock.On("GetValidatorsWithTx", blockNumber-2, mock.Anything, mock.Anything).
+				Return(validatorSet)
+
+			state := newTestState(t)
+			runtime := &consensusRuntime{
+				state:  state,
+				logger: hclog.NewNullLogger(),
+				config: &runtimeConfig{blockchain: blockchainMock, polybftBackend: polybftBackendMock},
+				epoch:  &epochMetadata{Number: epoch, Validators: validatorSet},
+				proposerCalculator: NewProposerCalculatorFromSnapshot(
+					snapshot,
+					&runtimeConfig{State: state},
+					hclog.NewNullLogger(),
+				),
+			}
+
+			req := &PostBlockRequest{FullBlock: &types.FullBlock{Block: &types.Block{Header: header}}, Epoch: epoch}
+
+			require.NoError(t, runtime.trackValidatorsUptime(req))
+			// the block is tracked only once
+			require.NoError(t, runtime.trackValidatorsUptime(req))
+
+			requireUptime := func(epoch uint64, expected map[types.Address]*types.ValidatorUptime) {
+				t.Helper()
+
+				uptime, err := state.UptimeStore.getValidatorsUptime(epoch, nil)
+				require.NoError(t, err)
+				require.Len(t, uptime, len(validatorSet))
+
+				for _, u := range uptime {
+					require.Equal(t, expected[u.Address], u)
+				}
+			}
+
+			requireUptime(epoch, expected)
+
+			if c.parentEpoch != epoch {
+				requireUptime(c.parentEpoch, expectedParent)
+			}
+
+			// the proposer snapshot of the calculator is untouched
+			current, ok := runtime.proposerCalculator.GetSnapshot()
+			require.True(t, ok)
+			require.Nil(t, current.Proposer)
+		})
+	}
+}
@@ -28,7 +28,8 @@ func Listen(path string) (net.Listener, error) {
 		return nil, err
 	}
 
-	if removeErr := os.Remove(path); removeErr != nil {
+	// remove a stale socket left behind by a previous run
+	if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
 		return nil, removeErr
 	}
 
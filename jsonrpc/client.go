@@ -50,6 +50,14 @@ func (e *EthClient) GetStorageAt(addr types.Address, slot types.Hash, block Bloc
 	return hash, err
 }
 
+// GetProof returns the merkle proof of the account and its storage slots at a given block (EIP-1186)
+func (e *EthClient) GetProof(addr types.Address, keys []types.Hash, block BlockNumberOrHash) (*AccountProof, error) {
+	var proof *AccountProof
+	err := e.client.Call("eth_getProof", &proof, addr, keys, block.String())
+
+	return proof, err
+}
+
 // BlockNumber returns the number of most recent block
 func (e *EthClient) BlockNumber() (uint64, error) {
 	var out string
@@ -262,6 +270,45 @@ func (e *EthClient) TxPoolStatus() (*StatusResponse, error) {
 	return &out, nil
 }
 
+// PolyBFTEpoch returns the epoch the given block belongs to
+func (e *EthClient) PolyBFTEpoch(blockNumber BlockNumber) (*types.EpochInfo, error) {
+	var out epochInfo
+	if err := e.client.Call("polybft_getEpoch", &out, blockNumber.String()); err != nil {
+		return nil, err
+	}
+
+	return &types.EpochInfo{
+		Number:           uint64(out.Number),
+		FirstBlock:       uint64(out.FirstBlock),
+		LastBlock:        uint64(out.LastBlock),
+		SprintSize:       uint64(out.SprintSize),
+		SprintFirstBlock: uint64(out.SprintFirstBlock),
+		SprintLastBlock:  uint64(out.SprintLastBlock),
+	}, nil
+}
+
+// PolyBFTValidatorsUptime returns the uptime of the validators in the given epoch
+func (e *EthClient) PolyBFTValidatorsUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	var out []*validatorUptime
+	if err := e.client.Call("polybft_getValidatorsUptime", &out, argUint64(epoch)); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.ValidatorUptime, len(out))
+
+	for i, u := range out {
+		result[i] = &types.ValidatorUptime{
+			Address:        u.Address,
+			SignedBlocks:   uint64(u.SignedBlocks),
+			MissedSeals:    uint64(u.MissedSeals),
+			ProposedBlocks: uint64(u.ProposedBlocks),
+			SkippedRounds:  uint64(u.SkippedRounds),
+		}
+	}
+
+	return result, nil
+}
+
 func (e *EthClient) Close() error {
 	return e.client.Close()
 }
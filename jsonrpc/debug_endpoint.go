@@ -16,16 +16,20 @@ import (
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/fourbytetracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/davecgh/go-spew/spew"
 )
 
 const (
-	callTracerName = "callTracer"
-	blockString    = "block"
-	mutexString    = "mutex"
-	heapString     = "heap"
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+	fourByteTracerName = "4byteTracer"
+	blockString        = "block"
+	mutexString        = "mutex"
+	heapString         = "heap"
 	// AccountRangeMaxResults is the maximum number of results to be returned per call
 	AccountRangeMaxResults = 256
 )
@@ -109,6 +113,10 @@ type debugBlockchainStore interface {
 
 	// TraceCall traces a single call at the point when the given header is mined
 	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
+	// SetHead sets the head of the chain back to the block with the given number,
+	// resetting the transaction pool and the consensus data
+	SetHead(number uint64) error
 }
 
 type debugTxPoolStore interface {
@@ -462,13 +470,20 @@ func (d *Debug) Stacks(filter *string) (interface{}, error) {
 }
 
 type TraceConfig struct {
-	EnableMemory      bool    `json:"enableMemory"`
-	DisableStack      bool    `json:"disableStack"`
-	DisableStorage    bool    `json:"disableStorage"`
-	EnableReturnData  bool    `json:"enableReturnData"`
-	DisableStructLogs bool    `json:"disableStructLogs"`
-	Timeout           *string `json:"timeout"`
-	Tracer            string  `json:"tracer"`
+	EnableMemory      bool          `json:"enableMemory"`
+	DisableStack      bool          `json:"disableStack"`
+	DisableStorage    bool          `json:"disableStorage"`
+	EnableReturnData  bool          `json:"enableReturnData"`
+	DisableStructLogs bool          `json:"disableStructLogs"`
+	Timeout           *string       `json:"timeout"`
+	Tracer            string        `json:"tracer"`
+	TracerConfig      *TracerConfig `json:"tracerConfig"`
+}
+
+// TracerConfig is the configuration of the tracer selected by name
+type TracerConfig struct {
+	// DiffMode makes the prestate tracer report the changes made by the transaction
+	DiffMode bool `json:"diffMode"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -843,6 +858,17 @@ func (d *Debug) Verbosity(level int) (interface{}, error) {
 	)
 }
 
+// SetHead sets the head of the chain back to the block with the given number,
+// removing the newer blocks. The state of the block has to be available.
+func (d *Debug) SetHead(number argUint64) (interface{}, error) {
+	return d.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			return nil, d.store.SetHead(uint64(number))
+		},
+	)
+}
+
 // IntermediateRoots executes a block, and returns a list
 // of intermediate roots: the state root after each transaction.
 func (d *Debug) IntermediateRoots(
@@ -1135,9 +1161,19 @@ func newTracer(config *TraceConfig) (
 
 	var tracer tracer.Tracer
 
-	if config.Tracer == callTracerName {
+	switch config.Tracer {
+	case callTracerName:
 		tracer = &calltracer.CallTracer{}
-	} else {
+	case prestateTracerName:
+		var diffMode bool
+		if config.TracerConfig != nil {
+			diffMode = config.TracerConfig.DiffMode
+		}
+
+		tracer = prestatetracer.NewPrestateTracer(prestatetracer.Config{DiffMode: diffMode})
+	case fourByteTracerName:
+		tracer = fourbytetracer.NewFourByteTracer()
+	default:
 		tracer = structtracer.NewStructTracer(structtracer.Config{
 			EnableMemory:     config.EnableMemory && !config.DisableStructLogs,
 			EnableStack:      !config.DisableStack && !config.DisableStructLogs,
@@ -1147,6 +1183,13 @@ func newTracer(config *TraceConfig) (
 		})
 	}
 
+	// cancellation of context is done by caller
+	return tracer, cancelOnTimeout(tracer, timeout), nil
+}
+
+// cancelOnTimeout cancels the tracer when the timeout expires,
+// unless the returned function is called before
+func cancelOnTimeout(tracer tracer.Tracer, timeout time.Duration) context.CancelFunc {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	go func() {
@@ -1157,6 +1200,5 @@ func newTracer(config *TraceConfig) (
 		}
 	}()
 
-	// cancellation of context is done by caller
-	return tracer, cancel, nil
+	return cancel
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/fourbytetracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -41,6 +43,7 @@ type debugEndpointMockStore struct {
 	traceCallFn           func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn            func(types.Address) uint64
 	getAccountFn          func(types.Hash, types.Address) (*Account, error)
+	setHeadFn             func(uint64) error
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
@@ -135,6 +138,10 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) SetHead(number uint64) error {
+	return s.setHeadFn(number)
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -430,6 +437,60 @@ func TestPrintBlock(t *testing.T) {
 	}
 }
 
+func TestSetHead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		number    uint64
+		store     *debugEndpointMockStore
+		returnErr string
+	}{
+		{
+			name:   "state not available",
+			number: testHeader10.Number,
+			store: &debugEndpointMockStore{
+				setHeadFn: func(number uint64) error {
+					require.Equal(t, testHeader10.Number, number)
+
+					return state.ErrStateNotAvailable
+				},
+			},
+			returnErr: state.ErrStateNotAvailable.Error(),
+		},
+		{
+			name:   "head set back",
+			number: testHeader10.Number,
+			store: &debugEndpointMockStore{
+				setHeadFn: func(number uint64) error {
+					require.Equal(t, testHeader10.Number, number)
+
+					return nil
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := NewDebug(test.store, 100000)
+			res, err := endpoint.SetHead(argUint64(test.number))
+
+			require.Nil(t, res)
+
+			if test.returnErr != "" {
+				require.ErrorContains(t, err, test.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestTraceBlockByHash(t *testing.T) {
 	t.Parallel()
 
@@ -2110,4 +2171,34 @@ func Test_newTracer(t *testing.T) {
 			EnableStructLogs: false,
 		}, st.Config)
 	})
+
+	t.Run("should create the tracer selected by name", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       prestateTracerName,
+			TracerConfig: &TracerConfig{DiffMode: true},
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		require.NoError(t, err)
+
+		pt, ok := tracer.(*prestatetracer.PrestateTracer)
+		require.True(t, ok)
+		require.True(t, pt.Config.DiffMode)
+
+		tracer, cancel, err = newTracer(&TraceConfig{
+			Tracer: fourByteTracerName,
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		require.NoError(t, err)
+		require.IsType(t, &fourbytetracer.FourByteTracer{}, tracer)
+	})
 }
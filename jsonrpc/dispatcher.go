@@ -20,6 +20,12 @@ import (
 var (
 	jsonIt     = jsonIter.ConfigCompatibleWithStandardLibrary
 	fastJSONIt = jsonIter.ConfigFastest
+
+	// privilegedMethods are the methods modifying the chain, which are served
+	// only by the privileged listeners, whatever namespaces they expose
+	privilegedMethods = map[string]struct{}{
+		"debug_setHead": {},
+	}
 )
 
 type serviceData struct {
@@ -44,7 +50,9 @@ type endpoints struct {
 	Net      *Net
 	TxPool   *TxPool
 	Bridge   *Bridge
+	PolyBFT  *PolyBFT
 	Debug    *Debug
+	Trace    *Trace
 	Personal *Personal
 }
 
@@ -56,6 +64,15 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 
+	// namespaces the dispatcher is restricted to, all of them if nil
+	namespaces map[string]struct{}
+
+	// privileged reports whether the dispatcher serves the privileged methods
+	privileged bool
+
+	// rateLimiter limits the requests of the clients, nil if there are no limits
+	rateLimiter *rateLimiter
+
 	params *dispatcherParams
 }
 
@@ -68,12 +85,19 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+
+	rateLimits      []*RateLimit
+	maxResponseSize uint64
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
 	return dp.jsonRPCBatchLengthLimit != 0 && value > dp.jsonRPCBatchLengthLimit
 }
 
+func (dp dispatcherParams) isExceedingResponseSize(size int) bool {
+	return dp.maxResponseSize != 0 && uint64(size) > dp.maxResponseSize
+}
+
 func newDispatcher(
 	logger hclog.Logger,
 	store JSONRPCStore,
@@ -85,6 +109,10 @@ func newDispatcher(
 		params: params,
 	}
 
+	if len(params.rateLimits) > 0 {
+		d.rateLimiter = newRateLimiter(params.rateLimits)
+	}
+
 	if store != nil {
 		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit)
 		go d.filterManager.Run()
@@ -119,7 +147,11 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore, manager accounts.Acco
 	d.endpoints.Bridge = &Bridge{
 		store,
 	}
+	d.endpoints.PolyBFT = &PolyBFT{
+		store,
+	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
+	d.endpoints.Trace = NewTrace(store, d.params.blockRangeLimit, d.params.concurrentRequestsDebug)
 	d.endpoints.Personal = NewPersonal(manager)
 
 	var err error
@@ -144,13 +176,60 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore, manager accounts.Acco
 		return err
 	}
 
+	if err = d.registerService("polybft", d.endpoints.PolyBFT); err != nil {
+		return err
+	}
+
 	if err = d.registerService("personal", d.endpoints.Personal); err != nil {
 		return err
 	}
 
+	if err = d.registerService("trace", d.endpoints.Trace); err != nil {
+		return err
+	}
+
 	return d.registerService("debug", d.endpoints.Debug)
 }
 
+// withNamespaces returns a view of the dispatcher serving only the given namespaces,
+// sharing the endpoints and the filters with it. No namespaces stand for all of them
+func (d *Dispatcher) withNamespaces(namespaces []string) (*Dispatcher, error) {
+	if len(namespaces) == 0 {
+		return d, nil
+	}
+
+	restricted := *d
+	restricted.namespaces = make(map[string]struct{}, len(namespaces))
+
+	for _, namespace := range namespaces {
+		if _, ok := d.serviceMap[namespace]; !ok {
+			return nil, fmt.Errorf("unknown json-rpc namespace %s", namespace)
+		}
+
+		restricted.namespaces[namespace] = struct{}{}
+	}
+
+	return &restricted, nil
+}
+
+// withPrivileged returns a view of the dispatcher serving the privileged methods as well
+func (d *Dispatcher) withPrivileged() *Dispatcher {
+	privileged := *d
+	privileged.privileged = true
+
+	return &privileged
+}
+
+func (d *Dispatcher) isNamespaceEnabled(namespace string) bool {
+	if d.namespaces == nil {
+		return true
+	}
+
+	_, ok := d.namespaces[namespace]
+
+	return ok
+}
+
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
 	callName := strings.SplitN(req.Method, "_", 2)
 	if len(callName) != 2 {
@@ -159,6 +238,14 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 
 	serviceName, funcName := callName[0], callName[1]
 
+	if !d.isNamespaceEnabled(serviceName) {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
+	if _, ok := privilegedMethods[req.Method]; ok && !d.privileged {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
 	service, ok := d.serviceMap[serviceName]
 	if !ok {
 		return nil, nil, NewMethodNotFoundError(req.Method)
@@ -259,7 +346,9 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+// HandleWs handles the request of the websocket (or IPC) connection of the client.
+// The requests of an unknown (empty) client are not rate limited
+func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn, client string) ([]byte, error) {
 	const (
 		openSquareBracket  byte = '['
 		closeSquareBracket byte = ']'
@@ -288,10 +377,20 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 			).Bytes()
 		}
 
-		responses := make([][]byte, len(batchReq))
+		var (
+			responses = make([][]byte, len(batchReq))
+			size      = 0
+		)
 
 		for i, req := range batchReq {
-			responses[i], err = d.handleSingleWs(req, conn).Bytes()
+			// the responses exceeding the size limit in total are replaced by errors
+			resp := d.handleSingleWs(req, conn, client, size)
+
+			if success, ok := resp.(*SuccessResponse); ok {
+				size += len(success.Result)
+			}
+
+			responses[i], err = resp.Bytes()
 			if err != nil {
 				return nil, err
 			}
@@ -313,15 +412,26 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
-	return d.handleSingleWs(req, conn).Bytes()
+	return d.handleSingleWs(req, conn, client, 0).Bytes()
 }
 
-func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
+// handleSingleWs handles a single request of the websocket client,
+// given the size of the responses already sent in the same batch
+func (d *Dispatcher) handleSingleWs(req Request, conn wsConn, client string, size int) Response {
 	id, err := formatID(req.ID)
 	if err != nil {
 		return NewRPCResponse(nil, "2.0", nil, err)
 	}
 
+	if err := d.checkRateLimit(client, req.Method); err != nil {
+		return NewRPCResponse(id, "2.0", nil, err)
+	}
+
+	// the subscriptions belong to the eth namespace
+	if (req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe") && !d.isNamespaceEnabled("eth") {
+		return NewRPCResponse(id, "2.0", nil, NewMethodNotFoundError(req.Method))
+	}
+
 	var response []byte
 
 	switch req.Method {
@@ -340,13 +450,15 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 		}
 	default:
 		// its a normal query that we handle with the dispatcher
-		response, err = d.handleReq(req)
+		response, err = d.handleReqWithinSize(req, size)
 	}
 
 	return NewRPCResponse(id, "2.0", response, err)
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+// Handle handles the HTTP request of the client.
+// The requests of an unknown (empty) client are not rate limited
+func (d *Dispatcher) Handle(reqBody []byte, client string) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -362,6 +474,10 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
+		if err := d.checkRateLimit(client, req.Method); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
 		resp, err := d.handleReq(req)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
@@ -388,10 +504,20 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 		).Bytes()
 	}
 
-	responses := make([]Response, 0)
+	var (
+		responses = make([]Response, 0)
+		size      = 0
+	)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		if err := d.checkRateLimit(client, req.Method); err != nil {
+			responses = append(responses, NewRPCResponse(req.ID, "2.0", nil, err))
+
+			continue
+		}
+
+		// the responses exceeding the size limit in total are replaced by errors
+		var response, err = d.handleReqWithinSize(req, size)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
 			responses = append(responses, errorResponse)
@@ -399,6 +525,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			continue
 		}
 
+		size += len(response)
+
 		resp := NewRPCResponse(req.ID, "2.0", response, nil)
 		responses = append(responses, resp)
 	}
@@ -412,6 +540,12 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 }
 
 func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+	return d.handleReqWithinSize(req, 0)
+}
+
+// handleReqWithinSize handles the request, failing if its response along with the responses
+// of the given size, already sent in the same batch, exceeds the size limit
+func (d *Dispatcher) handleReqWithinSize(req Request, size int) ([]byte, Error) {
 	d.logger.Trace("request", "method", req.Method, "id", req.ID)
 
 	service, fd, ferr := d.getFnHandler(req)
@@ -438,7 +572,6 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 
 	var (
 		data []byte
-		err  error
 		ok   bool
 	)
 
@@ -460,19 +593,94 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 			}
 		}
 
+		// the pruned history is reported with its own code, so that the clients can tell it from a missing entry
+		var prunedErr *historyPrunedError
+		if errors.As(err, &prunedErr) {
+			return data, prunedErr
+		}
+
 		return data, NewInvalidRequestError(err.Error())
 	}
 
 	if res := output[0].Interface(); res != nil {
-		data, err = fastJSONIt.Marshal(res)
+		return d.marshalResult(req.Method, res, size)
+	}
+
+	return data, nil
+}
+
+// marshalResult encodes the result of the request, failing as soon as the response along with the responses
+// of the given size exceeds the size limit. The elements of the list results (e.g. the logs) are encoded
+// one by one, so that a too large list is never encoded as a whole
+func (d *Dispatcher) marshalResult(method string, res interface{}, size int) ([]byte, Error) {
+	val := reflect.ValueOf(res)
+
+	isList := (val.Kind() == reflect.Slice && !val.IsNil() || val.Kind() == reflect.Array) &&
+		val.Type().Elem().Kind() != reflect.Uint8 // the bytes are encoded as a single string
+
+	if d.params.maxResponseSize == 0 || !isList {
+		data, err := fastJSONIt.Marshal(res)
 		if err != nil {
-			d.logInternalError(req.Method, err)
+			d.logInternalError(method, err)
 
 			return nil, NewInternalError("Internal error")
 		}
+
+		if d.params.isExceedingResponseSize(size + len(data)) {
+			return nil, d.responseTooLarge()
+		}
+
+		return data, nil
 	}
 
-	return data, nil
+	stream := fastJSONIt.BorrowStream(nil)
+	defer fastJSONIt.ReturnStream(stream)
+
+	stream.WriteArrayStart()
+
+	for i := 0; i < val.Len(); i++ {
+		if i > 0 {
+			stream.WriteMore()
+		}
+
+		stream.WriteVal(val.Index(i).Interface())
+
+		if stream.Error != nil {
+			d.logInternalError(method, stream.Error)
+
+			return nil, NewInternalError("Internal error")
+		}
+
+		if d.params.isExceedingResponseSize(size + stream.Buffered()) {
+			return nil, d.responseTooLarge()
+		}
+	}
+
+	stream.WriteArrayEnd()
+
+	if d.params.isExceedingResponseSize(size + stream.Buffered()) {
+		return nil, d.responseTooLarge()
+	}
+
+	// the buffer of the stream is reused
+	return append([]byte(nil), stream.Buffer()...), nil
+}
+
+// checkRateLimit returns an error if the client has exceeded the rate limit of the method
+func (d *Dispatcher) checkRateLimit(client string, method string) Error {
+	if d.rateLimiter == nil || client == "" || d.rateLimiter.allow(client, method, time.Now()) {
+		return nil
+	}
+
+	metrics.IncrCounter([]string{jsonRPCMetric, "rate_limited"}, 1)
+
+	return NewLimitExceededError(fmt.Sprintf("rate limit exceeded for %s", method))
+}
+
+func (d *Dispatcher) responseTooLarge() Error {
+	metrics.IncrCounter([]string{jsonRPCMetric, "response_too_large"}, 1)
+
+	return NewLimitExceededError(fmt.Sprintf("response size exceeds the limit of %d bytes", d.params.maxResponseSize))
 }
 
 func (d *Dispatcher) logInternalError(method string, err error) {
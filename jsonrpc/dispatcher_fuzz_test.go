@@ -152,9 +152,9 @@ func FuzzDispatcherBatchRequest(f *testing.F) {
 
 		body := fmt.Sprintf(`[{"id":1,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params": %s}]`, params)
 
-		_, err := dispatcher.HandleWs([]byte(body), mock)
+		_, err := dispatcher.HandleWs([]byte(body), mock, "")
 		assert.NoError(t, err)
-		_, err = dispatcher.Handle([]byte(body))
+		_, err = dispatcher.Handle([]byte(body), "")
 		assert.NoError(t, err)
 	})
 }
@@ -192,7 +192,7 @@ func FuzzDispatcherWebsocketConnectionUnsubscribe(f *testing.F) {
 	}
 
 	f.Fuzz(func(t *testing.T, request string) {
-		_, err := dispatcher.HandleWs([]byte(request), mockConn)
+		_, err := dispatcher.HandleWs([]byte(request), mockConn, "")
 		assert.NoError(t, err)
 	})
 }
@@ -251,6 +251,6 @@ func FuzzDispatcherWebSocketConnectionRequestFormats(f *testing.F) {
 	}
 
 	f.Fuzz(func(t *testing.T, request string) {
-		_, _ = dispatcher.HandleWs([]byte(request), mockConnection)
+		_, _ = dispatcher.HandleWs([]byte(request), mockConnection, "")
 	})
 }
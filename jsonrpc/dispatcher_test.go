@@ -80,7 +80,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		_, err := dispatcher.HandleWs(req, mockConnection)
+		_, err := dispatcher.HandleWs(req, mockConnection, "")
 		require.NoError(t, err)
 
 		store.emitEvent(&mockEvent{
@@ -109,7 +109,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newPendingTransactions"]
 	}`)
-		_, err := dispatcher.HandleWs(req, mockConnection)
+		_, err := dispatcher.HandleWs(req, mockConnection, "")
 		require.NoError(t, err)
 
 		store.emitTxPoolEvent(proto.EventType_ADDED, "evt1")
@@ -184,7 +184,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(c.msg, mockConnection, "")
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -314,6 +314,49 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcher_WithNamespaces(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	_, err := dispatcher.withNamespaces([]string{"eth", "unknown"})
+	require.ErrorContains(t, err, "unknown json-rpc namespace unknown")
+
+	public, err := dispatcher.withNamespaces([]string{"eth", "net", "web3"})
+	require.NoError(t, err)
+
+	_, _, rpcErr := public.getFnHandler(Request{Method: "web3_clientVersion"})
+	require.Nil(t, rpcErr)
+
+	_, _, rpcErr = public.getFnHandler(Request{Method: "txpool_content"})
+	require.Equal(t, NewMethodNotFoundError("txpool_content"), rpcErr)
+
+	// the origin dispatcher keeps serving all the namespaces
+	_, _, rpcErr = dispatcher.getFnHandler(Request{Method: "txpool_content"})
+	require.Nil(t, rpcErr)
+
+	admin, err := dispatcher.withNamespaces([]string{"txpool"})
+	require.NoError(t, err)
+
+	resp, err := admin.HandleWs([]byte(`{"id":1,"method":"eth_subscribe","params":["newHeads"]}`), &mockWsConn{}, "")
+	require.NoError(t, err)
+	require.Contains(t, string(resp), "the method eth_subscribe does not exist/is not available")
+
+	// the privileged methods are served only by the privileged views, whatever their namespaces
+	_, _, rpcErr = dispatcher.getFnHandler(Request{Method: "debug_setHead"})
+	require.Equal(t, NewMethodNotFoundError("debug_setHead"), rpcErr)
+
+	_, _, rpcErr = dispatcher.withPrivileged().getFnHandler(Request{Method: "debug_setHead"})
+	require.Nil(t, rpcErr)
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	t.Parallel()
 
@@ -499,11 +542,11 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
 
-			res, _ := c.dispatcher.HandleWs(c.reqBody, mock)
+			res, _ := c.dispatcher.HandleWs(c.reqBody, mock, "")
 
 			check(c, res)
 
-			res, _ = c.dispatcher.Handle(c.reqBody)
+			res, _ = c.dispatcher.Handle(c.reqBody, "")
 
 			check(c, res)
 		})
@@ -541,19 +584,19 @@ func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
 	}
 
 	// non existing subscription
-	r, err := dispatcher.HandleWs(reqUnsub("\"787832\""), mockConn)
+	r, err := dispatcher.HandleWs(reqUnsub("\"787832\""), mockConn, "")
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 	assert.Equal(t, "false", string(resp.Result))
 
-	r, err = dispatcher.HandleWs([]byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`), mockConn)
+	r, err = dispatcher.HandleWs([]byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`), mockConn, "")
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 
 	// existing subscription
-	r, err = dispatcher.HandleWs(reqUnsub(string(resp.Result)), mockConn)
+	r, err = dispatcher.HandleWs(reqUnsub(string(resp.Result)), mockConn, "")
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
@@ -10,6 +10,9 @@ import (
 
 var (
 	ErrStateNotFound = errors.New("given root and slot not found in storage")
+
+	// ErrHistoryPruned is returned for the blocks and transactions whose history is pruned by the node
+	ErrHistoryPruned = &historyPrunedError{"history pruned"}
 )
 
 type Error interface {
@@ -76,6 +79,31 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
+// historyPrunedError is the error of the requests for the pruned history, with the code used for it by EIP-4444
+type historyPrunedError struct {
+	err string
+}
+
+func (e *historyPrunedError) Error() string {
+	return e.err
+}
+
+func (e *historyPrunedError) ErrorCode() int {
+	return 4444
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -90,6 +118,10 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewLimitExceededError(msg string) *limitExceededError {
+	return &limitExceededError{msg}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
@@ -1,12 +1,14 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 	"strconv"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
@@ -14,6 +16,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -293,6 +296,86 @@ func TestEth_GetTransactionByHash(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Nil(t, res)
 	})
+
+	t.Run("returns history pruned error if transaction is found in a pruned block", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockBlockStore{historyTail: 10}
+		block := newTestBlock(5, hash1)
+		block.Transactions = append(block.Transactions, newTestTransaction(0, addr0))
+		store.add(block)
+
+		eth := newTestEthEndpoint(&prunedBlockStore{store})
+
+		res, err := eth.GetTransactionByHash(block.Transactions[0].Hash())
+
+		assert.ErrorIs(t, err, ErrHistoryPruned)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns nil if transaction is not found after the history is pruned", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestEthEndpoint(&mockBlockStore{historyTail: 10})
+
+		res, err := eth.GetTransactionByHash(types.StringToHash("abcdef"))
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestEth_HistoryPruned(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{historyTail: 10}
+	store.add(newTestBlock(5, hash1), newTestBlock(12, hash2))
+
+	eth := newTestEthEndpoint(&prunedBlockStore{store})
+
+	_, err := eth.GetBlockByNumber(5, true)
+	assert.ErrorIs(t, err, ErrHistoryPruned)
+
+	_, err = eth.GetBlockByHash(hash1, true)
+	assert.ErrorIs(t, err, ErrHistoryPruned)
+
+	_, err = eth.GetBlockReceipts(5)
+	assert.ErrorIs(t, err, ErrHistoryPruned)
+
+	_, err = eth.GetBlockTransactionCountByNumber(5)
+	assert.ErrorIs(t, err, ErrHistoryPruned)
+
+	// the blocks which are not found at all are still reported as such
+	res, err := eth.GetBlockByNumber(7, true)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetBlockByNumber(12, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+// prunedBlockStore returns the blocks below the history tail without the body, as the blockchain does
+type prunedBlockStore struct {
+	*mockBlockStore
+}
+
+func (m *prunedBlockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool) {
+	block, ok := m.mockBlockStore.GetBlockByNumber(num, full)
+	if ok && full && num < m.historyTail {
+		return &types.Block{Header: block.Header}, false
+	}
+
+	return block, ok
+}
+
+func (m *prunedBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	block, ok := m.mockBlockStore.GetBlockByHash(hash, full)
+	if ok && full && block.Number() < m.historyTail {
+		return &types.Block{Header: block.Header}, false
+	}
+
+	return block, ok
 }
 
 func TestEth_GetTransactionReceipt(t *testing.T) {
@@ -571,7 +654,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -595,7 +678,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -621,7 +704,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 		assert.Error(t, err)
 		assert.NotNil(t, res)
 		bres := res.([]byte)
@@ -629,6 +712,70 @@ func TestEth_Call(t *testing.T) {
 	})
 }
 
+func TestEth_Call_BlockOverrides(t *testing.T) {
+	t.Parallel()
+
+	var (
+		caller   = types.StringToAddress("a1")
+		contract = types.StringToAddress("a2")
+		coinbase = types.StringToAddress("a3")
+	)
+
+	// returns the timestamp, the number, the coinbase and the base fee of the block
+	code := []byte{
+		0x42, 0x60, 0x00, 0x52, // mstore(0, timestamp)
+		0x43, 0x60, 0x20, 0x52, // mstore(32, number)
+		0x41, 0x60, 0x40, 0x52, // mstore(64, coinbase)
+		0x48, 0x60, 0x60, 0x52, // mstore(96, basefee)
+		0x60, 0x80, 0x60, 0x00, 0xf3, // return(0, 128)
+	}
+
+	store := newExecutorMockStore(t, map[types.Address]*chain.GenesisAccount{
+		contract: {Code: code},
+	})
+	eth := newTestEthEndpoint(store)
+
+	call := func(overrides *BlockOverrides) []byte {
+		t.Helper()
+
+		res, err := eth.Call(&txnArgs{
+			From:     &caller,
+			To:       &contract,
+			GasPrice: argBytesPtr(big.NewInt(200).Bytes()),
+			Data:     argBytesPtr([]byte{}),
+		}, BlockNumberOrHash{}, nil, overrides)
+		require.NoError(t, err)
+
+		return *res.(*argBytes)
+	}
+
+	expected := func(timestamp, number uint64, coinbase types.Address, baseFee uint64) []byte {
+		return bytes.Join([][]byte{
+			types.BytesToHash(new(big.Int).SetUint64(timestamp).Bytes()).Bytes(),
+			types.BytesToHash(new(big.Int).SetUint64(number).Bytes()).Bytes(),
+			types.BytesToHash(coinbase.Bytes()).Bytes(),
+			types.BytesToHash(new(big.Int).SetUint64(baseFee).Bytes()).Bytes(),
+		}, nil)
+	}
+
+	header := store.Header()
+
+	assert.Equal(t,
+		expected(header.Timestamp, header.Number, types.BytesToAddress(header.Miner), header.BaseFee),
+		call(nil),
+	)
+
+	assert.Equal(t, expected(5000, 50, coinbase, 7), call(&BlockOverrides{
+		Time:          argUintPtr(5000),
+		Number:        argUintPtr(50),
+		FeeRecipient:  &coinbase,
+		BaseFeePerGas: argUintPtr(7),
+	}))
+
+	// the stored header is not modified
+	assert.Equal(t, uint64(1000), store.Header().Timestamp)
+}
+
 func TestEth_CreateAccessList(t *testing.T) {
 	store := newMockBlockStore()
 	hashs := make([]types.Hash, 10)
@@ -718,6 +865,11 @@ type mockBlockStore struct {
 	returnValue     []byte
 	forksInTime     chain.ForksInTime
 	baseFee         uint64
+	historyTail     uint64
+
+	// bloomSections are the sections of the bloom bits index
+	bloomSections    []*bloombits.Generator
+	bloomSectionSize uint64
 
 	maxPriorityFeePerGasFn func() (*big.Int, error)
 }
@@ -845,6 +997,10 @@ func (m *mockBlockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 	return receipts, nil
 }
 
+func (m *mockBlockStore) HistoryTail() uint64 {
+	return m.historyTail
+}
+
 func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Number() == blockNumber {
@@ -920,6 +1076,14 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) BloomStatus() (uint64, uint64) {
+	return m.bloomSectionSize, uint64(len(m.bloomSections))
+}
+
+func (m *mockBlockStore) GetBloomBits(bit uint, section uint64) ([]byte, error) {
+	return m.bloomSections[section].Bitset(bit)
+}
+
 func (m *mockBlockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -42,6 +42,7 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*state.AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -66,6 +67,9 @@ type ethBlockchainStore interface {
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
+	// HistoryTail returns the number of the first block whose history is not pruned
+	HistoryTail() uint64
+
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
@@ -92,6 +96,7 @@ type ethStore interface {
 	ethStateStore
 	ethBlockchainStore
 	ethFilter
+	ethSimulateStore
 	gasprice.GasStore
 }
 
@@ -141,7 +146,7 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
-		return nil, nil
+		return nil, e.historyPrunedError(block)
 	}
 
 	if err := e.filterExtra(block); err != nil {
@@ -155,7 +160,7 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error) {
 	block, ok := e.store.GetBlockByHash(hash, true)
 	if !ok {
-		return nil, nil
+		return nil, e.historyPrunedError(block)
 	}
 
 	if err := e.filterExtra(block); err != nil {
@@ -165,6 +170,16 @@ func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error)
 	return toBlock(block, fullTx), nil
 }
 
+// historyPrunedError returns ErrHistoryPruned if the block is found without the body,
+// because its history is pruned, or nil if the block is not found at all
+func (e *Eth) historyPrunedError(block *types.Block) error {
+	if block != nil && block.Header != nil && block.Number() < e.store.HistoryTail() {
+		return ErrHistoryPruned
+	}
+
+	return nil
+}
+
 // GetHeaderByNumber returns the requested canonical block header.
 func (e *Eth) GetHeaderByNumber(number BlockNumber) (interface{}, error) {
 	num, err := GetNumericBlockNumber(number, e.store)
@@ -269,7 +284,7 @@ func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interfac
 func (e *Eth) GetBlockTransactionCountByHash(blockHash types.Hash) (interface{}, error) {
 	block, ok := e.store.GetBlockByHash(blockHash, true)
 	if !ok {
-		return nil, nil
+		return nil, e.historyPrunedError(block)
 	}
 
 	return *common.EncodeUint64(uint64(len(block.Transactions))), nil
@@ -284,7 +299,7 @@ func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{},
 	block, ok := e.store.GetBlockByNumber(num, true)
 
 	if !ok {
-		return nil, nil
+		return nil, e.historyPrunedError(block)
 	}
 
 	return *common.EncodeUint64(uint64(len(block.Transactions))), nil
@@ -299,7 +314,7 @@ func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argU
 
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
-		return nil, nil
+		return nil, e.historyPrunedError(block)
 	}
 
 	return GetTransactionByBlockAndIndex(block, index)
@@ -309,7 +324,7 @@ func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argU
 func (e *Eth) GetTransactionByBlockHashAndIndex(blockHash types.Hash, index argUint64) (interface{}, error) {
 	block, ok := e.store.GetBlockByHash(blockHash, true)
 	if !ok {
-		return nil, nil
+		return nil, e.historyPrunedError(block)
 	}
 
 	return GetTransactionByBlockAndIndex(block, index)
@@ -396,18 +411,23 @@ func (e *Eth) Sign(from types.Address, buf argBytes) (interface{}, error) {
 func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	// findSealedTx is a helper method for checking the world state
 	// for the transaction with the provided hash
-	findSealedTx := func() *transaction {
+	findSealedTx := func() (*transaction, error) {
 		// Check the chain state for the transaction
 		blockNum, ok := e.store.ReadTxLookup(hash)
 		if !ok {
 			// Block not found in storage
-			return nil
+			return nil, nil
 		}
 
 		block, ok := e.store.GetBlockByNumber(blockNum, true)
 		if !ok {
+			// the block body might have been pruned in the meantime
+			if blockNum < e.store.HistoryTail() {
+				return nil, ErrHistoryPruned
+			}
+
 			// Block receipts not found in storage
-			return nil
+			return nil, nil
 		}
 
 		// Find the transaction within the block
@@ -416,10 +436,10 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 				txn,
 				block.Header,
 				&idx,
-			)
+			), nil
 		}
 
-		return nil
+		return nil, nil
 	}
 
 	// findPendingTx is a helper method for checking the TxPool
@@ -435,8 +455,8 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	}
 
 	// 1. Check the chain state for the txn
-	if resultTxn := findSealedTx(); resultTxn != nil {
-		return resultTxn, nil
+	if resultTxn, err := findSealedTx(); err != nil || resultTxn != nil {
+		return resultTxn, err
 	}
 
 	// 2. Check the TxPool for the txn
@@ -518,6 +538,10 @@ func (e *Eth) GetBlockReceipts(number BlockNumber) (interface{}, error) {
 
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
+		if err := e.historyPrunedError(block); err != nil {
+			return nil, err
+		}
+
 		return nil, ErrBlockNotFound
 	}
 
@@ -574,6 +598,25 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(result), nil
 }
 
+// GetProof returns the merkle proof of the account and its storage slots at the given block (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
 // GasPrice exposes "getGasPrice"'s function logic to public RPC interface
 func (e *Eth) GasPrice() (interface{}, error) {
 	gasPrice, err := e.getGasPrice()
@@ -623,13 +666,21 @@ func (e *Eth) fillTransactionGasPrice(tx *types.Transaction) error {
 	return nil
 }
 
-// Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *StateOverride) (interface{}, error) {
+// Call executes a smart contract call using the transaction object data,
+// optionally on top of the overridden state and block header fields
+func (e *Eth) Call(
+	arg *txnArgs,
+	filter BlockNumberOrHash,
+	apiOverride *StateOverride,
+	blockOverrides *BlockOverrides,
+) (interface{}, error) {
 	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
 	if err != nil {
 		return nil, err
 	}
 
+	header = overrideHeader(header, blockOverrides)
+
 	transaction, err := DecodeTxn(arg, e.store, true)
 	if err != nil {
 		return nil, err
@@ -645,16 +696,8 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *StateOve
 		return nil, err
 	}
 
-	var override types.StateOverride
-	if apiOverride != nil {
-		override = types.StateOverride{}
-		for addr, o := range *apiOverride {
-			override[addr] = o.ToType()
-		}
-	}
-
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction, override, true)
+	result, err := e.store.ApplyTxn(header, transaction, apiOverride.ToType(), true)
 	if err != nil {
 		return nil, err
 	}
@@ -671,8 +714,14 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *StateOve
 	return argBytesPtr(result.ReturnValue), nil
 }
 
-// EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+// EstimateGas estimates the gas needed to execute a transaction,
+// optionally on top of the overridden state and block header fields
+func (e *Eth) EstimateGas(
+	arg *txnArgs,
+	rawNum *BlockNumber,
+	apiOverride *StateOverride,
+	blockOverrides *BlockOverrides,
+) (interface{}, error) {
 	number := LatestBlockNumber
 	if rawNum != nil {
 		number = *rawNum
@@ -684,6 +733,9 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
+	header = overrideHeader(header, blockOverrides)
+	override := apiOverride.ToType()
+
 	// testTransaction should execute tx with nonce always set to the current expected nonce for the account
 	transaction, err := DecodeTxn(arg, e.store, true)
 	if err != nil {
@@ -692,7 +744,8 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 
 	forksInTime := e.store.GetForksInTime(header.Number)
 
-	if transaction.IsValueTransfer() {
+	// a value transfer to an account with the overridden code is a contract call
+	if transaction.IsValueTransfer() && override[*transaction.To()].Code == nil {
 		// if it is a simple value transfer or a contract creation,
 		// we already know what is the transaction gas cost, no need to apply transaction
 		gasCost, err := state.TransactionGasCost(transaction, forksInTime.Homestead, forksInTime.Istanbul)
@@ -757,6 +810,10 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			accountBalance = acc.Balance
 		}
 
+		if o, ok := override[transaction.From()]; ok && o.Balance != nil {
+			accountBalance = o.Balance
+		}
+
 		availableBalance = new(big.Int).Set(accountBalance)
 
 		if transaction.Value() != nil {
@@ -819,7 +876,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 
 		transaction.SetGas(gas)
 
-		result, applyErr := e.store.ApplyTxn(header, transaction, nil, true)
+		result, applyErr := e.store.ApplyTxn(header, transaction, override, true)
 
 		if result != nil {
 			data = []byte(hex.EncodeToString(result.ReturnValue))
@@ -0,0 +1,338 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of the blocks of a single simulation
+	maxSimulateBlocks = 256
+
+	// simulateBlockTime is the time between the simulated blocks, unless overridden
+	simulateBlockTime = 1
+
+	// error codes of the failed simulated calls
+	simulateRevertedErrorCode = 3
+	simulateVMErrorCode       = -32015
+)
+
+var (
+	ErrTooManySimulatedBlocks = fmt.Errorf("too many blocks to simulate, the maximum is %d", maxSimulateBlocks)
+	ErrSimulateBlockNumber    = errors.New("simulated block numbers must be increasing")
+	ErrSimulateBlockTimestamp = errors.New("simulated block timestamps must be increasing")
+)
+
+// ethSimulateStore provides the transitions the blocks are simulated with
+type ethSimulateStore interface {
+	// GetBlockCreator returns the block creator (the coinbase) of the header
+	GetBlockCreator(header *types.Header) (types.Address, error)
+
+	// BeginTxn begins a transition of the block on top of the given state
+	BeginTxn(parentRoot types.Hash, header *types.Header, coinbase types.Address) (*state.Transition, error)
+
+	// ContinueTxn moves the transition to the next block, keeping its state
+	ContinueTxn(t *state.Transition, header *types.Header, coinbase types.Address) error
+}
+
+// SimulateBlockStateCalls are the calls of a single simulated block,
+// executed on top of the overridden state
+type SimulateBlockStateCalls struct {
+	BlockOverrides *BlockOverrides `json:"blockOverrides"`
+	StateOverrides *StateOverride  `json:"stateOverrides"`
+	Calls          []*txnArgs      `json:"calls"`
+}
+
+// SimulateOpts are the options of the eth_simulateV1 request
+type SimulateOpts struct {
+	BlockStateCalls []*SimulateBlockStateCalls `json:"blockStateCalls"`
+
+	// TraceTransfers adds a log for each ether transfer
+	TraceTransfers bool `json:"traceTransfers"`
+
+	// Validation enables the balance and the fee checks of the calls. Without the validation,
+	// the fees are not charged and the base fee is zero unless overridden
+	Validation bool `json:"validation"`
+
+	ReturnFullTransactions bool `json:"returnFullTransactions"`
+}
+
+// simulatedCallError is the error of a failed simulated call
+type simulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// simulatedCallResult is the outcome of a simulated call
+type simulatedCallResult struct {
+	ReturnData argBytes            `json:"returnData"`
+	Logs       []*Log              `json:"logs"`
+	GasUsed    argUint64           `json:"gasUsed"`
+	Status     argUint64           `json:"status"`
+	Error      *simulatedCallError `json:"error,omitempty"`
+}
+
+// simulatedBlock is a simulated block along with the results of its calls.
+// Its state root is not calculated, as the simulated state is never committed
+type simulatedBlock struct {
+	*block
+	Calls []*simulatedCallResult `json:"calls"`
+}
+
+// SimulateV1 simulates a sequence of blocks of calls on top of the given block.
+// The state carries over between the calls and the blocks of the simulation
+func (e *Eth) SimulateV1(opts *SimulateOpts, filter BlockNumberOrHash) (interface{}, error) {
+	if opts == nil || len(opts.BlockStateCalls) == 0 {
+		return nil, errors.New("no blocks to simulate")
+	}
+
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, ErrTooManySimulatedBlocks
+	}
+
+	parent, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	coinbase, err := e.store.GetBlockCreator(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		transition *state.Transition
+		results    = make([]*simulatedBlock, 0, len(opts.BlockStateCalls))
+	)
+
+	for i, blockCalls := range opts.BlockStateCalls {
+		header, err := newSimulatedHeader(parent, coinbase, blockCalls.BlockOverrides, opts.Validation)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		blockCoinbase := types.BytesToAddress(header.Miner)
+
+		// without the validation, the gas is not bought, so the calls are executed with a zero base fee
+		// in order not to burn any fee. The overridden base fee is reported in the block only
+		txHeader := header
+		if !opts.Validation && header.BaseFee != 0 {
+			txHeader = header.Copy()
+			txHeader.BaseFee = 0
+		}
+
+		if transition == nil {
+			if transition, err = e.store.BeginTxn(parent.StateRoot, txHeader, blockCoinbase); err != nil {
+				return nil, err
+			}
+
+			transition.SetNonPayable(!opts.Validation)
+			transition.SetTransferLogs(opts.TraceTransfers)
+		} else if err := e.store.ContinueTxn(transition, txHeader, blockCoinbase); err != nil {
+			return nil, err
+		}
+
+		if override := blockCalls.StateOverrides.ToType(); override != nil {
+			if err := transition.WithStateOverride(override); err != nil {
+				return nil, fmt.Errorf("block %d: %w", i, err)
+			}
+		}
+
+		result, err := e.simulateBlock(transition, header, blockCalls.Calls, opts)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		results = append(results, result)
+		parent = header
+	}
+
+	return results, nil
+}
+
+// newSimulatedHeader returns the header of the block following the parent, with the overridden fields
+func newSimulatedHeader(
+	parent *types.Header,
+	coinbase types.Address,
+	overrides *BlockOverrides,
+	validation bool,
+) (*types.Header, error) {
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Sha3Uncles: types.EmptyUncleHash,
+		Miner:      coinbase.Bytes(),
+		Difficulty: parent.Difficulty,
+		Number:     parent.Number + 1,
+		GasLimit:   parent.GasLimit,
+		Timestamp:  parent.Timestamp + simulateBlockTime,
+		MixHash:    parent.MixHash,
+	}
+
+	// the fees are not charged without the validation, so the base fee is zero unless overridden
+	if validation {
+		header.BaseFee = parent.BaseFee
+	}
+
+	overrides.apply(header)
+
+	if header.Number <= parent.Number {
+		return nil, ErrSimulateBlockNumber
+	}
+
+	if header.Timestamp <= parent.Timestamp {
+		return nil, ErrSimulateBlockTimestamp
+	}
+
+	return header, nil
+}
+
+// simulateBlock applies the calls to the transition, sealing the block of them
+func (e *Eth) simulateBlock(
+	transition *state.Transition,
+	header *types.Header,
+	calls []*txnArgs,
+	opts *SimulateOpts,
+) (*simulatedBlock, error) {
+	var (
+		txs      = make([]*types.Transaction, 0, len(calls))
+		receipts = make([]*types.Receipt, 0, len(calls))
+		results  = make([]*simulatedCallResult, 0, len(calls))
+		logs     = make([][]*types.Log, 0, len(calls))
+	)
+
+	for i, call := range calls {
+		tx, err := e.decodeSimulatedCall(transition, header, call, opts.Validation)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+
+		result, err := transition.Apply(tx)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+
+		header.GasUsed += result.GasUsed
+
+		receipt := &types.Receipt{
+			CumulativeGasUsed: header.GasUsed,
+			TransactionType:   tx.Type(),
+			TxHash:            tx.Hash(),
+			GasUsed:           result.GasUsed,
+			Logs:              transition.Txn().Logs(),
+		}
+
+		// the self destructed accounts are deleted for the next calls
+		if err := transition.Txn().CleanDeleteObjects(true); err != nil {
+			return nil, err
+		}
+
+		callResult := &simulatedCallResult{
+			ReturnData: argBytes(result.ReturnValue),
+			GasUsed:    argUint64(result.GasUsed),
+		}
+
+		switch {
+		case result.Reverted():
+			receipt.SetStatus(types.ReceiptFailed)
+
+			callResult.Error = &simulatedCallError{
+				Code:    simulateRevertedErrorCode,
+				Message: constructErrorFromRevert(result).Error(),
+				Data:    hex.EncodeToHex(result.ReturnValue),
+			}
+		case result.Failed():
+			receipt.SetStatus(types.ReceiptFailed)
+
+			callResult.Error = &simulatedCallError{
+				Code:    simulateVMErrorCode,
+				Message: result.Err.Error(),
+			}
+		default:
+			receipt.SetStatus(types.ReceiptSuccess)
+		}
+
+		callResult.Status = argUint64(*receipt.Status)
+		receipt.LogsBloom = types.CreateBloom([]*types.Receipt{receipt})
+
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+		results = append(results, callResult)
+		logs = append(logs, receipt.Logs)
+	}
+
+	header.TxRoot = buildroot.CalculateTransactionsRoot(txs, header.Number)
+	header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(receipts)
+	header.LogsBloom = types.CreateBloom(receipts)
+	header.ComputeHash()
+
+	logIndex := uint64(0)
+
+	for i, callResult := range results {
+		callResult.Logs = toLogs(logs[i], logIndex, uint64(i), header, txs[i].Hash())
+		logIndex += uint64(len(logs[i]))
+	}
+
+	return &simulatedBlock{
+		block: toBlock(&types.Block{Header: header, Transactions: txs}, opts.ReturnFullTransactions),
+		Calls: results,
+	}, nil
+}
+
+// decodeSimulatedCall returns the transaction of the call. Unless set, the nonce is taken from the
+// simulated state and the gas defaults to the gas left in the block
+func (e *Eth) decodeSimulatedCall(
+	transition *state.Transition,
+	header *types.Header,
+	call *txnArgs,
+	validation bool,
+) (*types.Transaction, error) {
+	if call == nil {
+		return nil, errors.New("missing call")
+	}
+
+	if call.From == nil {
+		call.From = &types.ZeroAddress
+	}
+
+	if call.Nonce == nil {
+		call.Nonce = argUintPtr(transition.Txn().GetNonce(*call.From))
+	}
+
+	tx, err := DecodeTxn(call, e.store, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Gas() == 0 {
+		tx.SetGas(header.GasLimit - header.GasUsed)
+	}
+
+	// the fees are charged only with the validation. Without it, the gas is not bought,
+	// so the price is zero in order not to refund the sender nor to pay the coinbase for it
+	switch {
+	case !validation:
+		if tx.Type() == types.DynamicFeeTxType {
+			tx.SetGasFeeCap(new(big.Int))
+			tx.SetGasTipCap(new(big.Int))
+		} else {
+			tx.SetGasPrice(new(big.Int))
+		}
+	case tx.GetGasPrice(header.BaseFee).BitLen() == 0:
+		if tx.Type() == types.DynamicFeeTxType {
+			tx.SetGasFeeCap(new(big.Int).SetUint64(header.BaseFee))
+		} else {
+			tx.SetGasPrice(new(big.Int).SetUint64(header.BaseFee))
+		}
+	}
+
+	tx.ComputeHash()
+
+	return tx, nil
+}
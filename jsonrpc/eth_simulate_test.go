@@ -0,0 +1,232 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// simulateCounterCode increments the counter in the slot 0, logs and returns its new value
+	simulateCounterCode = []byte{
+		0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x80, 0x60, 0x00, 0x55, // sstore(0, sload(0) + 1)
+		0x60, 0x00, 0x52, // mstore(0, counter)
+		0x60, 0x20, 0x60, 0x00, 0xa0, // log0(0, 32)
+		0x60, 0x20, 0x60, 0x00, 0xf3, // return(0, 32)
+	}
+
+	// simulateRevertCode reverts without data
+	simulateRevertCode = []byte{0x60, 0x00, 0x60, 0x00, 0xfd}
+
+	// simulateBalancesCode returns the balances of the caller and of the coinbase
+	simulateBalancesCode = []byte{
+		0x33, 0x31, 0x60, 0x00, 0x52, // mstore(0, balance(caller))
+		0x41, 0x31, 0x60, 0x20, 0x52, // mstore(32, balance(coinbase))
+		0x60, 0x40, 0x60, 0x00, 0xf3, // return(0, 64)
+	}
+)
+
+func TestEth_SimulateV1(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("a1")
+		receiver = types.StringToAddress("a2")
+		counter  = types.StringToAddress("a3")
+		reverter = types.StringToAddress("a4")
+	)
+
+	store := newExecutorMockStore(t, map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000)},
+	})
+
+	eth := newTestEthEndpoint(store)
+
+	callCounter := func() *txnArgs {
+		return &txnArgs{From: &sender, To: &counter, Data: argBytesPtr([]byte{})}
+	}
+
+	res, err := eth.SimulateV1(&SimulateOpts{
+		BlockStateCalls: []*SimulateBlockStateCalls{
+			{
+				StateOverrides: &StateOverride{
+					counter:  {Code: argBytesPtr(simulateCounterCode)},
+					reverter: {Code: argBytesPtr(simulateRevertCode)},
+				},
+				Calls: []*txnArgs{
+					callCounter(),
+					callCounter(),
+					{From: &sender, To: &receiver, Value: argBytesPtr(big.NewInt(300).Bytes())},
+				},
+			},
+			{
+				BlockOverrides: &BlockOverrides{Number: argUintPtr(20), FeeRecipient: &receiver},
+				Calls: []*txnArgs{
+					callCounter(),
+					{From: &sender, To: &reverter, Data: argBytesPtr([]byte{})},
+				},
+			},
+		},
+		TraceTransfers: true,
+	}, BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	blocks, ok := res.([]*simulatedBlock)
+	require.True(t, ok)
+	require.Len(t, blocks, 2)
+
+	first, second := blocks[0], blocks[1]
+
+	assert.Equal(t, argUint64(11), first.Number)
+	assert.Equal(t, argUint64(1001), first.Timestamp)
+	assert.Equal(t, store.header.Hash, first.ParentHash)
+	assert.Equal(t, argUint64(0), first.BaseFee)
+	assert.Equal(t, argBytes(store.header.Miner), first.Miner)
+
+	assert.Equal(t, argUint64(20), second.Number)
+	assert.Equal(t, first.Hash, second.ParentHash)
+	assert.Equal(t, argBytes(receiver.Bytes()), second.Miner)
+
+	// the state carries over between the calls and the blocks
+	require.Len(t, first.Calls, 3)
+	require.Len(t, second.Calls, 2)
+
+	assert.Equal(t, types.BytesToHash([]byte{1}).Bytes(), []byte(first.Calls[0].ReturnData))
+	assert.Equal(t, types.BytesToHash([]byte{2}).Bytes(), []byte(first.Calls[1].ReturnData))
+	assert.Equal(t, types.BytesToHash([]byte{3}).Bytes(), []byte(second.Calls[0].ReturnData))
+
+	// the logs are indexed within the block
+	require.Len(t, first.Calls[1].Logs, 1)
+	assert.Equal(t, counter, first.Calls[1].Logs[0].Address)
+	assert.Equal(t, argUint64(1), first.Calls[1].Logs[0].LogIndex)
+	assert.Equal(t, argUint64(1), first.Calls[1].Logs[0].TxIndex)
+	assert.Equal(t, first.Hash, first.Calls[1].Logs[0].BlockHash)
+
+	// the ether transfers are logged
+	require.Len(t, first.Calls[2].Logs, 1)
+	transfer := first.Calls[2].Logs[0]
+	assert.Equal(t, state.TransferLogAddress, transfer.Address)
+	assert.Equal(t, []types.Hash{
+		state.TransferEventTopic,
+		types.BytesToHash(sender.Bytes()),
+		types.BytesToHash(receiver.Bytes()),
+	}, transfer.Topics)
+	assert.Equal(t, types.BytesToHash(big.NewInt(300).Bytes()).Bytes(), []byte(transfer.Data))
+
+	// the failed calls are reported without failing the simulation
+	assert.Equal(t, argUint64(types.ReceiptSuccess), second.Calls[0].Status)
+	assert.Equal(t, argUint64(types.ReceiptFailed), second.Calls[1].Status)
+	require.NotNil(t, second.Calls[1].Error)
+	assert.Equal(t, simulateRevertedErrorCode, second.Calls[1].Error.Code)
+
+	assert.Equal(t, second.GasUsed, second.Calls[0].GasUsed+second.Calls[1].GasUsed)
+	assert.Len(t, second.Transactions, 2)
+}
+
+func TestEth_SimulateV1_NoValidationFees(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("a1")
+		receiver = types.StringToAddress("a2")
+		balances = types.StringToAddress("a3")
+		coinbase = types.StringToAddress("a4")
+	)
+
+	store := newExecutorMockStore(t, map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000)},
+	})
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.SimulateV1(&SimulateOpts{
+		BlockStateCalls: []*SimulateBlockStateCalls{
+			{
+				BlockOverrides: &BlockOverrides{FeeRecipient: &coinbase, BaseFeePerGas: argUintPtr(5)},
+				StateOverrides: &StateOverride{
+					balances: {Code: argBytesPtr(simulateBalancesCode)},
+				},
+				Calls: []*txnArgs{
+					{From: &sender, To: &receiver, GasPrice: argBytesPtr(big.NewInt(7).Bytes())},
+					{
+						From:      &sender,
+						To:        &receiver,
+						Type:      argUintPtr(uint64(types.DynamicFeeTxType)),
+						GasFeeCap: argBytesPtr(big.NewInt(10).Bytes()),
+						GasTipCap: argBytesPtr(big.NewInt(3).Bytes()),
+					},
+					{From: &sender, To: &balances, Data: argBytesPtr([]byte{})},
+				},
+			},
+		},
+	}, BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	blocks, ok := res.([]*simulatedBlock)
+	require.True(t, ok)
+	require.Len(t, blocks, 1)
+
+	// the overridden base fee is reported, while the gas is neither refunded nor paid to the coinbase
+	assert.Equal(t, argUint64(5), blocks[0].BaseFee)
+
+	require.Len(t, blocks[0].Calls, 3)
+
+	for _, call := range blocks[0].Calls {
+		require.Nil(t, call.Error)
+	}
+
+	expected := append(
+		types.BytesToHash(big.NewInt(1000).Bytes()).Bytes(),
+		types.ZeroHash.Bytes()...,
+	)
+	assert.Equal(t, expected, []byte(blocks[0].Calls[2].ReturnData))
+}
+
+func TestEth_SimulateV1_Errors(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("a1")
+	store := newExecutorMockStore(t, map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000)},
+	})
+
+	eth := newTestEthEndpoint(store)
+
+	t.Run("decreasing block number", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*SimulateBlockStateCalls{
+				{BlockOverrides: &BlockOverrides{Number: argUintPtr(5)}},
+			},
+		}, BlockNumberOrHash{})
+		require.ErrorIs(t, err, ErrSimulateBlockNumber)
+	})
+
+	t.Run("too many blocks", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: make([]*SimulateBlockStateCalls, maxSimulateBlocks+1),
+		}, BlockNumberOrHash{})
+		require.ErrorIs(t, err, ErrTooManySimulatedBlocks)
+	})
+
+	t.Run("insufficient funds with validation", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.SimulateV1(&SimulateOpts{
+			BlockStateCalls: []*SimulateBlockStateCalls{
+				{Calls: []*txnArgs{{From: &sender, To: &sender, Gas: argUintPtr(21000)}}},
+			},
+			Validation: true,
+		}, BlockNumberOrHash{})
+		require.ErrorContains(t, err, state.ErrInsufficientFunds.Error())
+	})
+}
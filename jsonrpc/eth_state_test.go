@@ -684,7 +684,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -760,6 +760,8 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 		estimate, estimateErr := ethEndpoint.EstimateGas(
 			constructMockTx(nil, nil),
 			nil,
+			nil,
+			nil,
 		)
 
 		responseData, ok := estimate.([]byte)
@@ -794,6 +796,8 @@ func TestEth_EstimateGas_ValueTransfer(t *testing.T) {
 	estimate, err := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.NotNil(t, estimate)
@@ -821,6 +825,8 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	estimate, err := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.NotNil(t, estimate)
@@ -830,6 +836,42 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	assert.Equal(t, state.TxGasContractCreation, uint64(estimateUint64))
 }
 
+func TestEth_EstimateGas_StateOverride(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from     = types.StringToAddress("a1")
+		to       = types.StringToAddress("a2")
+		balance  = argUint64(1_000_000_000)
+		storeOne = []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00} // sstore(0, 1)
+	)
+
+	ethEndpoint := newTestEthEndpoint(newExecutorMockStore(t, nil))
+
+	tx := func() *txnArgs {
+		return &txnArgs{
+			From:     &from,
+			To:       &to,
+			GasPrice: argBytesPtr(big.NewInt(200).Bytes()),
+			Value:    argBytesPtr(big.NewInt(500).Bytes()),
+		}
+	}
+
+	// the value transfer executes the overridden code of the recipient,
+	// the sender doesn't have any balance to pay for it though
+	code := &StateOverride{to: {Code: argBytesPtr(storeOne)}}
+
+	_, err := ethEndpoint.EstimateGas(tx(), nil, code, nil)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	estimate, err := ethEndpoint.EstimateGas(tx(), nil, &StateOverride{
+		from: {Balance: &balance},
+		to:   {Code: argBytesPtr(storeOne)},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Greater(t, uint64(estimate.(argUint64)), state.TxGas+20_000)
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
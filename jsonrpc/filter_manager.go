@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
@@ -310,6 +312,12 @@ type filterManagerStore interface {
 
 	// TxPoolSubscribe subscribes for tx pool events
 	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
+
+	// BloomStatus returns the section size and the number of indexed sections of the bloom bits index
+	BloomStatus() (uint64, uint64)
+
+	// GetBloomBits returns the bit vector of the given bloom bit for the given indexed section
+	GetBloomBits(bit uint, section uint64) ([]byte, error)
 }
 
 // FilterManager manages all running filters
@@ -534,28 +542,71 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
-		block, ok := f.store.GetBlockByNumber(i, true)
-		if !ok {
-			break
+	// blocks covered by the bloom bits index are filtered by the index,
+	// so only the potentially matching ones are loaded
+	sectionSize, sections := f.store.BloomStatus()
+	if indexed := sectionSize * sections; from < indexed {
+		end := common.Min(to, indexed-1)
+
+		matches, err := bloombits.NewMatcher(sectionSize, query.bloomFilters()).
+			Match(from, end, f.store.GetBloomBits)
+		if err != nil {
+			return nil, err
 		}
 
-		if len(block.Transactions) == 0 {
-			// do not check logs if no txs
-			continue
+		for _, num := range matches {
+			blockLogs, ok, err := f.getLogsFromBlockNumber(query, num)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return logs, nil
+			}
+
+			logs = append(logs, blockLogs...)
 		}
 
-		blockLogs, err := f.getLogsFromBlock(query, block)
+		from = end + 1
+	}
+
+	for i := from; i <= to; i++ {
+		blockLogs, ok, err := f.getLogsFromBlockNumber(query, i)
 		if err != nil {
 			return nil, err
 		}
 
+		if !ok {
+			break
+		}
+
 		logs = append(logs, blockLogs...)
 	}
 
 	return logs, nil
 }
 
+// getLogsFromBlockNumber returns the logs matching the query from the block with the given number.
+// The returned flag is false if the block is not found
+func (f *FilterManager) getLogsFromBlockNumber(query *LogQuery, num uint64) ([]*Log, bool, error) {
+	block, ok := f.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if len(block.Transactions) == 0 {
+		// do not check logs if no txs
+		return nil, true, nil
+	}
+
+	logs, err := f.getLogsFromBlock(query, block)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return logs, true, nil
+}
+
 // GetLogsForQuery return array of logs for given query
 func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	if query.BlockHash != nil {
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
@@ -148,6 +149,82 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_BloomBits(t *testing.T) {
+	t.Parallel()
+
+	const sectionSize = uint64(8)
+
+	addr := types.StringToAddress("0x1234")
+	topic := types.StringToHash("0x5678")
+
+	store := newMockBlockStore()
+	store.bloomSectionSize = sectionSize
+
+	// blocks 1..19, two complete indexed sections and an unindexed tail
+	logBlocks := map[uint64]bool{2: true, 9: true, 17: true}
+
+	var gen *bloombits.Generator
+
+	for i := uint64(0); i < 20; i++ {
+		header := &types.Header{Number: i, Hash: types.StringToHash(strconv.FormatUint(i, 10))}
+
+		var bloom types.Bloom
+
+		if logBlocks[i] {
+			receipt := &types.Receipt{
+				Logs: []*types.Log{{Address: addr, Topics: []types.Hash{topic}}},
+			}
+			store.receipts[header.Hash] = []*types.Receipt{receipt}
+			bloom = types.CreateBloom([]*types.Receipt{receipt})
+		}
+
+		store.add(&types.Block{
+			Header:       header,
+			Transactions: []*types.Transaction{types.NewTx(types.NewLegacyTx())},
+		})
+
+		if i >= 2*sectionSize {
+			continue
+		}
+
+		if i%sectionSize == 0 {
+			var err error
+
+			gen, err = bloombits.NewGenerator(sectionSize)
+			require.NoError(t, err)
+
+			store.bloomSections = append(store.bloomSections, gen)
+		}
+
+		require.NoError(t, gen.AddBloom(i%sectionSize, bloom))
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	t.Cleanup(f.Close)
+
+	logs, err := f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   19,
+		Addresses: []types.Address{addr},
+		Topics:    [][]types.Hash{{topic}},
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, len(logBlocks))
+
+	for _, log := range logs {
+		require.True(t, logBlocks[uint64(log.BlockNumber)])
+	}
+
+	// a filter not matching any bloom is resolved without loading the indexed blocks
+	logs, err = f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   15,
+		Addresses: []types.Address{types.StringToAddress("0xabcd")},
+	})
+	require.NoError(t, err)
+	require.Empty(t, logs)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
@@ -163,6 +163,19 @@ func GetHeaderFromBlockNumberOrHash(bnh BlockNumberOrHash, store blockGetter) (*
 	return block.Header, nil
 }
 
+// overrideHeader returns a copy of the header with the overridden fields,
+// or the header itself if there are no overrides. The state root is kept
+func overrideHeader(header *types.Header, overrides *BlockOverrides) *types.Header {
+	if overrides == nil {
+		return header
+	}
+
+	header = header.Copy()
+	overrides.apply(header)
+
+	return header
+}
+
 type nonceGetter interface {
 	Header() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
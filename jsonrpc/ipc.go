@@ -0,0 +1,136 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
+)
+
+// ipcConn is a wrapper around a single IPC connection.
+// It implements the wsConn interface, so that subscriptions work the same way as over websockets
+type ipcConn struct {
+	sync.Mutex
+
+	conn     net.Conn     // the actual IPC connection
+	logger   hclog.Logger // module logger
+	filterID string       // filter ID
+}
+
+func (c *ipcConn) SetFilterID(filterID string) {
+	c.filterID = filterID
+}
+
+func (c *ipcConn) GetFilterID() string {
+	return c.filterID
+}
+
+// WriteMessage writes out the message to the IPC peer as a single line.
+// The message type is ignored, since the IPC stream only carries JSON text
+func (c *ipcConn) WriteMessage(_ int, data []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	// some messages (e.g. subscription notifications) are indented,
+	// so they need to be compacted in order to keep the stream newline-delimited
+	var msg bytes.Buffer
+	if err := json.Compact(&msg, data); err != nil {
+		msg.Reset()
+		msg.Write(data)
+	}
+
+	msg.WriteByte('\n')
+
+	if _, err := c.conn.Write(msg.Bytes()); err != nil {
+		c.logger.Error(fmt.Sprintf("Unable to write IPC message, %s", err.Error()))
+
+		return err
+	}
+
+	return nil
+}
+
+func (j *JSONRPC) setupIPC() error {
+	j.logger.Info("ipc server starting...", "path", j.config.IPCPath)
+
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return err
+	}
+
+	j.ipcListener = lis
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	return nil
+}
+
+// handleIPC serves the JSON-RPC requests of a single IPC connection.
+// Requests and responses are newline-delimited JSON messages
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	wrapConn := &ipcConn{conn: conn, logger: j.logger}
+
+	defer func() {
+		j.dispatcher.RemoveFilterByWs(wrapConn)
+
+		if err := conn.Close(); err != nil {
+			j.logger.Error(fmt.Sprintf("Unable to gracefully close IPC connection, %s", err.Error()))
+		}
+	}()
+
+	j.logger.Debug("IPC connection established")
+
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var message json.RawMessage
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				j.logger.Debug("Closing IPC connection gracefully")
+
+				return
+			}
+
+			// the stream can not be recovered after a malformed message
+			resp, _ := NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
+			_ = wrapConn.WriteMessage(0, resp)
+
+			j.logger.Error(fmt.Sprintf("Unable to read IPC message, %s", err.Error()))
+
+			return
+		}
+
+		go func() {
+			resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, "")
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle IPC request, %s", handleErr.Error()))
+
+				resp, _ = NewRPCResponse(nil, "2.0", nil, NewInternalError(handleErr.Error())).Bytes()
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !windows
+// +build !windows
+
+package jsonrpc
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestJSONRPC_IPC(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	port, err := common.GetFreePort()
+	require.NoError(t, err)
+
+	config := &Config{
+		Store:   store,
+		Addr:    &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		IPCPath: filepath.Join(t.TempDir(), "ipc", "jsonrpc.ipc"),
+	}
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), config, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+
+	dial := func(t *testing.T) (net.Conn, *bufio.Reader) {
+		t.Helper()
+
+		conn, err := ipc.DialTimeout(config.IPCPath, time.Second)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+		return conn, bufio.NewReader(conn)
+	}
+
+	t.Run("requests are served as newline-delimited messages", func(t *testing.T) {
+		t.Parallel()
+
+		conn, reader := dial(t)
+
+		_, err := conn.Write([]byte(
+			`{"jsonrpc":"2.0","id":1,"method":"web3_clientVersion","params":[]}` + "\n" +
+				`{"jsonrpc":"2.0","id":2,"method":"eth_chainId","params":[]}` + "\n",
+		))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			line, err := reader.ReadBytes('\n')
+			require.NoError(t, err)
+			require.Contains(t, string(line), `"result"`)
+		}
+	})
+
+	t.Run("subscriptions work over ipc", func(t *testing.T) {
+		t.Parallel()
+
+		conn, reader := dial(t)
+
+		_, err := conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}` + "\n"))
+		require.NoError(t, err)
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		require.Contains(t, string(line), `"result"`)
+
+		store.emitEvent(&mockEvent{
+			NewChain: []*mockHeader{
+				{
+					header: &types.Header{
+						Hash: types.StringToHash("1"),
+					},
+				},
+			},
+		})
+
+		line, err = reader.ReadBytes('\n')
+		require.NoError(t, err)
+		require.Contains(t, string(line), `"eth_subscription"`)
+	})
+
+	t.Run("malformed message closes the connection", func(t *testing.T) {
+		t.Parallel()
+
+		conn, reader := dial(t)
+
+		_, err := conn.Write([]byte("{invalid\n"))
+		require.NoError(t, err)
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		require.Contains(t, string(line), "Invalid json request")
+
+		_, err = reader.ReadBytes('\n')
+		require.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -22,12 +23,21 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	// listener is the HTTP listener served by this instance, nil for the IPC only one
+	listener *Listener
+
+	// httpServers are the HTTP servers of all the listeners, along with their network listeners
+	httpServers   []*http.Server
+	httpListeners []net.Listener
+
+	ipcListener net.Listener
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(reqBody []byte, conn wsConn, client string) ([]byte, error)
+	Handle(reqBody []byte, client string) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -38,12 +48,31 @@ type JSONRPCStore interface {
 	txPoolStore
 	filterManagerStore
 	bridgeStore
+	polybftStore
 	debugStore
+	traceStore
+}
+
+// Listener is an HTTP (and websocket) endpoint of the JSON-RPC server
+type Listener struct {
+	Addr *net.TCPAddr
+
+	// Namespaces are the namespaces served on the listener, all of them if empty
+	Namespaces []string
+
+	// JWTSecret is the secret of the HS256 signed bearer tokens required by the listener.
+	// No authentication is required if empty
+	JWTSecret []byte
+
+	// Privileged reports whether the listener serves the methods modifying the chain, e.g. debug_setHead
+	Privileged bool
 }
 
 type Config struct {
 	Store                    JSONRPCStore
 	Addr                     *net.TCPAddr
+	Namespaces               []string
+	JWTSecret                []byte
 	ChainID                  uint64
 	ChainName                string
 	AccessControlAllowOrigin []string
@@ -52,11 +81,22 @@ type Config struct {
 	BlockRangeLimit          uint64
 
 	ConcurrentRequestsDebug uint64
+	RateLimits              []*RateLimit
+	RateLimitKeyHeader      string
+	// RateLimitTrustedProxies are the addresses the rate limit key header is accepted from,
+	// since a client connecting directly could rotate the key to bypass the limits
+	RateLimitTrustedProxies []*net.IPNet
+	MaxResponseSize         uint64
 	WebSocketReadLimit      uint64
+	IPCPath                 string
 	UseTLS                  bool
 	TLSCertFile             string
 	TLSKeyFile              string
 	SecretsManager          secrets.SecretsManager
+
+	// Listeners are the HTTP listeners served in addition to the one on Addr,
+	// e.g. an admin one exposing the debug and the personal namespaces
+	Listeners []*Listener
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -71,6 +111,8 @@ func NewJSONRPC(logger hclog.Logger, config *Config, manager accounts.AccountMan
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			rateLimits:              config.RateLimits,
+			maxResponseSize:         config.MaxResponseSize,
 		},
 		manager,
 	)
@@ -85,20 +127,88 @@ func NewJSONRPC(logger hclog.Logger, config *Config, manager accounts.AccountMan
 		dispatcher: d,
 	}
 
-	// start http server
-	if err := srv.setupHTTP(); err != nil {
-		return nil, err
+	listeners := append([]*Listener{{
+		Addr:       config.Addr,
+		Namespaces: config.Namespaces,
+		JWTSecret:  config.JWTSecret,
+	}}, config.Listeners...)
+
+	// start http servers, each of them serving only its namespaces
+	for _, listener := range listeners {
+		listenerDispatcher, err := d.withNamespaces(listener.Namespaces)
+		if err != nil {
+			return nil, err
+		}
+
+		if listener.Privileged {
+			listenerDispatcher = listenerDispatcher.withPrivileged()
+		}
+
+		httpSrv := &JSONRPC{
+			logger:     srv.logger,
+			config:     config,
+			dispatcher: listenerDispatcher,
+			listener:   listener,
+		}
+
+		httpServer, lis, err := httpSrv.setupHTTP()
+		if err != nil {
+			_ = srv.Close()
+
+			return nil, err
+		}
+
+		srv.httpServers = append(srv.httpServers, httpServer)
+		srv.httpListeners = append(srv.httpListeners, lis)
+	}
+
+	// start ipc server, if enabled. The socket is reachable only locally, so it serves the privileged methods
+	if config.IPCPath != "" {
+		srv.dispatcher = d.withPrivileged()
+
+		if err := srv.setupIPC(); err != nil {
+			_ = srv.Close()
+
+			return nil, err
+		}
 	}
 
 	return srv, nil
 }
 
-func (j *JSONRPC) setupHTTP() error {
-	j.logger.Info("http server starting...", "addr", j.config.Addr.String())
+// Close stops the HTTP servers of all the listeners and the IPC server, if it is running
+func (j *JSONRPC) Close() error {
+	var errs []error
+
+	for i, httpServer := range j.httpServers {
+		if err := httpServer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+
+		// the listener is closed by the server only once served, which may not have happened yet
+		if err := j.httpListeners[i].Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+
+	if j.ipcListener != nil {
+		if err := j.ipcListener.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (j *JSONRPC) setupHTTP() (*http.Server, net.Listener, error) {
+	addr := j.listener.Addr.String()
 
-	lis, err := net.Listen("tcp", j.config.Addr.String())
+	j.logger.Info("http server starting...", "addr", addr, "namespaces", j.listener.Namespaces,
+		"auth", len(j.listener.JWTSecret) > 0)
+
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// NewServeMux must be used, as it disables all debug features.
@@ -108,11 +218,11 @@ func (j *JSONRPC) setupHTTP() error {
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	mux.Handle("/", middlewareFactory(j.config, j.listener.JWTSecret)(jsonRPCHandler))
 
 	mux.HandleFunc("/ws", j.handleWs)
 
-	srv := http.Server{
+	srv := &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 60 * time.Second,
 	}
@@ -125,7 +235,8 @@ func (j *JSONRPC) setupHTTP() error {
 			j.logger.Info("TLS", "key file", j.config.TLSKeyFile)
 
 			go func() {
-				if err := srv.ServeTLS(lis, j.config.TLSCertFile, j.config.TLSKeyFile); err != nil {
+				if err := srv.ServeTLS(lis, j.config.TLSCertFile, j.config.TLSKeyFile); err != nil &&
+					!errors.Is(err, http.ErrServerClosed) {
 					j.logger.Error("closed https connection", "err", err)
 				}
 			}()
@@ -136,7 +247,9 @@ func (j *JSONRPC) setupHTTP() error {
 			if err != nil {
 				j.logger.Error("loading tls certificate", "err", err)
 
-				return err
+				_ = lis.Close()
+
+				return nil, nil, err
 			}
 
 			srv.TLSConfig = &tls.Config{
@@ -145,22 +258,22 @@ func (j *JSONRPC) setupHTTP() error {
 			}
 
 			go func() {
-				if err := srv.ServeTLS(lis, "", ""); err != nil {
+				if err := srv.ServeTLS(lis, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
 					j.logger.Error("closed https connection", "err", err)
 				}
 			}()
 		}
 	} else {
 		go func() {
-			if err := srv.Serve(lis); err != nil {
+			if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				j.logger.Error("closed http connection", "err", err)
 			}
 		}()
 	}
 
-	j.logger.Info("http server started", "addr", j.config.Addr.String())
+	j.logger.Info("http server started", "addr", addr)
 
-	return nil
+	return srv, lis, nil
 }
 
 func loadTLSCertificate(manager secrets.SecretsManager) (*tls.Certificate, error) {
@@ -186,10 +299,20 @@ func loadTLSCertificate(manager secrets.SecretsManager) (*tls.Certificate, error
 	return nil, secrets.ErrSecretNotFound
 }
 
-// The middlewareFactory builds a middleware which enables CORS using the provided config.
-func middlewareFactory(config *Config) func(http.Handler) http.Handler {
+// The middlewareFactory builds a middleware which enables CORS using the provided config,
+// and authenticates the requests if the JWT secret is set.
+func middlewareFactory(config *Config, jwtSecret []byte) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the preflight requests don't carry the credentials
+			if len(jwtSecret) > 0 && r.Method != http.MethodOptions {
+				if err := authenticateRequest(r, jwtSecret, time.Now()); err != nil {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+
+					return
+				}
+			}
+
 			origin := r.Header.Get("Origin")
 
 			for _, allowedOrigin := range config.AccessControlAllowOrigin {
@@ -260,6 +383,15 @@ func isSupportedWSType(messageType int) bool {
 }
 
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
+	// the connection is authenticated once, before the upgrade
+	if len(j.listener.JWTSecret) > 0 {
+		if err := authenticateRequest(req, j.listener.JWTSecret, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+	}
+
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
@@ -287,6 +419,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	}(ws)
 
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	client := j.clientID(req)
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -313,7 +446,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, client)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -332,10 +465,12 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set(
-		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
-	)
+	allowHeaders := "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"
+	if j.config.RateLimitKeyHeader != "" {
+		allowHeaders += ", " + j.config.RateLimitKeyHeader
+	}
+
+	w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
 
 	switch req.Method {
 	case "POST":
@@ -360,7 +495,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Trace("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(data, j.clientID(req))
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
 	} else {
@@ -370,6 +505,39 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	j.logger.Trace("handle", "response", string(resp))
 }
 
+// clientID identifies the client of the request for the rate limiting, by the API key header
+// if configured and present on a request coming from a trusted proxy, otherwise by the remote IP
+func (j *JSONRPC) clientID(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	if j.config.RateLimitKeyHeader != "" && j.isTrustedProxy(host) {
+		if key := req.Header.Get(j.config.RateLimitKeyHeader); key != "" {
+			return "key:" + key
+		}
+	}
+
+	return "ip:" + host
+}
+
+// isTrustedProxy checks if the remote host is one of the proxies trusted to set the rate limit key header
+func (j *JSONRPC) isTrustedProxy(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range j.config.RateLimitTrustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type GetResponse struct {
 	Name    string `json:"name"`
 	ChainID uint64 `json:"chain_id"`
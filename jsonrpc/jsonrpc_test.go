@@ -3,9 +3,12 @@ package jsonrpc
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
@@ -20,6 +23,97 @@ func TestHTTPServer(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestJSONRPC_Close(t *testing.T) {
+	t.Parallel()
+
+	addrs := make([]*net.TCPAddr, 2)
+
+	for i := range addrs {
+		port, err := common.GetFreePort()
+		require.NoError(t, err)
+
+		addrs[i] = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port}
+	}
+
+	j, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:     newMockStore(),
+		Addr:      addrs[0],
+		Listeners: []*Listener{{Addr: addrs[1], Namespaces: []string{"web3"}}},
+	}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, j.Close())
+
+	// the addresses of all the listeners are released
+	for _, addr := range addrs {
+		lis, err := net.Listen("tcp", addr.String())
+		require.NoError(t, err)
+		require.NoError(t, lis.Close())
+	}
+}
+
+// setHeadMockStore is a mock store recording the number the head was set back to
+type setHeadMockStore struct {
+	*mockStore
+
+	headNumber atomic.Uint64
+}
+
+func (s *setHeadMockStore) SetHead(number uint64) error {
+	s.headNumber.Store(number)
+
+	return nil
+}
+
+func TestJSONRPC_PrivilegedMethods(t *testing.T) {
+	t.Parallel()
+
+	addrs := make([]*net.TCPAddr, 2)
+
+	for i := range addrs {
+		port, err := common.GetFreePort()
+		require.NoError(t, err)
+
+		addrs[i] = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port}
+	}
+
+	store := &setHeadMockStore{mockStore: newMockStore()}
+
+	// the default config serves all the namespaces on the public address
+	j, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:                   store,
+		Addr:                    addrs[0],
+		ConcurrentRequestsDebug: 1,
+		Listeners:               []*Listener{{Addr: addrs[1], Namespaces: []string{"debug"}, Privileged: true}},
+	}, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, j.Close())
+	})
+
+	setHead := func(addr *net.TCPAddr) string {
+		t.Helper()
+
+		resp, err := http.Post("http://"+addr.String(), "application/json",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"debug_setHead","params":["0x5"]}`))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(body)
+	}
+
+	require.Contains(t, setHead(addrs[0]), "the method debug_setHead does not exist/is not available")
+	require.Zero(t, store.headNumber.Load())
+
+	require.Contains(t, setHead(addrs[1]), `"result":null`)
+	require.Equal(t, uint64(5), store.headNumber.Load())
+}
+
 func Test_handleGetRequest(t *testing.T) {
 	var (
 		chainName = "polygon-edge-test"
@@ -0,0 +1,110 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// jwtMaxClockSkew is the maximum allowed difference between the token issuance time and the local time
+	jwtMaxClockSkew = 60 * time.Second
+
+	bearerPrefix = "Bearer "
+)
+
+var (
+	errMissingToken      = errors.New("missing bearer token")
+	errMalformedToken    = errors.New("malformed token")
+	errUnsupportedJWTAlg = errors.New("unsupported token algorithm, HS256 expected")
+	errInvalidSignature  = errors.New("invalid token signature")
+	errStaleToken        = errors.New("token issued at is too far from the current time")
+	errExpiredToken      = errors.New("token is expired")
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type jwtClaims struct {
+	IssuedAt  *int64 `json:"iat"`
+	ExpiresAt *int64 `json:"exp"`
+}
+
+// authenticateRequest checks the HS256 signed bearer token of the request against the secret
+func authenticateRequest(r *http.Request, secret []byte, now time.Time) error {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return errMissingToken
+	}
+
+	return verifyJWT(strings.TrimPrefix(auth, bearerPrefix), secret, now)
+}
+
+// verifyJWT verifies the HS256 signature of the token, and that it was issued
+// within jwtMaxClockSkew of the given time and hasn't expired
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errMalformedToken
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return err
+	}
+
+	if header.Alg != "HS256" {
+		return errUnsupportedJWTAlg
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errMalformedToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return err
+	}
+
+	if claims.IssuedAt == nil {
+		return errStaleToken
+	}
+
+	if skew := now.Sub(time.Unix(*claims.IssuedAt, 0)); skew > jwtMaxClockSkew || skew < -jwtMaxClockSkew {
+		return errStaleToken
+	}
+
+	if claims.ExpiresAt != nil && !now.Before(time.Unix(*claims.ExpiresAt, 0)) {
+		return errExpiredToken
+	}
+
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errMalformedToken
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return errMalformedToken
+	}
+
+	return nil
+}
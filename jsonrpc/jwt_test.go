@@ -0,0 +1,128 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestJWT(t *testing.T, alg, claims string, secret []byte) string {
+	t.Helper()
+
+	encode := base64.RawURLEncoding.EncodeToString
+
+	unsigned := encode([]byte(fmt.Sprintf(`{"alg":"%s","typ":"JWT"}`, alg))) + "." + encode([]byte(claims))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + encode(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	iat := func(offset time.Duration) string {
+		return fmt.Sprintf(`{"iat":%d}`, now.Add(offset).Unix())
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{
+			name:  "valid token",
+			token: newTestJWT(t, "HS256", iat(-10*time.Second), testJWTSecret),
+		},
+		{
+			name:  "wrong secret",
+			token: newTestJWT(t, "HS256", iat(0), []byte("other secret")),
+			err:   errInvalidSignature,
+		},
+		{
+			name:  "unsupported algorithm",
+			token: newTestJWT(t, "none", iat(0), testJWTSecret),
+			err:   errUnsupportedJWTAlg,
+		},
+		{
+			name:  "missing issued at",
+			token: newTestJWT(t, "HS256", `{}`, testJWTSecret),
+			err:   errStaleToken,
+		},
+		{
+			name:  "issued too long ago",
+			token: newTestJWT(t, "HS256", iat(-2*jwtMaxClockSkew), testJWTSecret),
+			err:   errStaleToken,
+		},
+		{
+			name:  "issued in the future",
+			token: newTestJWT(t, "HS256", iat(2*jwtMaxClockSkew), testJWTSecret),
+			err:   errStaleToken,
+		},
+		{
+			name: "expired",
+			token: newTestJWT(t, "HS256",
+				fmt.Sprintf(`{"iat":%d,"exp":%d}`, now.Unix(), now.Unix()), testJWTSecret),
+			err: errExpiredToken,
+		},
+		{
+			name:  "malformed",
+			token: "abc.def",
+			err:   errMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifyJWT(tt.token, testJWTSecret, now)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMiddlewareFactory_JWT(t *testing.T) {
+	t.Parallel()
+
+	handler := middlewareFactory(&Config{}, testJWTSecret)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	serve := func(authorization string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	token := newTestJWT(t, "HS256", fmt.Sprintf(`{"iat":%d}`, time.Now().Unix()), testJWTSecret)
+
+	require.Equal(t, http.StatusUnauthorized, serve(""))
+	require.Equal(t, http.StatusUnauthorized, serve(bearerPrefix+"abc.def.ghi"))
+	require.Equal(t, http.StatusOK, serve(bearerPrefix+token))
+}
@@ -3,8 +3,16 @@ package jsonrpc
 import (
 	"math/big"
 	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -149,6 +157,14 @@ func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
 
+func (m *mockStore) HistoryTail() uint64 {
+	return 0
+}
+
+func (m *mockStore) BloomStatus() (uint64, uint64) {
+	return 0, 0
+}
+
 func (m *mockStore) TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error) {
 	txPoolUnsubscribe := func() {
 		close(m.txPoolChannel)
@@ -220,3 +236,95 @@ func (m *mockStore) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 func (m *mockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
+
+// executorMockStore executes the transactions with a real executor,
+// on top of the genesis state of the latest header
+type executorMockStore struct {
+	*mockStore
+
+	executor *state.Executor
+}
+
+func newExecutorMockStore(t *testing.T, alloc map[types.Address]*chain.GenesisAccount) *executorMockStore {
+	t.Helper()
+
+	executor := state.NewExecutor(&chain.Params{
+		Forks:   chain.AllForksEnabled,
+		ChainID: 100,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	root, err := executor.WriteGenesis(alloc, types.ZeroHash)
+	require.NoError(t, err)
+
+	store := &executorMockStore{
+		mockStore: newMockStore(),
+		executor:  executor,
+	}
+
+	store.header = &types.Header{
+		Number:    10,
+		Timestamp: 1000,
+		GasLimit:  10_000_000,
+		BaseFee:   100,
+		Miner:     types.StringToAddress("c0").Bytes(),
+		StateRoot: root,
+	}
+	store.header.ComputeHash()
+
+	return store
+}
+
+func (s *executorMockStore) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return types.BytesToAddress(header.Miner), nil
+}
+
+func (s *executorMockStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
+	return chain.AllForksEnabled.At(blockNumber)
+}
+
+func (s *executorMockStore) GetBaseFee() uint64 {
+	return s.header.BaseFee
+}
+
+func (s *executorMockStore) BeginTxn(
+	parentRoot types.Hash,
+	header *types.Header,
+	coinbase types.Address,
+) (*state.Transition, error) {
+	return s.executor.BeginTxn(parentRoot, header, coinbase)
+}
+
+func (s *executorMockStore) ContinueTxn(t *state.Transition, header *types.Header, coinbase types.Address) error {
+	return s.executor.ContinueTxn(t, header, coinbase)
+}
+
+func (s *executorMockStore) ApplyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	override types.StateOverride,
+	nonPayable bool,
+) (*runtime.ExecutionResult, error) {
+	transition, err := s.executor.BeginTxn(header.StateRoot, header, types.BytesToAddress(header.Miner))
+	if err != nil {
+		return nil, err
+	}
+
+	if override != nil {
+		if err := transition.WithStateOverride(override); err != nil {
+			return nil, err
+		}
+	}
+
+	transition.SetNonPayable(nonPayable)
+
+	return transition.Apply(txn)
+}
@@ -18,7 +18,7 @@ func TestNetEndpoint_PeerCount(t *testing.T) {
 	resp, err := dispatcher.Handle([]byte(`{
 		"method": "net_peerCount",
 		"params": [""]
-	}`))
+	}`), "")
 	assert.NoError(t, err)
 
 	var res string
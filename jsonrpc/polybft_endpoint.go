@@ -0,0 +1,231 @@
+package jsonrpc
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// polybftStore interface provides access to the methods needed by polybft endpoint
+type polybftStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetValidatorSet returns the validators which validated the given block
+	GetValidatorSet(blockNumber uint64) ([]*types.ValidatorInfo, error)
+
+	// GetEpochInfo returns the epoch the given block belongs to
+	GetEpochInfo(blockNumber uint64) (*types.EpochInfo, error)
+
+	// GetBlockSigners returns the validators which committed the given block
+	GetBlockSigners(blockNumber uint64) (*types.BlockSigners, error)
+
+	// GetProposer calculates the proposer of the given round of the block being built
+	GetProposer(height, round uint64) (types.Address, error)
+
+	// GetVotingPowers returns the voting powers of the validators which validated the given block
+	GetVotingPowers(blockNumber uint64) (map[types.Address]*big.Int, error)
+
+	// GetValidatorsUptime returns the uptime of the validators in the given epoch
+	GetValidatorsUptime(epoch uint64) ([]*types.ValidatorUptime, error)
+
+	// GetDoubleSignEvidence returns the double sign evidence of the validators in the given range of heights
+	GetDoubleSignEvidence(fromHeight, toHeight uint64) ([]*types.DoubleSignEvidence, error)
+}
+
+type validatorInfo struct {
+	Address     types.Address `json:"address"`
+	BlsKey      argBytes      `json:"blsKey"`
+	VotingPower argBig        `json:"votingPower"`
+	IsActive    bool          `json:"isActive"`
+}
+
+type epochInfo struct {
+	Number           argUint64 `json:"number"`
+	FirstBlock       argUint64 `json:"firstBlock"`
+	LastBlock        argUint64 `json:"lastBlock"`
+	SprintSize       argUint64 `json:"sprintSize"`
+	SprintFirstBlock argUint64 `json:"sprintFirstBlock"`
+	SprintLastBlock  argUint64 `json:"sprintLastBlock"`
+}
+
+type blockSigners struct {
+	Signers           []types.Address `json:"signers"`
+	SignedVotingPower argBig          `json:"signedVotingPower"`
+	TotalVotingPower  argBig          `json:"totalVotingPower"`
+}
+
+type votingPowers struct {
+	TotalVotingPower argBig                   `json:"totalVotingPower"`
+	VotingPowers     map[types.Address]argBig `json:"votingPowers"`
+}
+
+type validatorUptime struct {
+	Address        types.Address `json:"address"`
+	SignedBlocks   argUint64     `json:"signedBlocks"`
+	MissedSeals    argUint64     `json:"missedSeals"`
+	ProposedBlocks argUint64     `json:"proposedBlocks"`
+	SkippedRounds  argUint64     `json:"skippedRounds"`
+}
+
+type doubleSignEvidence struct {
+	Height        argUint64     `json:"height"`
+	Round         argUint64     `json:"round"`
+	MessageType   string        `json:"messageType"`
+	Signer        types.Address `json:"signer"`
+	FirstMessage  argBytes      `json:"firstMessage"`
+	SecondMessage argBytes      `json:"secondMessage"`
+}
+
+// PolyBFT is the polybft jsonrpc endpoint, exposing the validators, epochs and signers of the chain
+type PolyBFT struct {
+	store polybftStore
+}
+
+// GetValidators returns the validator set which validated the given block
+func (p *PolyBFT) GetValidators(number BlockNumber) (interface{}, error) {
+	blockNumber, err := GetNumericBlockNumber(number, p.store)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := p.store.GetValidatorSet(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*validatorInfo, len(validators))
+
+	for i, v := range validators {
+		result[i] = &validatorInfo{
+			Address:     v.Address,
+			BlsKey:      v.BlsKey,
+			VotingPower: argBig(*v.VotingPower),
+			IsActive:    v.IsActive,
+		}
+	}
+
+	return result, nil
+}
+
+// GetEpoch returns the epoch the given block belongs to, with its first and last block,
+// and the sprint of the block within the epoch
+func (p *PolyBFT) GetEpoch(number BlockNumber) (interface{}, error) {
+	blockNumber, err := GetNumericBlockNumber(number, p.store)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := p.store.GetEpochInfo(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &epochInfo{
+		Number:           argUint64(epoch.Number),
+		FirstBlock:       argUint64(epoch.FirstBlock),
+		LastBlock:        argUint64(epoch.LastBlock),
+		SprintSize:       argUint64(epoch.SprintSize),
+		SprintFirstBlock: argUint64(epoch.SprintFirstBlock),
+		SprintLastBlock:  argUint64(epoch.SprintLastBlock),
+	}, nil
+}
+
+// GetBlockSigners returns the validators which committed the given block
+func (p *PolyBFT) GetBlockSigners(number BlockNumber) (interface{}, error) {
+	blockNumber, err := GetNumericBlockNumber(number, p.store)
+	if err != nil {
+		return nil, err
+	}
+
+	signers, err := p.store.GetBlockSigners(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockSigners{
+		Signers:           signers.Signers,
+		SignedVotingPower: argBig(*signers.SignedVotingPower),
+		TotalVotingPower:  argBig(*signers.TotalVotingPower),
+	}, nil
+}
+
+// GetProposer returns the proposer of the given round of the block being built
+func (p *PolyBFT) GetProposer(height argUint64, round argUint64) (interface{}, error) {
+	return p.store.GetProposer(uint64(height), uint64(round))
+}
+
+// GetVotingPowers returns the voting powers of the validators which validated the given block
+func (p *PolyBFT) GetVotingPowers(number BlockNumber) (interface{}, error) {
+	blockNumber, err := GetNumericBlockNumber(number, p.store)
+	if err != nil {
+		return nil, err
+	}
+
+	powers, err := p.store.GetVotingPowers(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &votingPowers{
+		VotingPowers: make(map[types.Address]argBig, len(powers)),
+	}
+
+	total := new(big.Int)
+
+	for addr, power := range powers {
+		result.VotingPowers[addr] = argBig(*power)
+		total.Add(total, power)
+	}
+
+	result.TotalVotingPower = argBig(*total)
+
+	return result, nil
+}
+
+// GetValidatorsUptime returns the signed blocks, missed seals, proposed blocks and skipped rounds
+// of the validators in the given epoch
+func (p *PolyBFT) GetValidatorsUptime(epoch argUint64) (interface{}, error) {
+	uptime, err := p.store.GetValidatorsUptime(uint64(epoch))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*validatorUptime, len(uptime))
+
+	for i, u := range uptime {
+		result[i] = &validatorUptime{
+			Address:        u.Address,
+			SignedBlocks:   argUint64(u.SignedBlocks),
+			MissedSeals:    argUint64(u.MissedSeals),
+			ProposedBlocks: argUint64(u.ProposedBlocks),
+			SkippedRounds:  argUint64(u.SkippedRounds),
+		}
+	}
+
+	return result, nil
+}
+
+// GetDoubleSignEvidence returns the evidence of the validators which signed conflicting consensus messages
+// in the given range of heights. Each evidence holds both the encoded messages along with their signatures
+func (p *PolyBFT) GetDoubleSignEvidence(fromHeight argUint64, toHeight argUint64) (interface{}, error) {
+	evidence, err := p.store.GetDoubleSignEvidence(uint64(fromHeight), uint64(toHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*doubleSignEvidence, len(evidence))
+
+	for i, e := range evidence {
+		result[i] = &doubleSignEvidence{
+			Height:        argUint64(e.Height),
+			Round:         argUint64(e.Round),
+			MessageType:   e.MessageType,
+			Signer:        e.Signer,
+			FirstMessage:  e.FirstMessage,
+			SecondMessage: e.SecondMessage,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,241 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type polybftEndpointMockStore struct {
+	header       *types.Header
+	validators   map[uint64][]*types.ValidatorInfo
+	epochs       map[uint64]*types.EpochInfo
+	signers      map[uint64]*types.BlockSigners
+	proposerFn   func(uint64, uint64) (types.Address, error)
+	votingPowers map[uint64]map[types.Address]*big.Int
+	uptime       map[uint64][]*types.ValidatorUptime
+	evidence     []*types.DoubleSignEvidence
+}
+
+func (s *polybftEndpointMockStore) Header() *types.Header {
+	return s.header
+}
+
+func (s *polybftEndpointMockStore) GetValidatorSet(blockNumber uint64) ([]*types.ValidatorInfo, error) {
+	validators, ok := s.validators[blockNumber]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return validators, nil
+}
+
+func (s *polybftEndpointMockStore) GetEpochInfo(blockNumber uint64) (*types.EpochInfo, error) {
+	epoch, ok := s.epochs[blockNumber]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return epoch, nil
+}
+
+func (s *polybftEndpointMockStore) GetBlockSigners(blockNumber uint64) (*types.BlockSigners, error) {
+	signers, ok := s.signers[blockNumber]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return signers, nil
+}
+
+func (s *polybftEndpointMockStore) GetProposer(height, round uint64) (types.Address, error) {
+	return s.proposerFn(height, round)
+}
+
+func (s *polybftEndpointMockStore) GetVotingPowers(blockNumber uint64) (map[types.Address]*big.Int, error) {
+	powers, ok := s.votingPowers[blockNumber]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return powers, nil
+}
+
+func (s *polybftEndpointMockStore) GetValidatorsUptime(epoch uint64) ([]*types.ValidatorUptime, error) {
+	uptime, ok := s.uptime[epoch]
+	if !ok {
+		return nil, errors.New("epoch not found")
+	}
+
+	return uptime, nil
+}
+
+func (s *polybftEndpointMockStore) GetDoubleSignEvidence(
+	fromHeight, toHeight uint64) ([]*types.DoubleSignEvidence, error) {
+	var result []*types.DoubleSignEvidence
+
+	for _, e := range s.evidence {
+		if e.Height >= fromHeight && e.Height <= toHeight {
+			result = append(result, e)
+		}
+	}
+
+	return result, nil
+}
+
+func TestPolyBFTEndpoint(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+	)
+
+	store := &polybftEndpointMockStore{
+		header: &types.Header{Number: 12},
+		validators: map[uint64][]*types.ValidatorInfo{
+			12: {
+				{Address: addr1, BlsKey: []byte{1}, VotingPower: big.NewInt(10), IsActive: true},
+				{Address: addr2, BlsKey: []byte{2}, VotingPower: big.NewInt(20), IsActive: true},
+			},
+		},
+		epochs: map[uint64]*types.EpochInfo{
+			5: {Number: 1, FirstBlock: 1, LastBlock: 10, SprintSize: 5, SprintFirstBlock: 1, SprintLastBlock: 5},
+		},
+		signers: map[uint64]*types.BlockSigners{
+			12: {Signers: []types.Address{addr2}, SignedVotingPower: big.NewInt(20), TotalVotingPower: big.NewInt(30)},
+		},
+		proposerFn: func(height, round uint64) (types.Address, error) {
+			if height != 13 {
+				return types.ZeroAddress, errors.New("invalid height")
+			}
+
+			if round%2 == 0 {
+				return addr1, nil
+			}
+
+			return addr2, nil
+		},
+		votingPowers: map[uint64]map[types.Address]*big.Int{
+			12: {addr1: big.NewInt(10), addr2: big.NewInt(20)},
+		},
+		uptime: map[uint64][]*types.ValidatorUptime{
+			2: {
+				{Address: addr1, SignedBlocks: 9, MissedSeals: 1, ProposedBlocks: 5},
+				{Address: addr2, SignedBlocks: 10, ProposedBlocks: 5, SkippedRounds: 2},
+			},
+		},
+		evidence: []*types.DoubleSignEvidence{
+			{Height: 10, Round: 1, MessageType: "COMMIT", Signer: addr1, FirstMessage: []byte{1}, SecondMessage: []byte{2}},
+			{Height: 12, MessageType: "PREPARE", Signer: addr2, FirstMessage: []byte{3}, SecondMessage: []byte{4}},
+		},
+	}
+
+	endpoint := &PolyBFT{store: store}
+
+	t.Run("getValidators", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetValidators(LatestBlockNumber)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*validatorInfo{
+			{Address: addr1, BlsKey: argBytes{1}, VotingPower: argBig(*big.NewInt(10)), IsActive: true},
+			{Address: addr2, BlsKey: argBytes{2}, VotingPower: argBig(*big.NewInt(20)), IsActive: true},
+		}, res)
+
+		_, err = endpoint.GetValidators(BlockNumber(13))
+		require.Error(t, err)
+	})
+
+	t.Run("getEpoch", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetEpoch(BlockNumber(5))
+		require.NoError(t, err)
+
+		assert.Equal(t, &epochInfo{
+			Number:           1,
+			FirstBlock:       1,
+			LastBlock:        10,
+			SprintSize:       5,
+			SprintFirstBlock: 1,
+			SprintLastBlock:  5,
+		}, res)
+	})
+
+	t.Run("getBlockSigners", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetBlockSigners(BlockNumber(12))
+		require.NoError(t, err)
+
+		assert.Equal(t, &blockSigners{
+			Signers:           []types.Address{addr2},
+			SignedVotingPower: argBig(*big.NewInt(20)),
+			TotalVotingPower:  argBig(*big.NewInt(30)),
+		}, res)
+	})
+
+	t.Run("getProposer", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetProposer(13, 1)
+		require.NoError(t, err)
+		assert.Equal(t, addr2, res)
+
+		_, err = endpoint.GetProposer(12, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("getVotingPowers", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetVotingPowers(LatestBlockNumber)
+		require.NoError(t, err)
+
+		assert.Equal(t, &votingPowers{
+			TotalVotingPower: argBig(*big.NewInt(30)),
+			VotingPowers: map[types.Address]argBig{
+				addr1: argBig(*big.NewInt(10)),
+				addr2: argBig(*big.NewInt(20)),
+			},
+		}, res)
+	})
+
+	t.Run("getValidatorsUptime", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetValidatorsUptime(2)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*validatorUptime{
+			{Address: addr1, SignedBlocks: 9, MissedSeals: 1, ProposedBlocks: 5},
+			{Address: addr2, SignedBlocks: 10, ProposedBlocks: 5, SkippedRounds: 2},
+		}, res)
+
+		_, err = endpoint.GetValidatorsUptime(3)
+		require.Error(t, err)
+	})
+
+	t.Run("getDoubleSignEvidence", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := endpoint.GetDoubleSignEvidence(11, 12)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*doubleSignEvidence{
+			{
+				Height:        12,
+				MessageType:   "PREPARE",
+				Signer:        addr2,
+				FirstMessage:  argBytes{3},
+				SecondMessage: argBytes{4},
+			},
+		}, res)
+	})
+}
@@ -167,6 +167,32 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// bloomFilters returns the query criteria in the form used for the bloom bits filtering.
+// Each group contains the alternatives (addresses or topics) out of which at least one has to match
+func (q *LogQuery) bloomFilters() [][][]byte {
+	filters := make([][][]byte, 0, len(q.Topics)+1)
+
+	if len(q.Addresses) > 0 {
+		group := make([][]byte, len(q.Addresses))
+		for i, addr := range q.Addresses {
+			group[i] = addr.Bytes()
+		}
+
+		filters = append(filters, group)
+	}
+
+	for _, topics := range q.Topics {
+		group := make([][]byte, len(topics))
+		for i, topic := range topics {
+			group[i] = topic.Bytes()
+		}
+
+		filters = append(filters, group)
+	}
+
+	return filters
+}
+
 // Match returns whether the receipt includes topics for this filter
 func (q *LogQuery) Match(log *types.Log) bool {
 	// check addresses
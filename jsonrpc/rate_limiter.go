@@ -0,0 +1,172 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterPruneInterval is the interval of dropping the buckets of the idle clients
+const rateLimiterPruneInterval = 10 * time.Minute
+
+// RateLimit is a token bucket limit of the requests a single client makes to a class of methods
+type RateLimit struct {
+	// Methods are the limited method names, or namespace wildcards like debug_*.
+	// All the methods are limited if empty
+	Methods []string
+
+	// RequestsPerSecond is the rate the bucket is refilled at
+	RequestsPerSecond float64
+
+	// Burst is the capacity of the bucket
+	Burst int
+}
+
+// ParseRateLimit parses the rate limit in the <methods>=<requests per second>:<burst> format,
+// where the methods are comma separated (e.g. eth_call,eth_getLogs=10:20 or debug_*=1:1).
+// The methods are omitted for the limit of all the methods (e.g. 100:200)
+func ParseRateLimit(raw string) (*RateLimit, error) {
+	limit := &RateLimit{}
+
+	if methods, bucket, ok := strings.Cut(raw, "="); ok {
+		limit.Methods = strings.Split(methods, ",")
+		raw = bucket
+	}
+
+	rps, burst, ok := strings.Cut(raw, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid rate limit %s, expected <methods>=<requests per second>:<burst>", raw)
+	}
+
+	var err error
+
+	if limit.RequestsPerSecond, err = strconv.ParseFloat(rps, 64); err != nil || limit.RequestsPerSecond <= 0 {
+		return nil, fmt.Errorf("invalid requests per second of the rate limit: %s", rps)
+	}
+
+	if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst <= 0 {
+		return nil, fmt.Errorf("invalid burst of the rate limit: %s", burst)
+	}
+
+	return limit, nil
+}
+
+// ParseTrustedProxy parses the address of a proxy trusted to set the rate limit key header,
+// either a single IP (e.g. 10.0.0.1) or a CIDR range (e.g. 10.0.0.0/8)
+func ParseTrustedProxy(raw string) (*net.IPNet, error) {
+	if _, ipNet, err := net.ParseCIDR(raw); err == nil {
+		return ipNet, nil
+	}
+
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid trusted proxy %s, expected an IP or a CIDR range", raw)
+	}
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+}
+
+// matches returns true if the method belongs to the limited class of methods
+func (l *RateLimit) matches(method string) bool {
+	if len(l.Methods) == 0 {
+		return true
+	}
+
+	for _, m := range l.Methods {
+		if m == method {
+			return true
+		}
+
+		if namespace, ok := strings.CutSuffix(m, "*"); ok && strings.HasPrefix(method, namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientBuckets are the token buckets of a single client, one per rate limit
+type clientBuckets struct {
+	limiters []*rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter limits the requests of each client (IP or API key) by the first rate limit
+// matching the requested method. The methods not matching any of the limits are not limited
+type rateLimiter struct {
+	limits []*RateLimit
+
+	lock       sync.Mutex
+	clients    map[string]*clientBuckets
+	lastPruned time.Time
+}
+
+func newRateLimiter(limits []*RateLimit) *rateLimiter {
+	return &rateLimiter{
+		limits:     limits,
+		clients:    make(map[string]*clientBuckets),
+		lastPruned: time.Now(),
+	}
+}
+
+// allow consumes a token of the client for the method, returning false if there is none left
+func (r *rateLimiter) allow(client string, method string, now time.Time) bool {
+	index := -1
+
+	for i, limit := range r.limits {
+		if limit.matches(method) {
+			index = i
+
+			break
+		}
+	}
+
+	if index == -1 {
+		return true
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.prune(now)
+
+	buckets, ok := r.clients[client]
+	if !ok {
+		buckets = &clientBuckets{limiters: make([]*rate.Limiter, len(r.limits))}
+		r.clients[client] = buckets
+	}
+
+	buckets.lastSeen = now
+
+	limiter := buckets.limiters[index]
+	if limiter == nil {
+		limiter = rate.NewLimiter(rate.Limit(r.limits[index].RequestsPerSecond), r.limits[index].Burst)
+		buckets.limiters[index] = limiter
+	}
+
+	return limiter.AllowN(now, 1)
+}
+
+// prune drops the buckets of the clients idle for the prune interval, must be called with the lock held
+func (r *rateLimiter) prune(now time.Time) {
+	if now.Sub(r.lastPruned) < rateLimiterPruneInterval {
+		return
+	}
+
+	for client, buckets := range r.clients {
+		if now.Sub(buckets.lastSeen) >= rateLimiterPruneInterval {
+			delete(r.clients, client)
+		}
+	}
+
+	r.lastPruned = now
+}
@@ -0,0 +1,182 @@
+package jsonrpc
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	limit, err := ParseRateLimit("eth_call,debug_*=0.5:10")
+	require.NoError(t, err)
+	assert.Equal(t, &RateLimit{Methods: []string{"eth_call", "debug_*"}, RequestsPerSecond: 0.5, Burst: 10}, limit)
+
+	limit, err = ParseRateLimit("100:200")
+	require.NoError(t, err)
+	assert.Equal(t, &RateLimit{RequestsPerSecond: 100, Burst: 200}, limit)
+
+	for _, raw := range []string{"eth_call=10", "eth_call=a:1", "eth_call=1:0", "0:1"} {
+		_, err := ParseRateLimit(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter([]*RateLimit{
+		{Methods: []string{"eth_getLogs", "debug_*"}, RequestsPerSecond: 1, Burst: 2},
+		{Methods: []string{"eth_call"}, RequestsPerSecond: 1, Burst: 1},
+	})
+
+	now := time.Now()
+
+	// the methods of the class share the bucket
+	assert.True(t, limiter.allow("ip:1", "eth_getLogs", now))
+	assert.True(t, limiter.allow("ip:1", "debug_traceBlockByNumber", now))
+	assert.False(t, limiter.allow("ip:1", "eth_getLogs", now))
+
+	// the classes and the clients have separate buckets
+	assert.True(t, limiter.allow("ip:1", "eth_call", now))
+	assert.True(t, limiter.allow("ip:2", "eth_getLogs", now))
+
+	// the methods not matching any of the limits are not limited
+	assert.True(t, limiter.allow("ip:1", "eth_chainId", now))
+
+	// the bucket refills over time
+	assert.True(t, limiter.allow("ip:1", "eth_getLogs", now.Add(time.Second)))
+
+	// the idle clients are pruned
+	limiter.allow("ip:3", "eth_call", now.Add(rateLimiterPruneInterval+time.Second))
+	assert.Len(t, limiter.clients, 1)
+}
+
+func TestDispatcher_Limits(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			rateLimits:              []*RateLimit{{Methods: []string{"web3_*"}, RequestsPerSecond: 0.001, Burst: 1}},
+			maxResponseSize:         70,
+		},
+	)
+
+	sha3 := []byte(`{"id":1,"method":"web3_sha3","params":["0x01"]}`)
+
+	resp, err := dispatcher.Handle(sha3, "ip:1")
+	require.NoError(t, err)
+	assert.NotContains(t, string(resp), "error")
+
+	resp, err = dispatcher.Handle(sha3, "ip:1")
+	require.NoError(t, err)
+	assert.Contains(t, string(resp), `"code":-32005,"message":"rate limit exceeded for web3_sha3"`)
+
+	// the requests of the unknown clients are not limited
+	resp, err = dispatcher.HandleWs(sha3, &mockWsConn{}, "")
+	require.NoError(t, err)
+	assert.NotContains(t, string(resp), "error")
+
+	// the responses are limited in total within the batch
+	resp, err = dispatcher.Handle([]byte(`[
+		{"id":1,"method":"web3_sha3","params":["0x01"]},
+		{"id":2,"method":"web3_sha3","params":["0x02"]}
+	]`), "")
+	require.NoError(t, err)
+
+	var responses []*ErrorResponse
+
+	require.NoError(t, expectBatchJSONResult(resp, &responses))
+	require.Len(t, responses, 2)
+	assert.Nil(t, responses[0].Error)
+	assert.Equal(t, "response size exceeds the limit of 70 bytes", responses[1].Error.Message)
+}
+
+func TestParseTrustedProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy, err := ParseTrustedProxy("10.0.0.0/8")
+	require.NoError(t, err)
+	assert.True(t, proxy.Contains(net.ParseIP("10.1.2.3")))
+	assert.False(t, proxy.Contains(net.ParseIP("11.1.2.3")))
+
+	proxy, err = ParseTrustedProxy("192.168.1.1")
+	require.NoError(t, err)
+	assert.True(t, proxy.Contains(net.ParseIP("192.168.1.1")))
+	assert.False(t, proxy.Contains(net.ParseIP("192.168.1.2")))
+
+	proxy, err = ParseTrustedProxy("::1")
+	require.NoError(t, err)
+	assert.True(t, proxy.Contains(net.ParseIP("::1")))
+
+	_, err = ParseTrustedProxy("proxy")
+	assert.Error(t, err)
+}
+
+func TestJSONRPC_clientID(t *testing.T) {
+	t.Parallel()
+
+	proxy, err := ParseTrustedProxy("10.0.0.1")
+	require.NoError(t, err)
+
+	j := &JSONRPC{config: &Config{
+		RateLimitKeyHeader:      "X-Api-Key",
+		RateLimitTrustedProxies: []*net.IPNet{proxy},
+	}}
+
+	newRequest := func(remoteAddr, key string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+
+		if key != "" {
+			req.Header.Set("X-Api-Key", key)
+		}
+
+		return req
+	}
+
+	// the key is honoured only when set by the trusted proxy
+	assert.Equal(t, "key:abc", j.clientID(newRequest("10.0.0.1:1234", "abc")))
+	assert.Equal(t, "ip:10.0.0.1", j.clientID(newRequest("10.0.0.1:1234", "")))
+	assert.Equal(t, "ip:10.0.0.2", j.clientID(newRequest("10.0.0.2:1234", "abc")))
+}
+
+func TestDispatcher_marshalResult(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{maxResponseSize: 20},
+	)
+
+	// the list is encoded as a whole when it fits
+	data, err := dispatcher.marshalResult("test", []string{"a", "b"}, 0)
+	require.Nil(t, err)
+	assert.Equal(t, `["a","b"]`, string(data))
+
+	data, err = dispatcher.marshalResult("test", []byte{0x1}, 0)
+	require.Nil(t, err)
+	assert.Equal(t, `"AQ=="`, string(data))
+
+	// the encoding stops once the limit is exceeded, along with the responses already sent in the batch
+	_, err = dispatcher.marshalResult("test", []string{"aaaa", "bbbb", "cccc", "dddd"}, 0)
+	require.NotNil(t, err)
+	assert.Equal(t, "response size exceeds the limit of 20 bytes", err.Error())
+
+	_, err = dispatcher.marshalResult("test", []string{"a", "b"}, 15)
+	require.NotNil(t, err)
+
+	_, err = dispatcher.marshalResult("test", "a long string result", 0)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,445 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	traceTypeTrace = "trace"
+
+	parityCallType   = "call"
+	parityCreateType = "create"
+)
+
+// ErrTraceTypeNotSupported is an error returned when replaying a transaction with unsupported trace type
+var ErrTraceTypeNotSupported = errors.New("trace type not supported")
+
+// traceStore interface provides access to the methods needed by trace endpoint
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (uint64, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the trace jsonrpc endpoint, serving the flat call traces in the Parity (OpenEthereum) format
+type Trace struct {
+	store           traceStore
+	throttling      *Throttling
+	blockRangeLimit uint64
+}
+
+func NewTrace(store traceStore, blockRangeLimit, requestsPerSecond uint64) *Trace {
+	return &Trace{
+		store:           store,
+		throttling:      NewThrottling(requestsPerSecond, time.Second),
+		blockRangeLimit: blockRangeLimit,
+	}
+}
+
+// ParityTrace is a single call of a transaction, flattened out of the call tree
+type ParityTrace struct {
+	Action              *ParityTraceAction `json:"action"`
+	BlockHash           *types.Hash        `json:"blockHash,omitempty"`
+	BlockNumber         *uint64            `json:"blockNumber,omitempty"`
+	Error               string             `json:"error,omitempty"`
+	Result              *ParityTraceResult `json:"result"`
+	Subtraces           int                `json:"subtraces"`
+	TraceAddress        []int              `json:"traceAddress"`
+	TransactionHash     *types.Hash        `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64            `json:"transactionPosition,omitempty"`
+	Type                string             `json:"type"`
+}
+
+// ParityTraceAction is the input of a call (callType, to and input)
+// or of a contract creation (init)
+type ParityTraceAction struct {
+	CallType string `json:"callType,omitempty"`
+	From     string `json:"from"`
+	To       string `json:"to,omitempty"`
+	Gas      string `json:"gas"`
+	Input    string `json:"input,omitempty"`
+	Init     string `json:"init,omitempty"`
+	Value    string `json:"value"`
+}
+
+// ParityTraceResult is the outcome of a successful call (output)
+// or of a successful contract creation (address and code)
+type ParityTraceResult struct {
+	Address string `json:"address,omitempty"`
+	Code    string `json:"code,omitempty"`
+	GasUsed string `json:"gasUsed"`
+	Output  string `json:"output,omitempty"`
+}
+
+// TraceReplayResult is the result of replaying a transaction
+type TraceReplayResult struct {
+	Output    string         `json:"output"`
+	StateDiff interface{}    `json:"stateDiff"`
+	Trace     []*ParityTrace `json:"trace"`
+	VMTrace   interface{}    `json:"vmTrace"`
+}
+
+// TraceFilterRequest selects the traces of the block range by the sender and the recipient
+type TraceFilterRequest struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *uint64         `json:"after"`
+	Count       *uint64         `json:"count"`
+}
+
+// match returns true if the trace is sent from and to one of the filtered addresses.
+// An empty list of addresses matches any address
+func (f *TraceFilterRequest) match(trace *ParityTrace) bool {
+	to := trace.Action.To
+	if trace.Type == parityCreateType {
+		if trace.Result == nil {
+			to = ""
+		} else {
+			to = trace.Result.Address
+		}
+	}
+
+	return matchAddress(f.FromAddress, trace.Action.From) && matchAddress(f.ToAddress, to)
+}
+
+func matchAddress(addrs []types.Address, addr string) bool {
+	if len(addrs) == 0 {
+		return true
+	}
+
+	if addr == "" {
+		return false
+	}
+
+	target := types.StringToAddress(addr)
+
+	for _, a := range addrs {
+		if a == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Block returns the traces of all the transactions in the block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			num, err := GetNumericBlockNumber(number, t.store)
+			if err != nil {
+				return nil, err
+			}
+
+			block, ok := t.store.GetBlockByNumber(num, true)
+			if !ok {
+				return nil, fmt.Errorf("block %d not found", num)
+			}
+
+			return t.traceBlock(block)
+		},
+	)
+}
+
+// Transaction returns the traces of the transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			call, block, err := t.traceTxn(txHash)
+			if err != nil {
+				return nil, err
+			}
+
+			position := uint64(0)
+
+			for i, tx := range block.Transactions {
+				if tx.Hash() == txHash {
+					position = uint64(i)
+
+					break
+				}
+			}
+
+			return flattenCall(call, block, txHash, position), nil
+		},
+	)
+}
+
+// ReplayTransaction replays the transaction, returning the requested trace types.
+// Only the trace type is supported
+func (t *Trace) ReplayTransaction(txHash types.Hash, traceTypes []string) (interface{}, error) {
+	withTrace := false
+
+	for _, traceType := range traceTypes {
+		if traceType != traceTypeTrace {
+			return nil, fmt.Errorf("%w: %s", ErrTraceTypeNotSupported, traceType)
+		}
+
+		withTrace = true
+	}
+
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			call, _, err := t.traceTxn(txHash)
+			if err != nil {
+				return nil, err
+			}
+
+			result := &TraceReplayResult{}
+
+			if call != nil {
+				result.Output = call.Output
+			}
+
+			if withTrace {
+				result.Trace = flattenCall(call, nil, txHash, 0)
+			}
+
+			return result, nil
+		},
+	)
+}
+
+// Filter returns the traces of the block range, matching the given filter
+func (t *Trace) Filter(filter TraceFilterRequest) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			from, err := t.filterBlockNumber(filter.FromBlock)
+			if err != nil {
+				return nil, err
+			}
+
+			to, err := t.filterBlockNumber(filter.ToBlock)
+			if err != nil {
+				return nil, err
+			}
+
+			if to < from {
+				return nil, ErrIncorrectBlockRange
+			}
+
+			// if not disabled, avoid handling large block ranges
+			if t.blockRangeLimit != 0 && to-from > t.blockRangeLimit {
+				return nil, ErrBlockRangeTooHigh
+			}
+
+			var (
+				traces = make([]*ParityTrace, 0)
+				skip   uint64
+			)
+
+			if filter.After != nil {
+				skip = *filter.After
+			}
+
+			for num := from; num <= to; num++ {
+				block, ok := t.store.GetBlockByNumber(num, true)
+				if !ok {
+					return nil, fmt.Errorf("block %d not found", num)
+				}
+
+				blockTraces, err := t.traceBlock(block)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, trace := range blockTraces {
+					if !filter.match(trace) {
+						continue
+					}
+
+					if skip > 0 {
+						skip--
+
+						continue
+					}
+
+					traces = append(traces, trace)
+
+					if filter.Count != nil && uint64(len(traces)) >= *filter.Count {
+						return traces, nil
+					}
+				}
+			}
+
+			return traces, nil
+		},
+	)
+}
+
+// filterBlockNumber returns the block number of the filter, the latest one if not set
+func (t *Trace) filterBlockNumber(number *BlockNumber) (uint64, error) {
+	if number == nil {
+		return t.store.Header().Number, nil
+	}
+
+	return GetNumericBlockNumber(*number, t.store)
+}
+
+// traceBlock returns the flat traces of all the transactions in the block
+func (t *Trace) traceBlock(block *types.Block) ([]*ParityTrace, error) {
+	traces := make([]*ParityTrace, 0)
+
+	// genesis has no transactions to trace
+	if block.Number() == 0 || len(block.Transactions) == 0 {
+		return traces, nil
+	}
+
+	callTracer := &calltracer.CallTracer{RecordErrors: true}
+
+	cancel := cancelOnTimeout(callTracer, defaultTraceTimeout)
+	defer cancel()
+
+	results, err := t.store.TraceBlock(block, callTracer)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, res := range results {
+		call, _ := res.(*calltracer.Call)
+		tx := block.Transactions[i]
+
+		traces = append(traces, flattenCall(call, block, tx.Hash(), uint64(i))...)
+	}
+
+	return traces, nil
+}
+
+// traceTxn returns the call tree of the transaction along with the block including it
+func (t *Trace) traceTxn(txHash types.Hash) (*calltracer.Call, *types.Block, error) {
+	tx, block := GetTxAndBlockByTxHash(txHash, t.store)
+	if tx == nil {
+		return nil, nil, fmt.Errorf("tx %s not found", txHash.String())
+	}
+
+	if block.Number() == 0 {
+		return nil, nil, ErrTraceGenesisBlock
+	}
+
+	callTracer := &calltracer.CallTracer{RecordErrors: true}
+
+	cancel := cancelOnTimeout(callTracer, defaultTraceTimeout)
+	defer cancel()
+
+	res, err := t.store.TraceTxn(block, tx.Hash(), callTracer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	call, _ := res.(*calltracer.Call)
+
+	return call, block, nil
+}
+
+// flattenCall converts the call tree of the transaction into the list of traces, ordered depth first.
+// The block fields are omitted when the block is not given
+func flattenCall(call *calltracer.Call, block *types.Block, txHash types.Hash, position uint64) []*ParityTrace {
+	traces := make([]*ParityTrace, 0)
+
+	if call == nil {
+		return traces
+	}
+
+	traces = appendCallTraces(traces, call, []int{})
+
+	if block == nil {
+		return traces
+	}
+
+	var (
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+	)
+
+	for _, trace := range traces {
+		trace.BlockHash = &blockHash
+		trace.BlockNumber = &blockNumber
+		trace.TransactionHash = &txHash
+		trace.TransactionPosition = &position
+	}
+
+	return traces
+}
+
+func appendCallTraces(traces []*ParityTrace, call *calltracer.Call, traceAddress []int) []*ParityTrace {
+	trace := &ParityTrace{
+		Error:        call.Error,
+		Subtraces:    len(call.Calls),
+		TraceAddress: traceAddress,
+	}
+
+	switch call.Type {
+	case "CREATE", "CREATE2":
+		trace.Type = parityCreateType
+		trace.Action = &ParityTraceAction{
+			From:  call.From,
+			Gas:   call.Gas,
+			Init:  call.Input,
+			Value: call.Value,
+		}
+
+		if call.Error == "" {
+			trace.Result = &ParityTraceResult{
+				Address: call.To,
+				Code:    call.Output,
+				GasUsed: call.GasUsed,
+			}
+		}
+	default:
+		trace.Type = parityCallType
+		trace.Action = &ParityTraceAction{
+			CallType: strings.ToLower(call.Type),
+			From:     call.From,
+			To:       call.To,
+			Gas:      call.Gas,
+			Input:    call.Input,
+			Value:    call.Value,
+		}
+
+		if call.Error == "" {
+			trace.Result = &ParityTraceResult{
+				GasUsed: call.GasUsed,
+				Output:  call.Output,
+			}
+		}
+	}
+
+	traces = append(traces, trace)
+
+	for i, child := range call.Calls {
+		childAddress := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(childAddress, traceAddress)
+
+		traces = appendCallTraces(traces, child, append(childAddress, i))
+	}
+
+	return traces
+}
@@ -0,0 +1,259 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	traceAddr1 = types.StringToAddress("1")
+	traceAddr2 = types.StringToAddress("2")
+	traceAddr3 = types.StringToAddress("3")
+)
+
+// newTestCallTree returns a call from the sender, creating a contract which makes a failing call
+func newTestCallTree(from types.Address) *calltracer.Call {
+	return &calltracer.Call{
+		Type:    "CALL",
+		From:    from.String(),
+		To:      traceAddr2.String(),
+		Value:   "0x1",
+		Gas:     "0x100",
+		GasUsed: "0x50",
+		Input:   "0x01",
+		Output:  "0x02",
+		Calls: []*calltracer.Call{
+			{
+				Type:    "CREATE",
+				From:    traceAddr2.String(),
+				To:      traceAddr3.String(),
+				Value:   "0x0",
+				Gas:     "0x80",
+				GasUsed: "0x20",
+				Input:   "0x03",
+				Output:  "0x04",
+				Calls: []*calltracer.Call{
+					{
+						Type:    "STATICCALL",
+						From:    traceAddr3.String(),
+						To:      traceAddr1.String(),
+						Value:   "0x0",
+						Gas:     "0x10",
+						GasUsed: "0x10",
+						Input:   "0x05",
+						Output:  "0x",
+						Error:   "out of gas",
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_flattenCall(t *testing.T) {
+	t.Parallel()
+
+	traces := flattenCall(newTestCallTree(traceAddr1), nil, testTxHash1, 0)
+	require.Len(t, traces, 3)
+
+	assert.Equal(t, &ParityTrace{
+		Action: &ParityTraceAction{
+			CallType: "call",
+			From:     traceAddr1.String(),
+			To:       traceAddr2.String(),
+			Gas:      "0x100",
+			Input:    "0x01",
+			Value:    "0x1",
+		},
+		Result:       &ParityTraceResult{GasUsed: "0x50", Output: "0x02"},
+		Subtraces:    1,
+		TraceAddress: []int{},
+		Type:         "call",
+	}, traces[0])
+
+	assert.Equal(t, &ParityTrace{
+		Action: &ParityTraceAction{
+			From:  traceAddr2.String(),
+			Gas:   "0x80",
+			Init:  "0x03",
+			Value: "0x0",
+		},
+		Result:       &ParityTraceResult{Address: traceAddr3.String(), Code: "0x04", GasUsed: "0x20"},
+		Subtraces:    1,
+		TraceAddress: []int{0},
+		Type:         "create",
+	}, traces[1])
+
+	// the failed calls have no result
+	assert.Equal(t, "staticcall", traces[2].Action.CallType)
+	assert.Equal(t, "out of gas", traces[2].Error)
+	assert.Nil(t, traces[2].Result)
+	assert.Equal(t, []int{0, 0}, traces[2].TraceAddress)
+	assert.Nil(t, traces[2].BlockHash)
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	testTxHash2 := types.BytesToHash([]byte{2})
+	blockWithTxs := &types.Block{
+		Header:       testBlock10.Header,
+		Transactions: []*types.Transaction{testTx1, createTestTransaction(testTxHash2)},
+	}
+
+	store := &debugEndpointMockStore{
+		readTxLookupFn: func(hash types.Hash) (uint64, bool) {
+			return testBlock10.Number(), true
+		},
+		getBlockByNumberFn: func(number uint64, full bool) (*types.Block, bool) {
+			require.Equal(t, testBlock10.Number(), number)
+
+			return blockWithTxs, true
+		},
+		traceTxnFn: func(block *types.Block, txHash types.Hash, tr tracer.Tracer) (interface{}, error) {
+			require.Equal(t, testTxHash2, txHash)
+			require.True(t, tr.(*calltracer.CallTracer).RecordErrors)
+
+			return newTestCallTree(traceAddr1), nil
+		},
+	}
+
+	endpoint := NewTrace(store, 0, 100000)
+
+	res, err := endpoint.Transaction(testTxHash2)
+	require.NoError(t, err)
+
+	traces, ok := res.([]*ParityTrace)
+	require.True(t, ok)
+	require.Len(t, traces, 3)
+
+	for _, trace := range traces {
+		assert.Equal(t, testBlock10.Hash(), *trace.BlockHash)
+		assert.Equal(t, testBlock10.Number(), *trace.BlockNumber)
+		assert.Equal(t, testTxHash2, *trace.TransactionHash)
+		assert.Equal(t, uint64(1), *trace.TransactionPosition)
+	}
+
+	res, err = endpoint.ReplayTransaction(testTxHash2, []string{"trace"})
+	require.NoError(t, err)
+
+	replay, ok := res.(*TraceReplayResult)
+	require.True(t, ok)
+	assert.Equal(t, "0x02", replay.Output)
+	require.Len(t, replay.Trace, 3)
+	assert.Nil(t, replay.Trace[0].BlockHash)
+
+	_, err = endpoint.ReplayTransaction(testTxHash2, []string{"trace", "vmTrace"})
+	require.ErrorIs(t, err, ErrTraceTypeNotSupported)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	blocks := make(map[uint64]*types.Block)
+	senders := map[uint64]types.Address{1: traceAddr1, 2: traceAddr2, 3: traceAddr1}
+
+	for num := range senders {
+		blocks[num] = &types.Block{
+			Header:       createTestHeader(num, nil),
+			Transactions: []*types.Transaction{testTx1},
+		}
+	}
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return blocks[3].Header
+		},
+		getBlockByNumberFn: func(number uint64, full bool) (*types.Block, bool) {
+			block, ok := blocks[number]
+
+			return block, ok
+		},
+		traceBlockFn: func(block *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+			return []interface{}{newTestCallTree(senders[block.Number()])}, nil
+		},
+	}
+
+	blockNumber := func(n BlockNumber) *BlockNumber {
+		return &n
+	}
+
+	uint64Ptr := func(n uint64) *uint64 {
+		return &n
+	}
+
+	tests := []struct {
+		name            string
+		blockRangeLimit uint64
+		filter          TraceFilterRequest
+		blocks          []uint64
+		traceAddresses  [][]int
+		err             error
+	}{
+		{
+			name:           "should return the traces from the address",
+			filter:         TraceFilterRequest{FromBlock: blockNumber(1), FromAddress: []types.Address{traceAddr1}},
+			blocks:         []uint64{1, 3},
+			traceAddresses: [][]int{{}, {}},
+		},
+		{
+			name:           "should match the created contracts by the recipient",
+			filter:         TraceFilterRequest{FromBlock: blockNumber(1), ToAddress: []types.Address{traceAddr3}},
+			blocks:         []uint64{1, 2, 3},
+			traceAddresses: [][]int{{0}, {0}, {0}},
+		},
+		{
+			name: "should paginate the traces",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumber(1),
+				ToBlock:   blockNumber(2),
+				After:     uint64Ptr(2),
+				Count:     uint64Ptr(2),
+			},
+			blocks:         []uint64{1, 2},
+			traceAddresses: [][]int{{0, 0}, {}},
+		},
+		{
+			name:            "should fail when the block range is too high",
+			blockRangeLimit: 1,
+			filter:          TraceFilterRequest{FromBlock: blockNumber(1)},
+			err:             ErrBlockRangeTooHigh,
+		},
+		{
+			name:   "should fail when the block range is incorrect",
+			filter: TraceFilterRequest{FromBlock: blockNumber(3), ToBlock: blockNumber(2)},
+			err:    ErrIncorrectBlockRange,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewTrace(store, tt.blockRangeLimit, 100000).Filter(tt.filter)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			traces, ok := res.([]*ParityTrace)
+			require.True(t, ok)
+			require.Len(t, traces, len(tt.blocks))
+
+			for i, trace := range traces {
+				assert.Equal(t, tt.blocks[i], *trace.BlockNumber)
+				assert.Equal(t, tt.traceAddresses[i], trace.TraceAddress)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/valyala/fastjson"
 )
@@ -486,7 +487,7 @@ func (args *txnArgs) setDefaults(priceLimit uint64, eth *Eth) error {
 			Data:      argBytesPtr(data),
 		}
 
-		estimatedGas, err := eth.EstimateGas(&callArgs, nil)
+		estimatedGas, err := eth.EstimateGas(&callArgs, nil, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -627,6 +628,58 @@ type feeHistoryResult struct {
 	Reward        [][]argUint64 `json:"reward,omitempty"`
 }
 
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProofResult struct {
+	Address      types.Address         `json:"address"`
+	AccountProof []argBytes            `json:"accountProof"`
+	Balance      argBig                `json:"balance"`
+	CodeHash     types.Hash            `json:"codeHash"`
+	Nonce        argUint64             `json:"nonce"`
+	StorageHash  types.Hash            `json:"storageHash"`
+	StorageProof []*storageProofResult `json:"storageProof"`
+}
+
+func toAccountProof(address types.Address, proof *state.AccountProof) *accountProofResult {
+	res := &accountProofResult{
+		Address:      address,
+		AccountProof: toArgBytesSlice(proof.Proof),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]*storageProofResult, len(proof.StorageProofs)),
+	}
+
+	if acc := proof.Account; acc != nil {
+		res.Balance = argBig(*acc.Balance)
+		res.CodeHash = types.BytesToHash(acc.CodeHash)
+		res.Nonce = argUint64(acc.Nonce)
+		res.StorageHash = acc.Root
+	}
+
+	for i, storageProof := range proof.StorageProofs {
+		res.StorageProof[i] = &storageProofResult{
+			Key:   storageProof.Key,
+			Value: argBig(*new(big.Int).SetBytes(storageProof.Value.Bytes())),
+			Proof: toArgBytesSlice(storageProof.Proof),
+		}
+	}
+
+	return res
+}
+
+func toArgBytesSlice(slice [][]byte) []argBytes {
+	argSlice := make([]argBytes, len(slice))
+	for i, value := range slice {
+		argSlice[i] = argBytes(value)
+	}
+
+	return argSlice
+}
+
 func convertToArgUint64Slice(slice []uint64) []argUint64 {
 	argSlice := make([]argUint64, len(slice))
 	for i, value := range slice {
@@ -682,6 +735,20 @@ func (o *OverrideAccount) ToType() types.OverrideAccount {
 // StateOverride is the collection of overridden accounts
 type StateOverride map[types.Address]OverrideAccount
 
+// ToType converts the state override to the types.StateOverride, returning nil if there is none
+func (s *StateOverride) ToType() types.StateOverride {
+	if s == nil {
+		return nil
+	}
+
+	override := types.StateOverride{}
+	for addr, o := range *s {
+		override[addr] = o.ToType()
+	}
+
+	return override
+}
+
 // MarshalJSON marshals the StateOverride to JSON
 func (s StateOverride) MarshalJSON() ([]byte, error) {
 	a := defaultArena.Get()
@@ -731,6 +798,52 @@ func (s StateOverride) MarshalJSON() ([]byte, error) {
 	return res, nil
 }
 
+// BlockOverrides overrides the fields of the header of the block the calls are executed in
+type BlockOverrides struct {
+	Number        *argUint64     `json:"number"`
+	Time          *argUint64     `json:"time"`
+	GasLimit      *argUint64     `json:"gasLimit"`
+	FeeRecipient  *types.Address `json:"feeRecipient"`
+	PrevRandao    *types.Hash    `json:"prevRandao"`
+	Difficulty    *argUint64     `json:"difficulty"`
+	BaseFeePerGas *argUint64     `json:"baseFeePerGas"`
+}
+
+// apply overrides the fields of the header
+func (o *BlockOverrides) apply(header *types.Header) {
+	if o == nil {
+		return
+	}
+
+	if o.Number != nil {
+		header.Number = uint64(*o.Number)
+	}
+
+	if o.Time != nil {
+		header.Timestamp = uint64(*o.Time)
+	}
+
+	if o.GasLimit != nil {
+		header.GasLimit = uint64(*o.GasLimit)
+	}
+
+	if o.FeeRecipient != nil {
+		header.Miner = o.FeeRecipient.Bytes()
+	}
+
+	if o.PrevRandao != nil {
+		header.MixHash = *o.PrevRandao
+	}
+
+	if o.Difficulty != nil {
+		header.Difficulty = uint64(*o.Difficulty)
+	}
+
+	if o.BaseFeePerGas != nil {
+		header.BaseFee = uint64(*o.BaseFeePerGas)
+	}
+}
+
 // CallMsg contains parameters for contract calls
 type CallMsg struct {
 	From       types.Address  // the sender of the 'transaction'
@@ -842,6 +955,60 @@ func (f *FeeHistory) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// StorageProof represents the merkle proof of a storage slot returned by an rpc node
+type StorageProof struct {
+	Key   types.Hash
+	Value *big.Int
+	Proof [][]byte
+}
+
+// AccountProof represents the merkle proof of an account and its storage slots returned by an rpc node
+type AccountProof struct {
+	Address      types.Address
+	AccountProof [][]byte
+	Balance      *big.Int
+	CodeHash     types.Hash
+	Nonce        uint64
+	StorageHash  types.Hash
+	StorageProof []*StorageProof
+}
+
+// UnmarshalJSON unmarshals the AccountProof object from JSON
+func (a *AccountProof) UnmarshalJSON(data []byte) error {
+	var raw accountProofResult
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	a.Address = raw.Address
+	a.AccountProof = fromArgBytesSlice(raw.AccountProof)
+	a.Balance = new(big.Int).Set((*big.Int)(&raw.Balance))
+	a.CodeHash = raw.CodeHash
+	a.Nonce = uint64(raw.Nonce)
+	a.StorageHash = raw.StorageHash
+
+	a.StorageProof = make([]*StorageProof, 0, len(raw.StorageProof))
+	for _, p := range raw.StorageProof {
+		a.StorageProof = append(a.StorageProof, &StorageProof{
+			Key:   p.Key,
+			Value: new(big.Int).Set((*big.Int)(&p.Value)),
+			Proof: fromArgBytesSlice(p.Proof),
+		})
+	}
+
+	return nil
+}
+
+func fromArgBytesSlice(slice []argBytes) [][]byte {
+	res := make([][]byte, len(slice))
+	for i, value := range slice {
+		res[i] = []byte(value)
+	}
+
+	return res
+}
+
 // Transaction is the json rpc transaction object
 // (types.Transaction object, expanded with block number, hash and index)
 type Transaction struct {
@@ -11,6 +11,7 @@ import (
 	"text/template"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
@@ -374,3 +375,63 @@ func loadTestData(t *testing.T, name string) string {
 
 	return string(data)
 }
+
+func Test_toAccountProof(t *testing.T) {
+	t.Parallel()
+
+	address := types.StringToAddress("0x1")
+	key := types.StringToHash("0x2")
+
+	t.Run("existing account", func(t *testing.T) {
+		t.Parallel()
+
+		proof := &state.AccountProof{
+			Account: &state.Account{
+				Nonce:    3,
+				Balance:  big.NewInt(100),
+				Root:     types.StringToHash("0x4"),
+				CodeHash: types.StringToHash("0x5").Bytes(),
+			},
+			Proof: [][]byte{{0x1, 0x2}, {0x3}},
+			StorageProofs: []*state.StorageProof{
+				{Key: key, Value: types.StringToHash("0xff"), Proof: [][]byte{{0x6}}},
+			},
+		}
+
+		data, err := json.Marshal(toAccountProof(address, proof))
+		require.NoError(t, err)
+
+		var res AccountProof
+		require.NoError(t, json.Unmarshal(data, &res))
+
+		require.Equal(t, address, res.Address)
+		require.Equal(t, proof.Proof, res.AccountProof)
+		require.Equal(t, big.NewInt(100), res.Balance)
+		require.Equal(t, types.StringToHash("0x5"), res.CodeHash)
+		require.Equal(t, uint64(3), res.Nonce)
+		require.Equal(t, types.StringToHash("0x4"), res.StorageHash)
+		require.Len(t, res.StorageProof, 1)
+		require.Equal(t, key, res.StorageProof[0].Key)
+		require.Equal(t, big.NewInt(0xff), res.StorageProof[0].Value)
+		require.Equal(t, [][]byte{{0x6}}, res.StorageProof[0].Proof)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		t.Parallel()
+
+		proof := &state.AccountProof{
+			Proof: [][]byte{{0x1}},
+			StorageProofs: []*state.StorageProof{
+				{Key: key, Proof: [][]byte{}},
+			},
+		}
+
+		res := toAccountProof(address, proof)
+
+		require.Equal(t, types.EmptyCodeHash, res.CodeHash)
+		require.Equal(t, types.EmptyRootHash, res.StorageHash)
+		require.Equal(t, 0, (*big.Int)(&res.Balance).Sign())
+		require.Equal(t, argUint64(0), res.Nonce)
+		require.Empty(t, res.StorageProof[0].Proof)
+	})
+}
@@ -25,7 +25,7 @@ func TestWeb3EndpointSha3(t *testing.T) {
 	resp, err := dispatcher.Handle([]byte(`{
 		"method": "web3_sha3",
 		"params": ["0x68656c6c6f20776f726c64"]
-	}`))
+	}`), "")
 	assert.NoError(t, err)
 
 	var res string
@@ -55,7 +55,7 @@ func TestWeb3EndpointClientVersion(t *testing.T) {
 	resp, err := dispatcher.Handle([]byte(`{
 		"method": "web3_clientVersion",
 		"params": []
-	}`))
+	}`), "")
 	assert.NoError(t, err)
 
 	var res string
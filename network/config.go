@@ -22,6 +22,9 @@ type Config struct {
 	Chain             *chain.Chain           // the reference to the chain configuration
 	SecretsManager    secrets.SecretsManager // the secrets manager used for key storage
 	GossipMessageSize int                    // the maximum size of a gossip message
+	StaticPeers       []string               // the addresses of the peers which are always kept connected
+	TrustedPeers      []string               // the IDs of the peers which bypass the connection slot limits
+	AllowedPeers      []string               // the IDs of the only peers allowed to connect, any peer if empty
 }
 
 func DefaultConfig() *Config {
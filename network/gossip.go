@@ -22,14 +22,22 @@ const (
 	subscribeOutputBufferSize = 1024
 )
 
+// MessageValidator checks the content of a decoded gossip message, returning an error if it's invalid
+type MessageValidator func(obj interface{}) error
+
 type Topic struct {
 	logger hclog.Logger
 
+	ps        *pubsub.PubSub
 	topic     *pubsub.Topic
 	typ       reflect.Type
 	closeCh   chan struct{}
 	closed    atomic.Bool
 	waitGroup sync.WaitGroup
+
+	reportPeer func(peer.ID, int64, string) // reports the peers sending malformed messages
+
+	validator atomic.Pointer[MessageValidator] // checks the content of the decoded messages, if set
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -52,6 +60,10 @@ func (t *Topic) Close() {
 
 	// if all subscribers are finished, close the topic
 	if t.topic != nil {
+		if t.ps != nil {
+			_ = t.ps.UnregisterTopicValidator(t.topic.String())
+		}
+
 		t.topic.Close()
 		t.topic = nil
 	}
@@ -68,6 +80,12 @@ func (t *Topic) Publish(obj proto.Message) error {
 	return t.topic.Publish(context.Background(), data)
 }
 
+// SetValidator sets the validator of the content of the messages. The invalid messages are rejected
+// before they are delivered or relayed, and the peer which sent them is reported
+func (t *Topic) SetValidator(validator MessageValidator) {
+	t.validator.Store(&validator)
+}
+
 func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 	sub, err := t.topic.Subscribe(pubsub.WithBufferSize(subscribeOutputBufferSize))
 	if err != nil {
@@ -110,11 +128,9 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 		go func() {
 			t.logger.Debug("gossip message", "size", common.ToMB(msg.Data))
 
-			obj := t.createObj()
-			if err := proto.Unmarshal(msg.Data, obj); err != nil {
-				t.logger.Error("failed to unmarshal topic", "err", err)
-				metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
-
+			// the message is decoded by the validator
+			obj, ok := msg.ValidatorData.(proto.Message)
+			if !ok {
 				return
 			}
 
@@ -125,6 +141,43 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 	}
 }
 
+// validate decodes and validates the message before it's delivered or relayed. The malformed
+// and the invalid messages are rejected, so that they are never relayed, and the peer which sent them
+// is reported, since an honest peer validates the messages the same way before relaying them
+func (t *Topic) validate(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	obj := t.createObj()
+	if err := proto.Unmarshal(msg.Data, obj); err != nil {
+		t.logger.Error("failed to unmarshal topic", "err", err)
+		metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
+
+		t.penalizePeer(from, PenaltyMalformedMessage, "malformed gossip message")
+
+		return pubsub.ValidationReject
+	}
+
+	if validator := t.validator.Load(); validator != nil {
+		if err := (*validator)(obj); err != nil {
+			t.logger.Debug("invalid gossip message", "peer", from, "err", err)
+			metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
+
+			t.penalizePeer(from, PenaltyInvalidMessage, err.Error())
+
+			return pubsub.ValidationReject
+		}
+	}
+
+	msg.ValidatorData = obj
+
+	return pubsub.ValidationAccept
+}
+
+// penalizePeer reports the peer which sent an invalid message
+func (t *Topic) penalizePeer(from peer.ID, penalty int64, reason string) {
+	if t.reportPeer != nil {
+		t.reportPeer(from, penalty, reason)
+	}
+}
+
 func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	topic, err := s.ps.Join(protoID)
 	if err != nil {
@@ -132,12 +185,20 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	}
 
 	tt := &Topic{
-		logger:  s.logger.Named(protoID),
-		topic:   topic,
-		typ:     reflect.TypeOf(obj).Elem(),
-		closeCh: make(chan struct{}),
+		logger:     s.logger.Named(protoID),
+		ps:         s.ps,
+		topic:      topic,
+		typ:        reflect.TypeOf(obj).Elem(),
+		closeCh:    make(chan struct{}),
+		reportPeer: s.ReportPeer,
 	}
 	tt.closed.Store(false)
 
+	if err := s.ps.RegisterTopicValidator(protoID, tt.validate); err != nil {
+		_ = topic.Close()
+
+		return nil, err
+	}
+
 	return tt, nil
 }
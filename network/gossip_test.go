@@ -114,3 +114,107 @@ func Test_RepeatedClose(t *testing.T) {
 	topic.Close()
 	topic.Close()
 }
+
+func TestGossip_MalformedMessage(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr, "Unable to create servers")
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	joinErrors := MeshJoin(servers...)
+	require.Empty(t, joinErrors, "Unable to join servers [%d], %v", len(joinErrors), joinErrors)
+
+	topicName := "msg-pub-sub"
+	receivedCh := make(chan struct{}, 1)
+
+	topic, err := servers[1].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+
+	require.NoError(t, topic.Subscribe(func(obj interface{}, _ peer.ID) {
+		receivedCh <- struct{}{}
+	}))
+
+	// the sender joins the topic without validating the messages
+	senderTopic, err := servers[0].ps.Join(topicName)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, WaitForSubscribers(ctx, servers[0], topicName, 1))
+
+	time.Sleep(500 * time.Millisecond)
+
+	require.NoError(t, senderTopic.Publish(ctx, []byte{0xff, 0xff, 0xff}))
+
+	// the sender is reported, while the message is not delivered
+	require.Eventually(t, func() bool {
+		return servers[1].PeerScore(servers[0].host.ID()) == -PenaltyMalformedMessage
+	}, 5*time.Second, 50*time.Millisecond)
+
+	select {
+	case <-receivedCh:
+		t.Fatal("malformed message delivered")
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestGossip_InvalidMessage(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr, "Unable to create servers")
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	joinErrors := MeshJoin(servers...)
+	require.Empty(t, joinErrors, "Unable to join servers [%d], %v", len(joinErrors), joinErrors)
+
+	topicName := "msg-pub-sub"
+	receivedCh := make(chan string, 2)
+
+	topic, err := servers[1].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+
+	topic.SetValidator(func(obj interface{}) error {
+		if msg, ok := obj.(*testproto.GenericMessage); !ok || msg.Message == "invalid" {
+			return errors.New("invalid message")
+		}
+
+		return nil
+	})
+
+	require.NoError(t, topic.Subscribe(func(obj interface{}, _ peer.ID) {
+		msg, _ := obj.(*testproto.GenericMessage)
+		receivedCh <- msg.Message
+	}))
+
+	// the sender doesn't validate the messages it publishes
+	senderTopic, err := servers[0].NewTopic(topicName, &testproto.GenericMessage{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, WaitForSubscribers(ctx, servers[0], topicName, 1))
+
+	time.Sleep(500 * time.Millisecond)
+
+	require.NoError(t, senderTopic.Publish(&testproto.GenericMessage{Message: "invalid"}))
+
+	// the sender is reported, while the message is not delivered
+	require.Eventually(t, func() bool {
+		return servers[1].PeerScore(servers[0].host.ID()) == -PenaltyInvalidMessage
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, senderTopic.Publish(&testproto.GenericMessage{Message: "valid"}))
+
+	select {
+	case msg := <-receivedCh:
+		require.Equal(t, "valid", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("valid message not delivered")
+	}
+}
@@ -19,6 +19,7 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrPeerNotAllowed   = errors.New("peer is not allowed")
 )
 
 // networkingServer defines the base communication interface between
@@ -52,6 +53,14 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// PEER PERMISSIONS //
+
+	// IsPeerAllowed checks if the peer is allowed to connect [Thread safe]
+	IsPeerAllowed(peerID peer.ID) bool
+
+	// IsTrustedPeer checks if the peer is not subject to the connection slot limits [Thread safe]
+	IsTrustedPeer(peerID peer.ID) bool
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -93,7 +102,13 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if !i.baseServer.IsPeerAllowed(peerID) {
+				i.disconnectFromPeer(peerID, ErrPeerNotAllowed.Error())
+
+				return
+			}
+
+			if !i.baseServer.IsTrustedPeer(peerID) && !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
@@ -200,6 +215,10 @@ func (i *IdentityService) Hello(_ context.Context, req *proto.Status) (*proto.St
 		return nil, err
 	}
 
+	if !i.baseServer.IsPeerAllowed(peerID) {
+		return nil, ErrPeerNotAllowed
+	}
+
 	return i.constructStatus(peerID), nil
 }
 
@@ -7,9 +7,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
 
@@ -122,3 +124,37 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// TestHello_PeerNotAllowed tests that the handshake is refused to the peers not allowed to connect
+func TestHello_PeerNotAllowed(t *testing.T) {
+	key, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	require.NoError(t, err)
+
+	peerID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	allowed := false
+
+	// Create an instance of the identity service
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookIsPeerAllowed(func(id peer.ID) bool {
+				assert.Equal(t, peerID, id)
+
+				return allowed
+			})
+		},
+	)
+
+	status := &proto.Status{
+		Metadata: map[string]string{PeerID: peerID.String()},
+	}
+
+	_, err = identityService.Hello(context.Background(), status)
+	assert.ErrorIs(t, err, ErrPeerNotAllowed)
+
+	allowed = true
+
+	_, err = identityService.Hello(context.Background(), status)
+	assert.NoError(t, err)
+}
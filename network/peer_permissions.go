@@ -0,0 +1,250 @@
+package network
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/identity"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// staticPeersDialInterval is the interval at which the disconnected static peers are redialed
+const staticPeersDialInterval = 10 * time.Second
+
+// peerPermissions holds the lists of the static, trusted and allowed peers:
+//   - the static peers are always kept connected, they are redialed whenever disconnected
+//   - the trusted peers (and the static ones) are not subject to the connection slot limits
+//   - if the allowlist is not empty, only the allowed peers (and the static and trusted ones) can connect
+type peerPermissions struct {
+	lock sync.RWMutex
+
+	static  map[peer.ID]*peer.AddrInfo
+	trusted map[peer.ID]struct{}
+	allowed map[peer.ID]struct{}
+
+	// the lists as configured, returned back when queried
+	rawStatic  []string
+	rawTrusted []string
+	rawAllowed []string
+}
+
+// newPeerPermissions parses the given lists of static peer addresses, trusted and allowed peer IDs
+func newPeerPermissions(static, trusted, allowed []string) (*peerPermissions, error) {
+	p := &peerPermissions{
+		static:     make(map[peer.ID]*peer.AddrInfo, len(static)),
+		rawStatic:  append([]string{}, static...),
+		rawTrusted: append([]string{}, trusted...),
+		rawAllowed: append([]string{}, allowed...),
+	}
+
+	for _, rawAddr := range static {
+		addrInfo, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse static peer %s: %w", rawAddr, err)
+		}
+
+		p.static[addrInfo.ID] = addrInfo
+	}
+
+	var err error
+
+	if p.trusted, err = parsePeerIDs(trusted); err != nil {
+		return nil, fmt.Errorf("failed to parse trusted peers: %w", err)
+	}
+
+	if p.allowed, err = parsePeerIDs(allowed); err != nil {
+		return nil, fmt.Errorf("failed to parse allowed peers: %w", err)
+	}
+
+	return p, nil
+}
+
+// parsePeerIDs decodes the given list of peer IDs into a set
+func parsePeerIDs(rawIDs []string) (map[peer.ID]struct{}, error) {
+	ids := make(map[peer.ID]struct{}, len(rawIDs))
+
+	for _, rawID := range rawIDs {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer id %s: %w", rawID, err)
+		}
+
+		ids[id] = struct{}{}
+	}
+
+	return ids, nil
+}
+
+// set replaces the lists with the ones of the given permissions
+func (p *peerPermissions) set(other *peerPermissions) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.static, p.trusted, p.allowed = other.static, other.trusted, other.allowed
+	p.rawStatic, p.rawTrusted, p.rawAllowed = other.rawStatic, other.rawTrusted, other.rawAllowed
+}
+
+// lists returns the configured static, trusted and allowed peers
+func (p *peerPermissions) lists() (static, trusted, allowed []string) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return append([]string{}, p.rawStatic...),
+		append([]string{}, p.rawTrusted...),
+		append([]string{}, p.rawAllowed...)
+}
+
+// staticPeers returns the address info of the static peers
+func (p *peerPermissions) staticPeers() []*peer.AddrInfo {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	peers := make([]*peer.AddrInfo, 0, len(p.static))
+	for _, addrInfo := range p.static {
+		peers = append(peers, addrInfo)
+	}
+
+	return peers
+}
+
+// isTrusted checks if the peer is trusted, static peers are trusted as well
+func (p *peerPermissions) isTrusted(peerID peer.ID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.isTrustedLocked(peerID)
+}
+
+// isTrustedLocked checks if the peer is trusted, it must be called with the lock held
+func (p *peerPermissions) isTrustedLocked(peerID peer.ID) bool {
+	if _, ok := p.static[peerID]; ok {
+		return true
+	}
+
+	_, ok := p.trusted[peerID]
+
+	return ok
+}
+
+// isAllowed checks if the peer is allowed to connect
+func (p *peerPermissions) isAllowed(peerID peer.ID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if len(p.allowed) == 0 || p.isTrustedLocked(peerID) {
+		return true
+	}
+
+	_, ok := p.allowed[peerID]
+
+	return ok
+}
+
+// connectionGater is the connection gater of the libp2p host. It rejects the connections to and from
+// the peers not allowed and the banned ones, unless they are trusted
+type connectionGater struct {
+	reputation  *peerReputation
+	permissions *peerPermissions
+}
+
+// isAccepted checks if the connections to and from the peer are accepted
+func (g *connectionGater) isAccepted(peerID peer.ID) bool {
+	if g.permissions.isTrusted(peerID) {
+		return true
+	}
+
+	return g.permissions.isAllowed(peerID) && !g.reputation.isBanned(peerID)
+}
+
+// InterceptPeerDial rejects dialing the peers not accepted
+func (g *connectionGater) InterceptPeerDial(peerID peer.ID) bool {
+	return g.isAccepted(peerID)
+}
+
+// InterceptAddrDial allows dialing any address of the accepted peers
+func (g *connectionGater) InterceptAddrDial(peerID peer.ID, _ multiaddr.Multiaddr) bool {
+	return g.isAccepted(peerID)
+}
+
+// InterceptAccept allows all the inbound connections, as the peer is not known until they are secured
+func (g *connectionGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured rejects the connections to and from the peers not accepted
+func (g *connectionGater) InterceptSecured(_ network.Direction, peerID peer.ID, _ network.ConnMultiaddrs) bool {
+	return g.isAccepted(peerID)
+}
+
+// InterceptUpgraded allows all the upgraded connections, as the peers are rejected before the upgrade
+func (g *connectionGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// IsPeerAllowed checks if the peer is allowed to connect to the node [Thread safe]
+func (s *Server) IsPeerAllowed(peerID peer.ID) bool {
+	return s.permissions.isAllowed(peerID)
+}
+
+// IsTrustedPeer checks if the peer is not subject to the connection slot limits [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.permissions.isTrusted(peerID)
+}
+
+// PeerPermissions returns the static peer addresses, the trusted and the allowed peer IDs
+func (s *Server) PeerPermissions() (static, trusted, allowed []string) {
+	return s.permissions.lists()
+}
+
+// SetPeerPermissions replaces the static peer addresses, the trusted and the allowed peer IDs.
+// The connected peers which are no longer allowed are disconnected, and the new static peers are dialed
+func (s *Server) SetPeerPermissions(static, trusted, allowed []string) error {
+	permissions, err := newPeerPermissions(static, trusted, allowed)
+	if err != nil {
+		return err
+	}
+
+	s.permissions.set(permissions)
+
+	s.logger.Info("Peer permissions updated",
+		"static", len(static), "trusted", len(trusted), "allowed", len(allowed))
+
+	for _, p := range s.Peers() {
+		if !s.IsPeerAllowed(p.Info.ID) {
+			s.DisconnectFromPeer(p.Info.ID, identity.ErrPeerNotAllowed.Error())
+		}
+	}
+
+	s.dialStaticPeers()
+
+	return nil
+}
+
+// dialStaticPeers adds the disconnected static peers to the dial queue
+func (s *Server) dialStaticPeers() {
+	for _, addrInfo := range s.permissions.staticPeers() {
+		if addrInfo.ID == s.host.ID() || s.IsConnected(addrInfo.ID) {
+			continue
+		}
+
+		s.addToDialQueue(addrInfo, common.PriorityRequestedDial)
+	}
+}
+
+// keepStaticPeersConnected periodically redials the disconnected static peers
+func (s *Server) keepStaticPeersConnected() {
+	for {
+		s.dialStaticPeers()
+
+		select {
+		case <-time.After(staticPeersDialInterval):
+		case <-s.closeCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerPermissions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		staticPeer  = newTestPeerID(t)
+		trustedPeer = newTestPeerID(t)
+		allowedPeer = newTestPeerID(t)
+		otherPeer   = newTestPeerID(t)
+		staticAddr  = fmt.Sprintf("/ip4/127.0.0.1/tcp/1478/p2p/%s", staticPeer)
+	)
+
+	// any peer is allowed without an allowlist
+	permissions, err := newPeerPermissions(nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.True(t, permissions.isAllowed(otherPeer))
+	assert.False(t, permissions.isTrusted(otherPeer))
+	assert.Empty(t, permissions.staticPeers())
+
+	permissions, err = newPeerPermissions(
+		[]string{staticAddr},
+		[]string{trustedPeer.String()},
+		[]string{allowedPeer.String()},
+	)
+	require.NoError(t, err)
+
+	// static and trusted peers are implicitly allowed
+	assert.True(t, permissions.isAllowed(staticPeer))
+	assert.True(t, permissions.isAllowed(trustedPeer))
+	assert.True(t, permissions.isAllowed(allowedPeer))
+	assert.False(t, permissions.isAllowed(otherPeer))
+
+	// static peers are trusted as well
+	assert.True(t, permissions.isTrusted(staticPeer))
+	assert.True(t, permissions.isTrusted(trustedPeer))
+	assert.False(t, permissions.isTrusted(allowedPeer))
+
+	require.Len(t, permissions.staticPeers(), 1)
+	assert.Equal(t, staticPeer, permissions.staticPeers()[0].ID)
+
+	static, trusted, allowed := permissions.lists()
+	assert.Equal(t, []string{staticAddr}, static)
+	assert.Equal(t, []string{trustedPeer.String()}, trusted)
+	assert.Equal(t, []string{allowedPeer.String()}, allowed)
+
+	// invalid lists are rejected
+	_, err = newPeerPermissions([]string{"/ip4/127.0.0.1/tcp/1478"}, nil, nil)
+	require.ErrorContains(t, err, "failed to parse static peer")
+
+	_, err = newPeerPermissions(nil, []string{"invalid"}, nil)
+	require.ErrorContains(t, err, "failed to parse trusted peers")
+
+	_, err = newPeerPermissions(nil, nil, []string{"invalid"})
+	require.ErrorContains(t, err, "failed to parse allowed peers")
+}
+
+func TestConnectionGater(t *testing.T) {
+	t.Parallel()
+
+	var (
+		trustedPeer = newTestPeerID(t)
+		allowedPeer = newTestPeerID(t)
+		otherPeer   = newTestPeerID(t)
+	)
+
+	reputation, err := newPeerReputation(hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	permissions, err := newPeerPermissions(nil, nil, nil)
+	require.NoError(t, err)
+
+	gater := &connectionGater{reputation: reputation, permissions: permissions}
+
+	// any peer not banned is accepted without an allowlist
+	assert.True(t, gater.InterceptPeerDial(otherPeer))
+	assert.True(t, gater.InterceptAddrDial(otherPeer, nil))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, otherPeer, nil))
+
+	permissions, err = newPeerPermissions(nil, []string{trustedPeer.String()}, []string{allowedPeer.String()})
+	require.NoError(t, err)
+
+	gater.permissions.set(permissions)
+
+	// the peers not allowed are rejected
+	assert.True(t, gater.InterceptPeerDial(allowedPeer))
+	assert.True(t, gater.InterceptSecured(network.DirOutbound, allowedPeer, nil))
+	assert.False(t, gater.InterceptPeerDial(otherPeer))
+	assert.False(t, gater.InterceptAddrDial(otherPeer, nil))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, otherPeer, nil))
+
+	// the banned peers are rejected, unless they are trusted
+	require.True(t, reputation.penalize(allowedPeer, -banScoreThreshold+1))
+	require.True(t, reputation.penalize(trustedPeer, -banScoreThreshold+1))
+
+	assert.False(t, gater.InterceptPeerDial(allowedPeer))
+	assert.False(t, gater.InterceptAddrDial(allowedPeer, nil))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, allowedPeer, nil))
+
+	assert.True(t, gater.InterceptPeerDial(trustedPeer))
+	assert.True(t, gater.InterceptAddrDial(trustedPeer, nil))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, trustedPeer, nil))
+}
+
+func TestSetPeerPermissions(t *testing.T) {
+	servers, createErr := createServers(3, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	var (
+		peerID1 = servers[1].AddrInfo().ID
+		peerID2 = servers[2].AddrInfo().ID
+	)
+
+	// only the second peer is allowed
+	require.NoError(t, servers[0].SetPeerPermissions(nil, nil, []string{peerID2.String()}))
+
+	// the handshake with a peer not allowed is refused
+	require.Error(t, JoinAndWait(servers[1], servers[0], 0, 5*time.Second))
+	assert.False(t, servers[0].hasPeer(peerID1))
+
+	require.NoError(t, JoinAndWait(servers[0], servers[2], DefaultBufferTimeout, DefaultJoinTimeout))
+
+	// the allowed peer is disconnected once it's no longer allowed,
+	// while the first peer, now static, is dialed
+	staticAddr := fmt.Sprintf("%s/p2p/%s", servers[1].AddrInfo().Addrs[0], peerID1)
+	require.NoError(t, servers[0].SetPeerPermissions([]string{staticAddr}, nil, []string{peerID1.String()}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	disconnected, err := WaitUntilPeerDisconnectsFrom(ctx, servers[0], peerID2)
+	require.NoError(t, err)
+	assert.True(t, disconnected)
+
+	connected, err := WaitUntilPeerConnectsTo(ctx, servers[0], peerID1)
+	require.NoError(t, err)
+	assert.True(t, connected)
+
+	static, trusted, allowed := servers[0].PeerPermissions()
+	assert.Equal(t, []string{staticAddr}, static)
+	assert.Empty(t, trusted)
+	assert.Equal(t, []string{peerID1.String()}, allowed)
+
+	// invalid lists leave the permissions untouched
+	require.Error(t, servers[0].SetPeerPermissions(nil, []string{"invalid"}, nil))
+	assert.True(t, servers[0].IsTrustedPeer(peerID1))
+
+	// the trusted peers are never banned
+	servers[0].ReportPeer(peerID1, -banScoreThreshold+1, "invalid block")
+
+	_, banned := servers[0].IsBanned(peerID1)
+	assert.False(t, banned)
+	assert.True(t, servers[0].IsConnected(peerID1))
+}
@@ -0,0 +1,277 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Penalties reported by the protocols for the misbehaviour of a peer
+const (
+	// PenaltyMalformedMessage is the penalty for a message which can't be decoded
+	PenaltyMalformedMessage int64 = 20
+	// PenaltyInvalidMessage is the penalty for a message which is decoded, but is not valid
+	PenaltyInvalidMessage int64 = 10
+	// PenaltyInvalidBlock is the penalty for a block which fails the verification
+	PenaltyInvalidBlock int64 = 50
+)
+
+const (
+	// banScoreThreshold is the score below which a peer is disconnected and banned
+	banScoreThreshold int64 = -100
+
+	// scoreRecoveryInterval is the time it takes for a penalized peer to recover one point of its score
+	scoreRecoveryInterval = 30 * time.Second
+
+	// DefaultBanDuration is the time a peer stays banned
+	DefaultBanDuration = time.Hour
+
+	// bannedPeersFile is the name of the file, in the networking data directory, persisting the ban list
+	bannedPeersFile = "banned_peers.json"
+)
+
+// peerScore is the reputation of a peer, recovering towards zero over time
+type peerScore struct {
+	score      int64
+	lastUpdate time.Time
+}
+
+// current returns the score of the peer, after recovering the points earned until now
+func (s *peerScore) current(now time.Time) int64 {
+	recovered := int64(now.Sub(s.lastUpdate) / scoreRecoveryInterval)
+	if recovered >= -s.score {
+		return 0
+	}
+
+	return s.score + recovered
+}
+
+// peerReputation keeps the score of the peers based on the misbehaviour reported by the protocols,
+// and the list of peers banned because of a too low score
+type peerReputation struct {
+	logger hclog.Logger
+
+	// path of the file persisting the ban list (empty if the ban list is not persisted)
+	path string
+
+	lock   sync.Mutex
+	scores map[peer.ID]*peerScore
+	banned map[peer.ID]time.Time // peer -> ban expiration
+
+	now func() time.Time
+}
+
+// newPeerReputation creates a new peer reputation, loading the ban list from the given data directory
+func newPeerReputation(logger hclog.Logger, dataDir string) (*peerReputation, error) {
+	r := &peerReputation{
+		logger: logger,
+		scores: make(map[peer.ID]*peerScore),
+		banned: make(map[peer.ID]time.Time),
+		now:    time.Now,
+	}
+
+	if dataDir == "" {
+		return r, nil
+	}
+
+	r.path = filepath.Join(dataDir, bannedPeersFile)
+
+	if err := r.load(); err != nil {
+		return nil, fmt.Errorf("failed to load banned peers: %w", err)
+	}
+
+	return r, nil
+}
+
+// penalize lowers the score of the peer by the given penalty.
+// Returns true if the peer got banned because of it
+func (r *peerReputation) penalize(peerID peer.ID, penalty int64) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+
+	if expiration, ok := r.banned[peerID]; ok && now.Before(expiration) {
+		return false
+	}
+
+	score, ok := r.scores[peerID]
+	if !ok {
+		score = &peerScore{}
+		r.scores[peerID] = score
+	}
+
+	score.score = score.current(now) - penalty
+	score.lastUpdate = now
+
+	if score.score >= banScoreThreshold {
+		return false
+	}
+
+	delete(r.scores, peerID)
+	r.banned[peerID] = now.Add(DefaultBanDuration)
+
+	if err := r.persist(); err != nil {
+		r.logger.Error("failed to persist banned peers", "err", err)
+	}
+
+	return true
+}
+
+// score returns the current score of the peer
+func (r *peerReputation) score(peerID peer.ID) int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	score, ok := r.scores[peerID]
+	if !ok {
+		return 0
+	}
+
+	return score.current(r.now())
+}
+
+// banExpiration returns the time the ban of the peer expires, if the peer is banned
+func (r *peerReputation) banExpiration(peerID peer.ID) (time.Time, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	expiration, ok := r.banned[peerID]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if !r.now().Before(expiration) {
+		delete(r.banned, peerID)
+
+		return time.Time{}, false
+	}
+
+	return expiration, true
+}
+
+// bannedPeers returns the currently banned peers, with the expiration of their bans
+func (r *peerReputation) bannedPeers() map[peer.ID]time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	result := make(map[peer.ID]time.Time, len(r.banned))
+
+	for peerID, expiration := range r.banned {
+		if !now.Before(expiration) {
+			delete(r.banned, peerID)
+
+			continue
+		}
+
+		result[peerID] = expiration
+	}
+
+	return result
+}
+
+// load reads the ban list from its file, skipping the bans which already expired
+func (r *peerReputation) load() error {
+	raw, err := os.ReadFile(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var bans map[string]int64
+	if err := json.Unmarshal(raw, &bans); err != nil {
+		return err
+	}
+
+	now := r.now()
+
+	for rawID, expirationUnix := range bans {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid peer id %s: %w", rawID, err)
+		}
+
+		if expiration := time.Unix(expirationUnix, 0); now.Before(expiration) {
+			r.banned[peerID] = expiration
+		}
+	}
+
+	return nil
+}
+
+// persist writes the ban list to its file. It must be called with the lock held
+func (r *peerReputation) persist() error {
+	if r.path == "" {
+		return nil
+	}
+
+	bans := make(map[string]int64, len(r.banned))
+	for peerID, expiration := range r.banned {
+		bans[peerID.String()] = expiration.Unix()
+	}
+
+	raw, err := json.Marshal(bans)
+	if err != nil {
+		return err
+	}
+
+	return common.SaveFileSafe(r.path, raw, 0660)
+}
+
+// isBanned checks if the peer is currently banned
+func (r *peerReputation) isBanned(peerID peer.ID) bool {
+	_, banned := r.banExpiration(peerID)
+
+	return banned
+}
+
+// ReportPeer lowers the score of the peer because of the given misbehaviour.
+// The peer is disconnected and banned for DefaultBanDuration if its score falls below the threshold,
+// unless the peer is trusted
+func (s *Server) ReportPeer(peerID peer.ID, penalty int64, reason string) {
+	if peerID == "" || peerID == s.host.ID() {
+		return
+	}
+
+	s.logger.Debug("peer reported", "id", peerID, "penalty", penalty, "reason", reason)
+
+	// trusted peers are never banned
+	if s.IsTrustedPeer(peerID) {
+		return
+	}
+
+	if !s.reputation.penalize(peerID, penalty) {
+		return
+	}
+
+	s.logger.Warn("Banning peer", "id", peerID, "duration", DefaultBanDuration, "reason", reason)
+
+	s.DisconnectFromPeer(peerID, fmt.Sprintf("banned: %s", reason))
+	s.updateBannedPeersMetrics()
+}
+
+// PeerScore returns the current reputation score of the peer
+func (s *Server) PeerScore(peerID peer.ID) int64 {
+	return s.reputation.score(peerID)
+}
+
+// IsBanned checks if the peer is currently banned, returning the time its ban expires
+func (s *Server) IsBanned(peerID peer.ID) (time.Time, bool) {
+	return s.reputation.banExpiration(peerID)
+}
+
+// BannedPeers returns the currently banned peers, with the time their bans expire
+func (s *Server) BannedPeers() map[peer.ID]time.Time {
+	return s.reputation.bannedPeers()
+}
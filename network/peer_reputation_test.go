@@ -0,0 +1,162 @@
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerReputation_Penalize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Unix(1_700_000_000, 0)
+		peerID     = peer.ID("A")
+		reputation = &peerReputation{
+			logger: hclog.NewNullLogger(),
+			scores: make(map[peer.ID]*peerScore),
+			banned: make(map[peer.ID]time.Time),
+			now:    func() time.Time { return now },
+		}
+	)
+
+	// penalties up to the threshold don't ban the peer
+	assert.False(t, reputation.penalize(peerID, PenaltyInvalidBlock))
+	assert.False(t, reputation.penalize(peerID, PenaltyInvalidBlock))
+	assert.Equal(t, banScoreThreshold, reputation.score(peerID))
+
+	// the score recovers over time
+	now = now.Add(10 * scoreRecoveryInterval)
+	assert.Equal(t, banScoreThreshold+10, reputation.score(peerID))
+
+	assert.False(t, reputation.penalize(peerID, PenaltyInvalidMessage))
+	assert.True(t, reputation.penalize(peerID, PenaltyMalformedMessage))
+	assert.True(t, reputation.isBanned(peerID))
+
+	// a banned peer isn't banned again
+	assert.False(t, reputation.penalize(peerID, PenaltyInvalidBlock))
+
+	expiration, banned := reputation.banExpiration(peerID)
+	require.True(t, banned)
+	assert.Equal(t, now.Add(DefaultBanDuration), expiration)
+	assert.Equal(t, map[peer.ID]time.Time{peerID: expiration}, reputation.bannedPeers())
+
+	// the ban expires with a clean score
+	now = expiration
+	assert.False(t, reputation.isBanned(peerID))
+	assert.Empty(t, reputation.bannedPeers())
+	assert.Equal(t, int64(0), reputation.score(peerID))
+
+	// the score doesn't recover beyond zero
+	assert.False(t, reputation.penalize(peerID, PenaltyInvalidMessage))
+
+	now = now.Add(100 * scoreRecoveryInterval)
+	assert.Equal(t, int64(0), reputation.score(peerID))
+}
+
+func TestPeerReputation_Persistence(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dataDir = t.TempDir()
+		// the ban list is loaded against the current time, rounded to the precision it is persisted with
+		now        = time.Unix(time.Now().Unix(), 0)
+		bannedPeer = newTestPeerID(t)
+		otherPeer  = newTestPeerID(t)
+	)
+
+	reputation, err := newPeerReputation(hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+
+	reputation.now = func() time.Time { return now }
+
+	assert.True(t, reputation.penalize(bannedPeer, -banScoreThreshold+1))
+	assert.False(t, reputation.penalize(otherPeer, PenaltyInvalidMessage))
+
+	// the ban list survives a restart, the scores don't
+	restarted, err := newPeerReputation(hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+
+	restarted.now = func() time.Time { return now }
+
+	assert.Equal(t, map[peer.ID]time.Time{bannedPeer: now.Add(DefaultBanDuration)}, restarted.bannedPeers())
+	assert.Equal(t, int64(0), restarted.score(otherPeer))
+
+	// the expired bans are dropped when loading the ban list
+	restarted.now = func() time.Time { return now.Add(DefaultBanDuration) }
+	restarted.banned = make(map[peer.ID]time.Time)
+
+	require.NoError(t, restarted.load())
+	assert.Empty(t, restarted.banned)
+
+	// the ban list is not persisted without a data directory
+	reputation, err = newPeerReputation(hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+	assert.True(t, reputation.penalize(bannedPeer, -banScoreThreshold+1))
+
+	// an invalid ban list fails the loading
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, bannedPeersFile), []byte("{"), 0660))
+
+	_, err = newPeerReputation(hclog.NewNullLogger(), dataDir)
+	require.Error(t, err)
+}
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	key, _, err := GenerateAndEncodeLibp2pKey()
+	require.NoError(t, err)
+
+	peerID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	return peerID
+}
+
+func TestReportPeer_BansAndDisconnects(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	peerID := servers[1].AddrInfo().ID
+
+	// reporting itself is ignored
+	servers[0].ReportPeer(servers[0].AddrInfo().ID, -banScoreThreshold+1, "self")
+	assert.Empty(t, servers[0].BannedPeers())
+
+	servers[0].ReportPeer(peerID, PenaltyInvalidBlock, "invalid block")
+	assert.Equal(t, -PenaltyInvalidBlock, servers[0].PeerScore(peerID))
+	assert.True(t, servers[0].IsConnected(peerID))
+
+	servers[0].ReportPeer(peerID, -banScoreThreshold, "invalid block")
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	disconnected, err := WaitUntilPeerDisconnectsFrom(ctx, servers[0], peerID)
+	require.NoError(t, err)
+	assert.True(t, disconnected)
+
+	_, banned := servers[0].IsBanned(peerID)
+	assert.True(t, banned)
+
+	// the banned peer can't be dialed again
+	require.Error(t, servers[0].host.Connect(context.Background(), *servers[1].AddrInfo()))
+	assert.False(t, servers[0].IsConnected(peerID))
+}
@@ -88,6 +88,10 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	reputation *peerReputation // scores of the peers and the list of the banned ones
+
+	permissions *peerPermissions // static, trusted and allowed peers
 }
 
 // NewServer returns a new instance of the networking server
@@ -118,12 +122,24 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return addrs
 	}
 
+	reputation, err := newPeerReputation(logger, config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := newPeerPermissions(config.StaticPeers, config.TrustedPeers, config.AllowedPeers)
+	if err != nil {
+		return nil, err
+	}
+
 	host, err := libp2p.New(
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
+		// Reject the connections to and from the banned peers and the ones not allowed
+		libp2p.ConnectionGater(&connectionGater{reputation: reputation, permissions: permissions}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
@@ -145,6 +161,8 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		emitterPeerEvent: emitter,
 		protocols:        map[string]Protocol{},
 		secretsManager:   config.SecretsManager,
+		reputation:       reputation,
+		permissions:      permissions,
 		bootnodes: &bootnodesWrapper{
 			bootnodeArr:       make([]*peer.AddrInfo, 0),
 			bootnodesMap:      make(map[peer.ID]*peer.AddrInfo),
@@ -168,6 +186,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 	}
 
 	srv.ps = ps
+	srv.updateBannedPeersMetrics()
 
 	return srv, nil
 }
@@ -181,6 +200,9 @@ func (s *Server) HasFreeConnectionSlot(direction network.Direction) bool {
 type PeerConnInfo struct {
 	Info peer.AddrInfo
 
+	// trusted peers don't take a connection slot, the status is fixed when the peer connects
+	trusted bool
+
 	connDirections  map[network.Direction]bool
 	protocolStreams map[string]*rawGrpc.ClientConn
 }
@@ -269,6 +291,7 @@ func (s *Server) Start() error {
 
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
+	go s.keepStaticPeersConnected()
 
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
@@ -360,12 +383,20 @@ func (s *Server) runDial() {
 
 	defer cancel()
 
+	// the trusted peers dialed without taking a slot, tracked so that no slot is released
+	// for them even if they are no longer trusted once disconnected
+	var trustedDials sync.Map
+
 	if err := s.Subscribe(ctx, func(event *peerEvent.PeerEvent) {
 		// Return back slot on PeerFailedToConnect or PeerDisconnected
 		switch event.Type {
 		case
 			peerEvent.PeerFailedToConnect,
 			peerEvent.PeerDisconnected:
+			if _, trusted := trustedDials.LoadAndDelete(event.PeerID); trusted {
+				return
+			}
+
 			slots.Release()
 			s.logger.Debug("slot released", "event", event.Type, "peerID", event.PeerID)
 		}
@@ -399,10 +430,20 @@ func (s *Server) runDial() {
 				continue
 			}
 
-			s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
+			if !s.IsPeerAllowed(peerInfo.ID) {
+				s.logger.Debug("Skipping dial of a peer not allowed", "addr", peerInfo)
 
-			if closed := slots.Take(ctx); closed {
-				return
+				continue
+			}
+
+			if s.IsTrustedPeer(peerInfo.ID) {
+				trustedDials.Store(peerInfo.ID, struct{}{})
+			} else {
+				s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
+
+				if closed := slots.Take(ctx); closed {
+					return
+				}
 			}
 
 			// the connection process is async because it involves connection (here) +
@@ -508,8 +549,11 @@ func (s *Server) removePeerInfo(peerID peer.ID) *PeerConnInfo {
 	// Update connection counters
 	for connDirection, active := range connectionInfo.connDirections {
 		if active {
-			s.connectionCounts.UpdateConnCountByDirection(-1, connDirection)
-			s.updateConnCountMetrics(connDirection)
+			if !connectionInfo.trusted {
+				s.connectionCounts.UpdateConnCountByDirection(-1, connDirection)
+				s.updateConnCountMetrics(connDirection)
+			}
+
 			s.updateBootnodeConnCount(peerID, -1)
 		}
 	}
@@ -735,6 +779,11 @@ func (s *Server) updateConnCountMetrics(direction network.Direction) {
 	}
 }
 
+// updateBannedPeersMetrics updates the banned peers count metrics
+func (s *Server) updateBannedPeersMetrics() {
+	metrics.SetGauge([]string{networkMetrics, "banned_peers"}, float32(len(s.reputation.bannedPeers())))
+}
+
 // updatePendingConnCountMetrics updates the pending connection count metrics
 func (s *Server) updatePendingConnCountMetrics(direction network.Direction) {
 	switch direction {
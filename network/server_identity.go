@@ -64,6 +64,7 @@ func (s *Server) addPeerInfo(id peer.ID, direction network.Direction) bool {
 		// Create a new record for the connection info
 		connectionInfo = &PeerConnInfo{
 			Info:            s.host.Peerstore().PeerInfo(id),
+			trusted:         s.IsTrustedPeer(id),
 			connDirections:  make(map[network.Direction]bool),
 			protocolStreams: make(map[string]*rawGrpc.ClientConn),
 		}
@@ -75,8 +76,11 @@ func (s *Server) addPeerInfo(id peer.ID, direction network.Direction) bool {
 	s.peers[id] = connectionInfo
 
 	// Update connection counters
-	s.connectionCounts.UpdateConnCountByDirection(1, direction)
-	s.updateConnCountMetrics(direction)
+	if !connectionInfo.trusted {
+		s.connectionCounts.UpdateConnCountByDirection(1, direction)
+		s.updateConnCountMetrics(direction)
+	}
+
 	s.updateBootnodeConnCount(id, 1)
 
 	// Update the metric stats
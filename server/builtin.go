@@ -1,6 +1,9 @@
 package server
 
 import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	consensusDev "github.com/0xPolygon/polygon-edge/consensus/dev"
@@ -26,6 +29,11 @@ type ForkManagerInitialParamsFactory func(config *chain.Chain) (*forkmanager.For
 
 type IsL1OriginatedTokenCheck func(config *chain.Params) (bool, error)
 
+type HeaderHashSetup func()
+
+type ImportVerifierFactory func(bc *blockchain.Blockchain, config *chain.Params,
+	logger hclog.Logger) (ImportVerifier, error)
+
 const (
 	DevConsensus     ConsensusType = "dev"
 	PolyBFTConsensus ConsensusType = consensusPolyBFT.ConsensusName
@@ -64,6 +72,18 @@ var isL1OriginatedTokenCheckFactory = map[ConsensusType]IsL1OriginatedTokenCheck
 	PolyBFTConsensus: consensusPolyBFT.IsL1OriginatedTokenCheck,
 }
 
+// headerHashSetupFactory defines the custom header hash functions of the consensus engines,
+// needed to hash the blocks when the engine itself is not running
+var headerHashSetupFactory = map[ConsensusType]HeaderHashSetup{
+	PolyBFTConsensus: consensusPolyBFT.SetupHeaderHashFunc,
+}
+
+// importVerifierFactory defines the verifiers of the blocks imported into the chain of a stopped node,
+// for the consensus engines sealing their blocks
+var importVerifierFactory = map[ConsensusType]ImportVerifierFactory{
+	PolyBFTConsensus: newPolyBFTImportVerifier,
+}
+
 func ConsensusSupported(value string) bool {
 	_, ok := consensusBackends[ConsensusType(value)]
 
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/chain"
+	consensusPolyBFT "github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ImportVerifier verifies the blocks imported into the chain of a stopped node
+type ImportVerifier interface {
+	blockchain.Verifier
+
+	// Close releases the resources held by the verifier
+	Close() error
+}
+
+// ImportChain imports the blocks of the chain file at the given path into the data directory of a stopped node.
+// Every block is verified by the consensus engine, executed against the local state
+// and written as if it had been synced, the ones already present in the local chain are skipped.
+// The given blockchain database is closed once the import is done
+func ImportChain(dataDir string, db *storagev2.Storage, config *chain.Chain, path string,
+	logger hclog.Logger) (*archive.ImportResult, error) {
+	engine := ConsensusType(config.Params.GetEngine())
+
+	// hash the blocks the way the consensus engine does
+	if setup, ok := headerHashSetupFactory[engine]; ok {
+		setup()
+	}
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, "trie"), logger)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("failed to open trie database: %w", err)
+	}
+	defer stateStorage.Close()
+
+	executor, err := newExecutor(config, stateStorage, itrie.NewState(stateStorage), logger)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	signer := crypto.NewSigner(config.Params.Forks.At(0), uint64(config.Params.ChainID))
+
+	bc, err := blockchain.NewBlockchain(logger, db, config, nil, executor, signer)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+	defer bc.Close()
+
+	executor.GetHash = bc.GetHashHelper
+
+	var verifier ImportVerifier = &unsealedImportVerifier{}
+
+	if factory, ok := importVerifierFactory[engine]; ok {
+		if verifier, err = factory(bc, config.Params, logger); err != nil {
+			return nil, fmt.Errorf("failed to create block verifier: %w", err)
+		}
+	}
+
+	defer verifier.Close()
+
+	bc.SetConsensus(verifier)
+
+	if err := bc.ComputeGenesis(); err != nil {
+		return nil, err
+	}
+
+	reader, err := archive.OpenChainFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chain file: %w", err)
+	}
+	defer reader.Close()
+
+	return archive.ImportChain(bc, reader, logger)
+}
+
+// newPolyBFTImportVerifier creates the verifier of the blocks imported into a PolyBFT chain
+func newPolyBFTImportVerifier(bc *blockchain.Blockchain, config *chain.Params,
+	logger hclog.Logger) (ImportVerifier, error) {
+	verifier, err := consensusPolyBFT.NewImportVerifier(bc, config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifier, nil
+}
+
+// unsealedImportVerifier is the verifier of the blocks imported into the chain of a consensus engine
+// which doesn't seal its blocks, the execution of every block still has to match the roots of its header
+type unsealedImportVerifier struct{}
+
+func (v *unsealedImportVerifier) VerifyHeader(_ *types.Header) error {
+	return nil
+}
+
+func (v *unsealedImportVerifier) ProcessHeaders(_ []*types.Header) error {
+	return nil
+}
+
+func (v *unsealedImportVerifier) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return types.BytesToAddress(header.Miner), nil
+}
+
+func (v *unsealedImportVerifier) PreCommitState(_ *types.Block, _ *state.Transition) error {
+	return nil
+}
+
+func (v *unsealedImportVerifier) GetLatestChainConfig() (*chain.Params, error) {
+	return nil, nil
+}
+
+func (v *unsealedImportVerifier) Close() error {
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -27,12 +28,33 @@ type Config struct {
 	MaxSlots           uint64
 	TxGossipBatchSize  uint64
 
+	// TxPoolJournal enables keeping the local transactions of the pool
+	// in the data directory, to restore them on restart
+	TxPoolJournal         bool
+	TxPoolJournalRotation time.Duration
+
 	Telemetry *Telemetry
 	Network   *network.Config
 
 	DataDir     string
 	RestoreFile *string
 
+	// StateRetention is the number of the most recent blocks whose state is kept.
+	// Older states are pruned in the background. Zero keeps all the states (archive node)
+	StateRetention uint64
+
+	// StateSync enables downloading the state of a recent block from the peers,
+	// instead of executing all the blocks, when the local chain is empty
+	StateSync bool
+
+	// FreezerThreshold is the number of the most recent blocks kept in the main database.
+	// Older canonical blocks are moved to the freezer in the background. Zero disables the freezer
+	FreezerThreshold uint64
+
+	// HistoryRetention is the number of the most recent blocks keeping their bodies, receipts and transaction lookups.
+	// The history of older canonical blocks is pruned in the background. Zero keeps the whole history
+	HistoryRetention uint64
+
 	Seal bool
 
 	SecretsManager *secrets.SecretsManagerConfig
@@ -69,6 +91,22 @@ type JSONRPC struct {
 	BlockRangeLimit          uint64
 	ConcurrentRequestsDebug  uint64
 	WebSocketReadLimit       uint64
+	IPCPath                  string
+
+	// Namespaces served on JSONRPCAddr, all of them if empty
+	Namespaces []string
+	JWTSecret  []byte
+
+	// AdminAddr is the address of the additional listener, disabled if nil
+	AdminAddr       *net.TCPAddr
+	AdminNamespaces []string
+	AdminJWTSecret  []byte
+
+	// RateLimits are the per client limits of the requests, applied to all the listeners
+	RateLimits              []*jsonrpc.RateLimit
+	RateLimitKeyHeader      string
+	RateLimitTrustedProxies []*net.IPNet
+	MaxResponseSize         uint64
 }
 
 type EventTracker struct {
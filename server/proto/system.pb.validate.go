@@ -360,6 +360,10 @@ func (m *Peer) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for Score
+
+	// no validation rules for BannedUntil
+
 	if len(errors) > 0 {
 		return PeerMultiError(errors)
 	}
@@ -823,6 +827,40 @@ func (m *PeersListResponse) validate(all bool) error {
 
 	}
 
+	for idx, item := range m.GetBannedPeers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PeersListResponseValidationError{
+						field:  fmt.Sprintf("BannedPeers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PeersListResponseValidationError{
+						field:  fmt.Sprintf("BannedPeers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PeersListResponseValidationError{
+					field:  fmt.Sprintf("BannedPeers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return PeersListResponseMultiError(errors)
 	}
@@ -903,6 +941,212 @@ var _ interface {
 	ErrorName() string
 } = PeersListResponseValidationError{}
 
+// Validate checks the field values on PeersReloadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PeersReloadRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersReloadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersReloadRequestMultiError, or nil if none found.
+func (m *PeersReloadRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersReloadRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return PeersReloadRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersReloadRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersReloadRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersReloadRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersReloadRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersReloadRequestMultiError) AllErrors() []error { return m }
+
+// PeersReloadRequestValidationError is the validation error returned by
+// PeersReloadRequest.Validate if the designated constraints aren't met.
+type PeersReloadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersReloadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersReloadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersReloadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersReloadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersReloadRequestValidationError) ErrorName() string {
+	return "PeersReloadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersReloadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersReloadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersReloadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersReloadRequestValidationError{}
+
+// Validate checks the field values on PeersReloadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PeersReloadResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersReloadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersReloadResponseMultiError, or nil if none found.
+func (m *PeersReloadResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersReloadResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	if len(errors) > 0 {
+		return PeersReloadResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersReloadResponseMultiError is an error wrapping multiple validation errors
+// returned by PeersReloadResponse.ValidateAll() if the designated constraints
+// aren't met.
+type PeersReloadResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersReloadResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersReloadResponseMultiError) AllErrors() []error { return m }
+
+// PeersReloadResponseValidationError is the validation error returned by
+// PeersReloadResponse.Validate if the designated constraints aren't met.
+type PeersReloadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersReloadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersReloadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersReloadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersReloadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersReloadResponseValidationError) ErrorName() string {
+	return "PeersReloadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersReloadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersReloadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersReloadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersReloadResponseValidationError{}
+
 // Validate checks the field values on BlockByNumberRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -21,6 +21,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/accounts/keystore"
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/bloombits"
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
@@ -51,6 +52,7 @@ import (
 var (
 	errBlockTimeMissing = errors.New("block time configuration is missing")
 	errBlockTimeInvalid = errors.New("block time configuration is invalid")
+	errNoValidators     = errors.New("validators data is not provided by the consensus engine")
 )
 
 // Server is the central manager of the blockchain client
@@ -66,6 +68,12 @@ type Server struct {
 	blockchain *blockchain.Blockchain
 	chain      *chain.Chain
 
+	// bloom bits index of the chain logs
+	bloomIndexer *bloombits.Indexer
+
+	// statePruner removes the states out of the retention window (nil for archive nodes)
+	statePruner *statePruner
+
 	// state executor
 	executor *state.Executor
 
@@ -170,6 +178,7 @@ func NewServer(config *Config) (*Server, error) {
 	var dirPaths = []string{
 		"blockchain",
 		"trie",
+		"txpool",
 	}
 
 	// Generate all the paths in the dataDir
@@ -217,100 +226,22 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	var pruner *itrie.Pruner
+	if m.config.StateRetention > 0 {
+		pruner = itrie.NewPruner(stateStorage, logger)
+		stateStorage = pruner
+	}
+
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
 	m.state = st
 
-	m.executor = state.NewExecutor(config.Chain.Params, st, logger.Named("executor"))
-
-	// custom write genesis hook per consensus engine
-	engineName := m.config.Chain.Params.GetEngine()
-	if factory, exists := genesisCreationFactory[ConsensusType(engineName)]; exists {
-		m.executor.GenesisPostHook = factory(m.config.Chain, engineName)
-	}
-
-	if factory, exists := isL1OriginatedTokenCheckFactory[ConsensusType(engineName)]; exists {
-		isL1OriginatedToken, err := factory(m.config.Chain.Params)
-		if err != nil {
-			return nil, err
-		}
-
-		m.executor.IsL1OriginatedToken = isL1OriginatedToken
-	}
-
-	// apply allow list contracts deployer genesis data
-	if m.config.Chain.Params.ContractDeployerAllowList != nil {
-		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListContractsAddr,
-			m.config.Chain.Params.ContractDeployerAllowList)
-	}
-
-	// apply block list contracts deployer genesis data
-	if m.config.Chain.Params.ContractDeployerBlockList != nil {
-		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.BlockListContractsAddr,
-			m.config.Chain.Params.ContractDeployerBlockList)
-	}
-
-	// apply transactions execution allow list genesis data
-	if m.config.Chain.Params.TransactionsAllowList != nil {
-		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListTransactionsAddr,
-			m.config.Chain.Params.TransactionsAllowList)
-	}
-
-	// apply transactions execution block list genesis data
-	if m.config.Chain.Params.TransactionsBlockList != nil {
-		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.BlockListTransactionsAddr,
-			m.config.Chain.Params.TransactionsBlockList)
-	}
-
-	// apply bridge allow list genesis data
-	if m.config.Chain.Params.BridgeAllowList != nil {
-		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListBridgeAddr,
-			m.config.Chain.Params.BridgeAllowList)
-	}
-
-	// apply bridge block list genesis data
-	if m.config.Chain.Params.BridgeBlockList != nil {
-		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.BlockListBridgeAddr,
-			m.config.Chain.Params.BridgeBlockList)
-	}
-
-	var initialStateRoot = types.ZeroHash
-
-	if ConsensusType(engineName) == PolyBFTConsensus {
-		polyBFTConfig, err := consensusPolyBFT.GetPolyBFTConfig(config.Chain.Params)
-		if err != nil {
-			return nil, err
-		}
-
-		if polyBFTConfig.InitialTrieRoot != types.ZeroHash {
-			checkedInitialTrieRoot, err := itrie.HashChecker(polyBFTConfig.InitialTrieRoot.Bytes(), stateStorage)
-			if err != nil {
-				return nil, fmt.Errorf("error on state root verification %w", err)
-			}
-
-			if checkedInitialTrieRoot != polyBFTConfig.InitialTrieRoot {
-				return nil, errors.New("invalid initial state root")
-			}
-
-			logger.Info("Initial state root checked and correct")
-
-			initialStateRoot = polyBFTConfig.InitialTrieRoot
-		}
-	}
-
-	genesisRoot, err := m.executor.WriteGenesis(config.Chain.Genesis.Alloc, initialStateRoot)
+	m.executor, err = newExecutor(config.Chain, stateStorage, st, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := initForkManager(engineName, config.Chain); err != nil {
-		return nil, err
-	}
-
-	// compute the genesis root state
-	config.Chain.Genesis.StateRoot = genesisRoot
-
 	signer := crypto.NewSigner(config.Chain.Params.Forks.At(0), uint64(m.config.Chain.Params.ChainID))
 
 	// create storage instance for blockchain
@@ -329,6 +260,19 @@ func NewServer(config *Config) (*Server, error) {
 			if err != nil {
 				return nil, err
 			}
+
+			if m.config.FreezerThreshold > 0 {
+				freezer, err := storagev2.OpenFreezer(filepath.Join(m.config.DataDir, "blockchain", "ancient"))
+				if err != nil {
+					return nil, err
+				}
+
+				db.StartFreezer(freezer, m.config.FreezerThreshold)
+			}
+
+			if m.config.HistoryRetention > 0 {
+				db.StartHistoryPruner(m.config.HistoryRetention, bloombits.DefaultSectionSize)
+			}
 		}
 	}
 
@@ -345,6 +289,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	m.bloomIndexer, err = bloombits.NewIndexer(logger, m.blockchain, db, bloombits.DefaultSectionSize)
+	if err != nil {
+		return nil, err
+	}
+
 	// setup account manager
 	{
 		keystore, err := keystore.NewKeyStore(
@@ -373,6 +322,11 @@ func NewServer(config *Config) (*Server, error) {
 			Blockchain: m.blockchain,
 		}
 
+		var txPoolJournalPath string
+		if m.config.TxPoolJournal && m.config.DataDir != "" {
+			txPoolJournalPath = filepath.Join(m.config.DataDir, "txpool", "journal.rlp")
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -387,6 +341,8 @@ func NewServer(config *Config) (*Server, error) {
 				TxGossipBatchSize:  m.config.TxGossipBatchSize,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
 				PeerID:             m.network.AddrInfo().ID,
+				JournalPath:        txPoolJournalPath,
+				JournalRotation:    m.config.TxPoolJournalRotation,
 			},
 		)
 		if err != nil {
@@ -413,6 +369,15 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// start indexing the chain logs
+	m.bloomIndexer.Start()
+
+	// start pruning the old states
+	if pruner != nil {
+		m.statePruner = newStatePruner(logger, m.blockchain, st, pruner, m.config.StateRetention)
+		m.statePruner.start()
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -608,6 +573,8 @@ func (s *Server) setupConsensus() error {
 			Network:         s.network,
 			Blockchain:      s.blockchain,
 			Executor:        s.executor,
+			StateStorage:    s.stateStorage,
+			StateSync:       s.config.StateSync,
 			Grpc:            s.grpcServer,
 			Logger:          s.logger,
 			SecretsManager:  s.secretsManager,
@@ -662,11 +629,13 @@ type jsonRPCHub struct {
 	restoreProgression *progress.ProgressionWrapper
 
 	*blockchain.Blockchain
+	*bloombits.Indexer
 	*txpool.TxPool
 	*state.Executor
 	*network.Server
 	consensus.Consensus
 	consensus.BridgeDataProvider
+	consensus.ValidatorsDataProvider
 	gasprice.GasStore
 }
 
@@ -709,6 +678,54 @@ func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot t
 	return res.Bytes(), nil
 }
 
+// GetProof returns the merkle proof of the given account and its storage slots at the given state root
+func (j *jsonRPCHub) GetProof(
+	stateRoot types.Hash,
+	addr types.Address,
+	keys []types.Hash,
+) (*state.AccountProof, error) {
+	snap, err := j.state.NewSnapshot(stateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", stateRoot, err)
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof, err := snap.GetAccountProof(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	storageRoot := types.EmptyRootHash
+	if account != nil {
+		storageRoot = account.Root
+	}
+
+	storageProofs := make([]*state.StorageProof, len(keys))
+
+	for i, key := range keys {
+		proof, err := snap.GetStorageProof(storageRoot, key)
+		if err != nil {
+			return nil, err
+		}
+
+		storageProofs[i] = &state.StorageProof{
+			Key:   key,
+			Value: snap.GetStorage(addr, storageRoot, key),
+			Proof: proof,
+		}
+	}
+
+	return &state.AccountProof{
+		Account:       account,
+		Proof:         accountProof,
+		StorageProofs: storageProofs,
+	}, nil
+}
+
 func (j *jsonRPCHub) Get(key string) ([]byte, error) {
 	hash := types.StringToHash(key)
 
@@ -729,6 +746,26 @@ func (j *jsonRPCHub) Verbosity(level int) (string, error) {
 	return j.Executor.Verbosity(level)
 }
 
+// SetHead sets the head of the chain back to the block with the given number,
+// and resets the transaction pool and the consensus data to match it.
+// The transactions of the removed blocks are added back to the pool
+func (j *jsonRPCHub) SetHead(number uint64) error {
+	removed, err := j.Blockchain.SetHead(number)
+	if err != nil {
+		return err
+	}
+
+	header := j.Blockchain.Header()
+
+	j.TxPool.ResetWithHeader(header, removed)
+
+	if err := j.Consensus.ResetHead(header); err != nil {
+		return fmt.Errorf("failed to reset consensus: %w", err)
+	}
+
+	return nil
+}
+
 func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
 	account, err := getAccountImpl(j.state, root, addr)
 	if err != nil {
@@ -1086,20 +1123,58 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// noValidatorsProvider serves the validators data requests of the consensus engines without validators
+type noValidatorsProvider struct{}
+
+func (noValidatorsProvider) GetValidatorSet(uint64) ([]*types.ValidatorInfo, error) {
+	return nil, errNoValidators
+}
+
+func (noValidatorsProvider) GetEpochInfo(uint64) (*types.EpochInfo, error) {
+	return nil, errNoValidators
+}
+
+func (noValidatorsProvider) GetBlockSigners(uint64) (*types.BlockSigners, error) {
+	return nil, errNoValidators
+}
+
+func (noValidatorsProvider) GetProposer(uint64, uint64) (types.Address, error) {
+	return types.ZeroAddress, errNoValidators
+}
+
+func (noValidatorsProvider) GetVotingPowers(uint64) (map[types.Address]*big.Int, error) {
+	return nil, errNoValidators
+}
+
+func (noValidatorsProvider) GetValidatorsUptime(uint64) ([]*types.ValidatorUptime, error) {
+	return nil, errNoValidators
+}
+
+func (noValidatorsProvider) GetDoubleSignEvidence(uint64, uint64) ([]*types.DoubleSignEvidence, error) {
+	return nil, errNoValidators
+}
+
 // SETUP //
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
+	validatorsProvider := s.consensus.GetValidatorsProvider()
+	if validatorsProvider == nil {
+		validatorsProvider = noValidatorsProvider{}
+	}
+
 	hub := &jsonRPCHub{
-		state:              s.state,
-		restoreProgression: s.restoreProgression,
-		Blockchain:         s.blockchain,
-		TxPool:             s.txpool,
-		Executor:           s.executor,
-		Consensus:          s.consensus,
-		Server:             s.network,
-		BridgeDataProvider: s.consensus.GetBridgeProvider(),
-		GasStore:           s.gasHelper,
+		state:                  s.state,
+		restoreProgression:     s.restoreProgression,
+		Blockchain:             s.blockchain,
+		Indexer:                s.bloomIndexer,
+		TxPool:                 s.txpool,
+		Executor:               s.executor,
+		Consensus:              s.consensus,
+		Server:                 s.network,
+		BridgeDataProvider:     s.consensus.GetBridgeProvider(),
+		ValidatorsDataProvider: validatorsProvider,
+		GasStore:               s.gasHelper,
 	}
 
 	conf := &jsonrpc.Config{
@@ -1113,10 +1188,28 @@ func (s *Server) setupJSONRPC() error {
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
 		ConcurrentRequestsDebug:  s.config.JSONRPC.ConcurrentRequestsDebug,
 		WebSocketReadLimit:       s.config.JSONRPC.WebSocketReadLimit,
+		IPCPath:                  s.config.JSONRPC.IPCPath,
 		UseTLS:                   s.config.UseTLS,
 		TLSCertFile:              s.config.TLSCertFile,
 		TLSKeyFile:               s.config.TLSKeyFile,
 		SecretsManager:           s.secretsManager,
+
+		Namespaces: s.config.JSONRPC.Namespaces,
+		JWTSecret:  s.config.JSONRPC.JWTSecret,
+
+		RateLimits:              s.config.JSONRPC.RateLimits,
+		RateLimitKeyHeader:      s.config.JSONRPC.RateLimitKeyHeader,
+		RateLimitTrustedProxies: s.config.JSONRPC.RateLimitTrustedProxies,
+		MaxResponseSize:         s.config.JSONRPC.MaxResponseSize,
+	}
+
+	if s.config.JSONRPC.AdminAddr != nil {
+		conf.Listeners = []*jsonrpc.Listener{{
+			Addr:       s.config.JSONRPC.AdminAddr,
+			Namespaces: s.config.JSONRPC.AdminNamespaces,
+			JWTSecret:  s.config.JSONRPC.AdminJWTSecret,
+			Privileged: true,
+		}}
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf, s.accManager)
@@ -1162,6 +1255,14 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop the bloom bits indexer before the blockchain storage gets closed
+	s.bloomIndexer.Close()
+
+	// Stop the state pruner before the state storage gets closed
+	if s.statePruner != nil {
+		s.statePruner.close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -1191,6 +1292,13 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close networking", "err", err.Error())
 	}
 
+	// Close the JSON-RPC listeners
+	if s.jsonrpcServer != nil {
+		if err := s.jsonrpcServer.Close(); err != nil {
+			s.logger.Error("failed to close json rpc server", "err", err.Error())
+		}
+	}
+
 	// Close DataDog profiler
 	s.closeDataDogProfiler()
 
@@ -1229,6 +1337,106 @@ func (s *Server) startPrometheusServer(listenAddr *net.TCPAddr) *http.Server {
 	return srv
 }
 
+// newExecutor creates the state executor of the chain and writes the genesis state,
+// applying the genesis hooks and allocations of the consensus engine
+func newExecutor(
+	config *chain.Chain,
+	stateStorage itrie.Storage,
+	st *itrie.State,
+	logger hclog.Logger,
+) (*state.Executor, error) {
+	executor := state.NewExecutor(config.Params, st, logger.Named("executor"))
+
+	// custom write genesis hook per consensus engine
+	engineName := config.Params.GetEngine()
+	if factory, exists := genesisCreationFactory[ConsensusType(engineName)]; exists {
+		executor.GenesisPostHook = factory(config, engineName)
+	}
+
+	if factory, exists := isL1OriginatedTokenCheckFactory[ConsensusType(engineName)]; exists {
+		isL1OriginatedToken, err := factory(config.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		executor.IsL1OriginatedToken = isL1OriginatedToken
+	}
+
+	// apply allow list contracts deployer genesis data
+	if config.Params.ContractDeployerAllowList != nil {
+		addresslist.ApplyGenesisAllocs(config.Genesis, contracts.AllowListContractsAddr,
+			config.Params.ContractDeployerAllowList)
+	}
+
+	// apply block list contracts deployer genesis data
+	if config.Params.ContractDeployerBlockList != nil {
+		addresslist.ApplyGenesisAllocs(config.Genesis, contracts.BlockListContractsAddr,
+			config.Params.ContractDeployerBlockList)
+	}
+
+	// apply transactions execution allow list genesis data
+	if config.Params.TransactionsAllowList != nil {
+		addresslist.ApplyGenesisAllocs(config.Genesis, contracts.AllowListTransactionsAddr,
+			config.Params.TransactionsAllowList)
+	}
+
+	// apply transactions execution block list genesis data
+	if config.Params.TransactionsBlockList != nil {
+		addresslist.ApplyGenesisAllocs(config.Genesis, contracts.BlockListTransactionsAddr,
+			config.Params.TransactionsBlockList)
+	}
+
+	// apply bridge allow list genesis data
+	if config.Params.BridgeAllowList != nil {
+		addresslist.ApplyGenesisAllocs(config.Genesis, contracts.AllowListBridgeAddr,
+			config.Params.BridgeAllowList)
+	}
+
+	// apply bridge block list genesis data
+	if config.Params.BridgeBlockList != nil {
+		addresslist.ApplyGenesisAllocs(config.Genesis, contracts.BlockListBridgeAddr,
+			config.Params.BridgeBlockList)
+	}
+
+	var initialStateRoot = types.ZeroHash
+
+	if ConsensusType(engineName) == PolyBFTConsensus {
+		polyBFTConfig, err := consensusPolyBFT.GetPolyBFTConfig(config.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		if polyBFTConfig.InitialTrieRoot != types.ZeroHash {
+			checkedInitialTrieRoot, err := itrie.HashChecker(polyBFTConfig.InitialTrieRoot.Bytes(), stateStorage)
+			if err != nil {
+				return nil, fmt.Errorf("error on state root verification %w", err)
+			}
+
+			if checkedInitialTrieRoot != polyBFTConfig.InitialTrieRoot {
+				return nil, errors.New("invalid initial state root")
+			}
+
+			logger.Info("Initial state root checked and correct")
+
+			initialStateRoot = polyBFTConfig.InitialTrieRoot
+		}
+	}
+
+	genesisRoot, err := executor.WriteGenesis(config.Genesis.Alloc, initialStateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := initForkManager(engineName, config); err != nil {
+		return nil, err
+	}
+
+	// compute the genesis root state
+	config.Genesis.StateRoot = genesisRoot
+
+	return executor, nil
+}
+
 func initForkManager(engineName string, config *chain.Chain) error {
 	var initialParams *forkmanager.ForkParams
 
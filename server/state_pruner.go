@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// statePruner removes, in the background, the states of the blocks which are
+// older than the retention window. A pruning is triggered every retention blocks
+type statePruner struct {
+	logger hclog.Logger
+
+	blockchain *blockchain.Blockchain
+	state      *itrie.State
+	pruner     *itrie.Pruner
+	retention  uint64
+
+	// lastPruned is the head number at the time of the last pruning
+	lastPruned atomic.Uint64
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	blockchain *blockchain.Blockchain,
+	state *itrie.State,
+	pruner *itrie.Pruner,
+	retention uint64,
+) *statePruner {
+	return &statePruner{
+		logger:     logger.Named("state-pruner"),
+		blockchain: blockchain,
+		state:      state,
+		pruner:     pruner,
+		retention:  retention,
+		closeCh:    make(chan struct{}),
+	}
+}
+
+// start starts listening for the new blocks
+func (p *statePruner) start() {
+	p.lastPruned.Store(p.blockchain.Header().Number)
+
+	subscription := p.blockchain.SubscribeEvents()
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		defer p.blockchain.UnsubscribeEvents(subscription)
+
+		eventCh := subscription.GetEventCh()
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+
+			case ev := <-eventCh:
+				if ev == nil || ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+					continue
+				}
+
+				head := ev.NewChain[len(ev.NewChain)-1].Number
+				if head < p.lastPruned.Load()+p.retention {
+					continue
+				}
+
+				p.wg.Add(1)
+
+				go func() {
+					defer p.wg.Done()
+
+					p.prune(head)
+				}()
+			}
+		}
+	}()
+}
+
+// close stops the pruner and waits for the running pruning to finish
+func (p *statePruner) close() {
+	close(p.closeCh)
+	p.wg.Wait()
+}
+
+// prune removes the states which are out of the retention window of the given head
+func (p *statePruner) prune(head uint64) {
+	roots, err := itrie.RetainedStateRoots(head, p.retention, func(number uint64) (types.Hash, bool) {
+		header, ok := p.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			return types.ZeroHash, false
+		}
+
+		return header.StateRoot, true
+	})
+	if err != nil {
+		p.logger.Error("failed to prune state", "head", head, "err", err)
+
+		return
+	}
+
+	start := time.Now().UTC()
+
+	res, err := p.pruner.Prune(roots)
+	if errors.Is(err, itrie.ErrPruningInProgress) {
+		return
+	} else if err != nil {
+		p.logger.Error("failed to prune state", "head", head, "err", err)
+
+		return
+	}
+
+	p.lastPruned.Store(head)
+
+	// cached tries of the pruned states must not be served anymore
+	p.state.PurgeCache()
+
+	p.logger.Info("state pruned", "head", head, "retained nodes", res.Retained,
+		"deleted nodes", res.Deleted, "duration", time.Since(start))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network/common"
@@ -101,6 +102,22 @@ func (s *systemService) PeersAdd(_ context.Context, req *proto.PeersAddRequest)
 	}, nil
 }
 
+// PeersReload implements the 'peers reload' operator service
+func (s *systemService) PeersReload(
+	_ context.Context,
+	req *proto.PeersReloadRequest,
+) (*proto.PeersReloadResponse, error) {
+	if err := s.server.network.SetPeerPermissions(req.Static, req.Trusted, req.Allowed); err != nil {
+		return &proto.PeersReloadResponse{
+			Message: "Unable to reload peer permissions",
+		}, err
+	}
+
+	return &proto.PeersReloadResponse{
+		Message: "Peer permissions reloaded",
+	}, nil
+}
+
 // PeersStatus implements the 'peers status' operator service
 func (s *systemService) PeersStatus(ctx context.Context, req *proto.PeersStatusRequest) (*proto.Peer, error) {
 	peerID, err := peer.Decode(req.Id)
@@ -134,6 +151,11 @@ func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 		Id:        id.String(),
 		Protocols: protocols,
 		Addrs:     addrs,
+		Score:     s.server.network.PeerScore(id),
+	}
+
+	if bannedUntil, banned := s.server.network.IsBanned(id); banned {
+		peer.BannedUntil = bannedUntil.Unix()
 	}
 
 	return peer, nil
@@ -158,6 +180,18 @@ func (s *systemService) PeersList(
 		resp.Peers = append(resp.Peers, peer)
 	}
 
+	// the banned peers are disconnected, so only the ban is known about them
+	for id, bannedUntil := range s.server.network.BannedPeers() {
+		resp.BannedPeers = append(resp.BannedPeers, &proto.Peer{
+			Id:          id.String(),
+			BannedUntil: bannedUntil.Unix(),
+		})
+	}
+
+	sort.Slice(resp.BannedPeers, func(i, j int) bool {
+		return resp.BannedPeers[i].Id < resp.BannedPeers[j].Id
+	})
+
 	return resp, nil
 }
 
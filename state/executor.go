@@ -211,33 +211,36 @@ func (e *Executor) ProcessBlock(
 	return txn, nil
 }
 
+// HasState checks if the state with the given root is available in the storage
+func (e *Executor) HasState(root types.Hash) bool {
+	_, err := e.state.NewSnapshot(root)
+
+	return err == nil
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (e *Executor) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return e.config.Forks.At(blockNumber)
 }
 
-func (e *Executor) BeginTxn(
-	parentRoot types.Hash,
+// txContext returns the forks and the context of the transactions executed in the block
+func (e *Executor) txContext(
 	header *types.Header,
 	coinbaseReceiver types.Address,
-) (*Transition, error) {
+) (chain.ForksInTime, runtime.TxContext, error) {
 	forkConfig := e.config.Forks.At(header.Number)
 
-	snap, err := e.state.NewSnapshot(parentRoot)
-	if err != nil {
-		return nil, err
-	}
-
 	burnContract := types.ZeroAddress
+
 	if forkConfig.London {
+		var err error
+
 		burnContract, err = e.config.CalculateBurnContract(header.Number)
 		if err != nil {
-			return nil, err
+			return forkConfig, runtime.TxContext{}, err
 		}
 	}
 
-	newTxn := NewTxn(snap)
-
 	txCtx := runtime.TxContext{
 		Coinbase:     coinbaseReceiver,
 		Timestamp:    header.Timestamp,
@@ -249,6 +252,26 @@ func (e *Executor) BeginTxn(
 		BurnContract: burnContract,
 	}
 
+	return forkConfig, txCtx, nil
+}
+
+func (e *Executor) BeginTxn(
+	parentRoot types.Hash,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	snap, err := e.state.NewSnapshot(parentRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	forkConfig, txCtx, err := e.txContext(header, coinbaseReceiver)
+	if err != nil {
+		return nil, err
+	}
+
+	newTxn := NewTxn(snap)
+
 	t := NewTransition(e.logger, forkConfig, snap, newTxn)
 	t.PostHook = e.PostHook
 	t.getHash = e.GetHash(header)
@@ -287,6 +310,27 @@ func (e *Executor) BeginTxn(
 	return t, nil
 }
 
+// ContinueTxn moves the transition to the next block, keeping the state modified by the transactions
+// applied so far. It's used to simulate a sequence of blocks without committing the state of each of them
+func (e *Executor) ContinueTxn(t *Transition, header *types.Header, coinbaseReceiver types.Address) error {
+	forkConfig, txCtx, err := e.txContext(header, coinbaseReceiver)
+	if err != nil {
+		return err
+	}
+
+	txCtx.Tracer = t.ctx.Tracer
+	txCtx.NonPayable = t.ctx.NonPayable
+
+	t.config = forkConfig
+	t.getHash = e.GetHash(header)
+	t.ctx = txCtx
+	t.gasPool = txCtx.GasLimit
+	t.receipts = nil
+	t.totalGas = 0
+
+	return nil
+}
+
 type Transition struct {
 	logger hclog.Logger
 
@@ -324,6 +368,9 @@ type Transition struct {
 	accessList *runtime.AccessList
 
 	isL1OriginatedToken bool
+
+	// transferLogs enables the logs of the ether transfers
+	transferLogs bool
 }
 
 func NewTransition(logger hclog.Logger, config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -375,6 +422,12 @@ func (t *Transition) WithStateOverride(override types.StateOverride) error {
 	return nil
 }
 
+// SetTransferLogs enables emitting a log with the TransferLogAddress for each ether transfer,
+// used to expose the transfers of the simulated transactions
+func (t *Transition) SetTransferLogs(enabled bool) {
+	t.transferLogs = enabled
+}
+
 func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
@@ -385,6 +438,14 @@ func (t *Transition) Receipts() []*types.Receipt {
 
 var emptyFrom = types.Address{}
 
+var (
+	// TransferLogAddress is the pseudo address emitting the logs of the ether transfers
+	TransferLogAddress = types.StringToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+	// TransferEventTopic is the topic of the ether transfer logs, the same as of the ERC20 Transfer event
+	TransferEventTopic = types.BytesToHash(crypto.Keccak256([]byte("Transfer(address,address,uint256)")))
+)
+
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
 	if txn.From() == emptyFrom && txn.Type() != types.StateTxType {
@@ -501,6 +562,8 @@ func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 
 	s := t.Snapshot()
 
+	t.captureTxPreState(msg)
+
 	result, err := t.apply(msg)
 	if err != nil {
 		if revertErr := t.RevertToSnapshot(s); revertErr != nil {
@@ -508,6 +571,8 @@ func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		}
 	}
 
+	t.captureTxPostState()
+
 	if t.PostHook != nil {
 		t.PostHook(t)
 	}
@@ -868,6 +933,14 @@ func (t *Transition) Transfer(from, to types.Address, amount *big.Int) error {
 
 	t.state.AddBalance(to, amount)
 
+	if t.transferLogs && amount.Sign() > 0 {
+		t.state.EmitLog(
+			TransferLogAddress,
+			[]types.Hash{TransferEventTopic, types.BytesToHash(from.Bytes()), types.BytesToHash(to.Bytes())},
+			types.BytesToHash(amount.Bytes()).Bytes(),
+		)
+	}
+
 	return nil
 }
 
@@ -982,7 +1055,7 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 
 	var result *runtime.ExecutionResult
 
-	t.captureCallStart(c, evm.CREATE)
+	t.captureCallStart(c, c.Type)
 
 	defer func() {
 		// pass result to be set later
@@ -1168,6 +1241,14 @@ func (t *Transition) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	return t.state.GetState(addr, key)
 }
 
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
 func (t *Transition) AccountExists(addr types.Address) bool {
 	return t.state.Exist(addr)
 }
@@ -1190,7 +1271,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -1323,6 +1404,30 @@ func checkAndProcessStateTx(msg *types.Transaction) error {
 	return nil
 }
 
+// captureTxPreState passes the state before the transaction to the tracer,
+// if the tracer inspects the state of the accounts
+func (t *Transition) captureTxPreState(msg *types.Transaction) {
+	stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer)
+	if !ok {
+		return
+	}
+
+	touched := []types.Address{msg.From(), t.ctx.Coinbase}
+	if t.isL1OriginatedToken && t.config.London && msg.Type() != types.StateTxType {
+		touched = append(touched, t.ctx.BurnContract)
+	}
+
+	stateTracer.TxPreState(t.state.Copy(), touched)
+}
+
+// captureTxPostState passes the state after the transaction to the tracer,
+// if the tracer inspects the state of the accounts
+func (t *Transition) captureTxPostState() {
+	if stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer); ok {
+		stateTracer.TxPostState(t.state.Copy())
+	}
+}
+
 // captureCallStart calls CallStart in Tracer if context has the tracer
 func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.CallType) {
 	if t.ctx.Tracer == nil {
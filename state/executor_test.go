@@ -12,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -270,3 +271,35 @@ func Test_Transition_EIP2929(t *testing.T) {
 		})
 	}
 }
+
+func Test_Transition_TraceContractCreations(t *testing.T) {
+	t.Parallel()
+
+	// the deployed contract creates two empty contracts, with CREATE2 and with CREATE
+	code := []byte{
+		uint8(evm.PUSH1), 0x0, uint8(evm.DUP1), uint8(evm.DUP1), uint8(evm.DUP1), uint8(evm.CREATE2), uint8(evm.POP),
+		uint8(evm.PUSH1), 0x0, uint8(evm.DUP1), uint8(evm.DUP1), uint8(evm.CREATE), uint8(evm.POP),
+		uint8(evm.STOP),
+	}
+
+	state := newStateWithPreState(nil)
+	transition := NewTransition(hclog.NewNullLogger(), chain.AllForksEnabled.At(0), state, newTxn(state))
+
+	tracer := &calltracer.CallTracer{}
+	transition.ctx.Tracer = tracer
+
+	result := transition.Create2(types.StringToAddress("1"), code, big.NewInt(0), uint64(1000000))
+	require.NoError(t, result.Err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	call, ok := res.(*calltracer.Call)
+	require.True(t, ok)
+
+	// the contract creations are reported with their own call types
+	require.Equal(t, "CREATE", call.Type)
+	require.Len(t, call.Calls, 2)
+	require.Equal(t, "CREATE2", call.Calls[0].Type)
+	require.Equal(t, "CREATE", call.Calls[1].Type)
+}
@@ -0,0 +1,86 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Prove returns the merkle proof for the given key in the trie with the given root.
+// The proof is a list of RLP encoded trie nodes on the path from the root node to the node
+// holding the value of the key. If the key is not present in the trie, the proof contains
+// all the nodes up to the point where the path of the key diverges from the trie (proof of absence).
+func Prove(root types.Hash, key []byte, storage Storage) ([][]byte, error) {
+	proof := [][]byte{}
+
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return proof, nil
+	}
+
+	var (
+		hash   = root.Bytes()
+		search = bytesToHexNibbles(key)
+	)
+
+	for hash != nil {
+		data, ok, err := storage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok || len(data) == 0 {
+			return nil, fmt.Errorf("trie node %s not found", types.BytesToHash(hash))
+		}
+
+		node, err := parseNode(data, storage)
+		if err != nil {
+			return nil, err
+		}
+
+		proof = append(proof, data)
+
+		hash, search = nextProofNode(node, search)
+	}
+
+	return proof, nil
+}
+
+// nextProofNode walks the given node (and its embedded children) following the search key.
+// It returns the hash of the next stored node on the path and the remaining part of the search key,
+// or nil hash if the path ends within the given node.
+func nextProofNode(node Node, search []byte) ([]byte, []byte) {
+	for {
+		switch n := node.(type) {
+		case nil:
+			return nil, nil
+
+		case *ValueNode:
+			if n.hash {
+				return n.buf, search
+			}
+
+			return nil, nil
+
+		case *ShortNode:
+			plen := len(n.key)
+			if plen > len(search) || !bytes.Equal(search[:plen], n.key) {
+				return nil, nil
+			}
+
+			node, search = n.child, search[plen:]
+
+		case *FullNode:
+			if len(search) == 0 {
+				node = n.value
+
+				continue
+			}
+
+			node, search = n.getEdge(search[0]), search[1:]
+
+		default:
+			panic(fmt.Sprintf("unknown node type %v", n)) //nolint:gocritic
+		}
+	}
+}
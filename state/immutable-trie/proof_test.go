@@ -0,0 +1,88 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestProve(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	batch := storage.Batch()
+
+	tx := NewTrie().Txn(storage)
+	tx.batch = batch
+
+	keys := make([][]byte, 0, 100)
+
+	for i := 0; i < 100; i++ {
+		key := crypto.Keccak256([]byte{byte(i)})
+		tx.Insert(key, []byte{0x1, byte(i)})
+
+		keys = append(keys, key)
+	}
+
+	rootBytes, err := tx.Hash()
+	require.NoError(t, err)
+	require.NoError(t, batch.Write())
+
+	root := types.BytesToHash(rootBytes)
+
+	// verifyProof checks that proof nodes are linked by their hashes starting from the root
+	// and that the path of the key ends within the last node of the proof
+	verifyProof := func(key []byte, proof [][]byte) {
+		t.Helper()
+
+		require.NotEmpty(t, proof)
+
+		hash, search := root.Bytes(), bytesToHexNibbles(key)
+
+		for i, data := range proof {
+			require.Equal(t, hash, crypto.Keccak256(data))
+
+			node, err := parseNode(data, storage)
+			require.NoError(t, err)
+
+			hash, search = nextProofNode(node, search)
+			if i < len(proof)-1 {
+				require.NotNil(t, hash)
+			}
+		}
+
+		require.Nil(t, hash)
+	}
+
+	t.Run("existing keys", func(t *testing.T) {
+		for _, key := range keys {
+			proof, err := Prove(root, key, storage)
+			require.NoError(t, err)
+
+			verifyProof(key, proof)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		key := crypto.Keccak256([]byte("missing"))
+
+		proof, err := Prove(root, key, storage)
+		require.NoError(t, err)
+
+		verifyProof(key, proof)
+	})
+
+	t.Run("empty trie", func(t *testing.T) {
+		proof, err := Prove(types.EmptyRootHash, keys[0], storage)
+		require.NoError(t, err)
+		require.Empty(t, proof)
+	})
+
+	t.Run("unknown root", func(t *testing.T) {
+		_, err := Prove(types.StringToHash("0x1"), keys[0], storage)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,282 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// pruneBatchSize is the number of trie nodes deleted in a single batch
+const pruneBatchSize = 10_000
+
+// ErrPruningInProgress is returned when a pruning is requested while another one is still running
+var ErrPruningInProgress = errors.New("state pruning already in progress")
+
+// ErrStateRootNotFound is returned when the state root of a retained block can't be resolved
+var ErrStateRootNotFound = errors.New("state root of the retained block not found")
+
+// PruneResult holds the outcome of a single pruning
+type PruneResult struct {
+	// Retained is the number of trie nodes reachable from the retained state roots
+	Retained uint64
+	// Deleted is the number of removed trie nodes
+	Deleted uint64
+}
+
+// Pruner is a Storage wrapper which removes the trie nodes that are not reachable
+// from a given set of retained state roots (mark and sweep).
+// The pruner keeps track of the trie nodes written through it since the previous pruning,
+// so that the states committed in the meantime (e.g. the ones of the blocks which are not
+// yet inserted into the chain) are left intact. Contract code is never removed.
+type Pruner struct {
+	Storage
+
+	logger hclog.Logger
+
+	// lock guards written and protected
+	lock sync.Mutex
+	// written holds the trie nodes written since the last pruning has started
+	written map[types.Hash]struct{}
+	// protected holds the trie nodes written before the running pruning has started
+	protected map[types.Hash]struct{}
+
+	running atomic.Bool
+}
+
+// NewPruner wraps the given storage with a pruner
+func NewPruner(storage Storage, logger hclog.Logger) *Pruner {
+	return &Pruner{
+		Storage: storage,
+		logger:  logger.Named("state-pruner"),
+		written: make(map[types.Hash]struct{}),
+	}
+}
+
+// Put stores the key value pair and keeps track of it
+func (p *Pruner) Put(k, v []byte) error {
+	p.track(k)
+
+	return p.Storage.Put(k, v)
+}
+
+// Batch returns a batch which keeps track of the written keys
+func (p *Pruner) Batch() Batch {
+	return &prunerBatch{Batch: p.Storage.Batch(), pruner: p}
+}
+
+// Prune removes all the trie nodes which are not reachable from the given state roots.
+// Missing roots are skipped, since there is nothing to retain for them.
+func (p *Pruner) Prune(roots []types.Hash) (*PruneResult, error) {
+	if !p.running.CompareAndSwap(false, true) {
+		return nil, ErrPruningInProgress
+	}
+	defer p.running.Store(false)
+
+	p.lock.Lock()
+	p.protected, p.written = p.written, make(map[types.Hash]struct{})
+	p.lock.Unlock()
+
+	defer func() {
+		p.lock.Lock()
+		p.protected = nil
+		p.lock.Unlock()
+	}()
+
+	marked := make(map[types.Hash]struct{})
+
+	for _, root := range roots {
+		if root == types.EmptyRootHash || root == types.ZeroHash {
+			continue
+		}
+
+		if ok, err := p.Storage.Has(root.Bytes()); err != nil {
+			return nil, err
+		} else if !ok {
+			p.logger.Warn("retained state root not found", "root", root)
+
+			continue
+		}
+
+		if err := p.markNode(root.Bytes(), marked, false); err != nil {
+			return nil, fmt.Errorf("failed to mark state %s: %w", root, err)
+		}
+	}
+
+	p.logger.Debug("trie nodes marked", "retained", len(marked))
+
+	deleted, err := p.sweep(marked)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PruneResult{
+		Retained: uint64(len(marked)),
+		Deleted:  deleted,
+	}, nil
+}
+
+// track records the written trie node
+func (p *Pruner) track(k []byte) {
+	if len(k) != types.HashLength {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.written[types.BytesToHash(k)] = struct{}{}
+}
+
+// markNode marks the stored node with the given hash and all the nodes reachable from it.
+// For the state trie, the storage tries of the accounts are marked as well
+func (p *Pruner) markNode(hash []byte, marked map[types.Hash]struct{}, isStorage bool) error {
+	walker := &trieWalker{
+		storage: p.Storage,
+		visitNode: func(hash, _ []byte) (bool, error) {
+			key := types.BytesToHash(hash)
+			if _, ok := marked[key]; ok {
+				// the whole subtrie is already marked
+				return false, nil
+			}
+
+			marked[key] = struct{}{}
+
+			return true, nil
+		},
+	}
+
+	return walker.walkNode(hash, isStorage)
+}
+
+// sweep removes all the trie nodes which are not marked and returns their number
+func (p *Pruner) sweep(marked map[types.Hash]struct{}) (uint64, error) {
+	var (
+		deleted  uint64
+		flushErr error
+		pending  = make([]types.Hash, 0, pruneBatchSize)
+	)
+
+	iterErr := p.Storage.Iterate(func(k, _ []byte) bool {
+		// the trie nodes are keyed by their hash, anything else (e.g. code) is kept
+		if len(k) != types.HashLength {
+			return true
+		}
+
+		key := types.BytesToHash(k)
+		if _, ok := marked[key]; ok {
+			return true
+		}
+
+		if pending = append(pending, key); len(pending) < pruneBatchSize {
+			return true
+		}
+
+		n, err := p.deleteNodes(pending)
+		if err != nil {
+			flushErr = err
+
+			return false
+		}
+
+		deleted += n
+		pending = pending[:0]
+
+		return true
+	})
+	if iterErr != nil {
+		return deleted, iterErr
+	}
+
+	if flushErr != nil {
+		return deleted, flushErr
+	}
+
+	n, err := p.deleteNodes(pending)
+
+	return deleted + n, err
+}
+
+// deleteNodes removes the given trie nodes, except the recently written ones.
+// The lock is held while writing, so a node can't be re-written between the check and the removal
+func (p *Pruner) deleteNodes(keys []types.Hash) (uint64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var deleted uint64
+
+	batch := p.Storage.Batch()
+
+	for _, key := range keys {
+		if _, ok := p.written[key]; ok {
+			continue
+		}
+
+		if _, ok := p.protected[key]; ok {
+			continue
+		}
+
+		batch.Delete(key.Bytes())
+
+		deleted++
+	}
+
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// prunerBatch is a Batch which keeps track of the written trie nodes
+type prunerBatch struct {
+	Batch
+
+	pruner *Pruner
+}
+
+func (b *prunerBatch) Put(k, v []byte) {
+	b.pruner.track(k)
+	b.Batch.Put(k, v)
+}
+
+// RetainedStateRoots returns the state roots which have to be retained when only the states
+// of the last retention blocks are kept. The genesis state is always retained.
+// A block whose state root can't be resolved aborts the pruning, since its state would be lost otherwise
+func RetainedStateRoots(
+	head, retention uint64,
+	getStateRoot func(number uint64) (types.Hash, bool),
+) ([]types.Hash, error) {
+	from := uint64(1)
+	if head >= retention {
+		from = head - retention + 1
+	}
+
+	roots := make([]types.Hash, 0, retention+1)
+
+	root, ok := getStateRoot(0)
+	if !ok {
+		return nil, fmt.Errorf("%w: block 0", ErrStateRootNotFound)
+	}
+
+	roots = append(roots, root)
+
+	for number := from; number <= head; number++ {
+		root, ok := getStateRoot(number)
+		if !ok {
+			return nil, fmt.Errorf("%w: block %d", ErrStateRootNotFound, number)
+		}
+
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,128 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	t.Parallel()
+
+	pruner := NewPruner(NewMemoryStorage(), hclog.NewNullLogger())
+	st := NewState(pruner)
+
+	addr := types.StringToAddress("1")
+	code := []byte{0x1, 0x2, 0x3}
+	codeHash := types.BytesToHash(hashit(code))
+
+	require.NoError(t, st.SetCode(codeHash, code))
+
+	// commits a new state on top of the given root, which updates the account balance and storage slot
+	commit := func(root types.Hash, balance int64, slot, value types.Hash) types.Hash {
+		t.Helper()
+
+		snap, err := st.NewSnapshot(root)
+		require.NoError(t, err)
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		accountRoot := types.EmptyRootHash
+		if account != nil {
+			accountRoot = account.Root
+		}
+
+		_, newRoot, err := snap.Commit([]*state.Object{
+			{
+				Address:  addr,
+				Balance:  big.NewInt(balance),
+				CodeHash: codeHash,
+				Root:     accountRoot,
+				Storage:  []*state.StorageObject{{Key: slot.Bytes(), Val: value.Bytes()}},
+			},
+		})
+		require.NoError(t, err)
+
+		return types.BytesToHash(newRoot)
+	}
+
+	slot1, slot2 := types.StringToHash("1"), types.StringToHash("2")
+
+	root1 := commit(types.EmptyRootHash, 1, slot1, types.StringToHash("1"))
+	root2 := commit(root1, 2, slot1, types.StringToHash("2"))
+	root3 := commit(root2, 3, slot2, types.StringToHash("3"))
+
+	// nodes written since the previous pruning are not removed
+	res, err := pruner.Prune([]types.Hash{root3})
+	require.NoError(t, err)
+	require.Zero(t, res.Deleted)
+
+	res, err = pruner.Prune([]types.Hash{root3})
+	require.NoError(t, err)
+	require.NotZero(t, res.Deleted)
+	require.NotZero(t, res.Retained)
+
+	st.PurgeCache()
+
+	// pruned states are not available anymore
+	for _, root := range []types.Hash{root1, root2} {
+		_, err := st.NewSnapshot(root)
+		require.ErrorIs(t, err, state.ErrStateNotAvailable)
+	}
+
+	// retained state is intact
+	checked, err := HashChecker(root3.Bytes(), pruner)
+	require.NoError(t, err)
+	require.Equal(t, root3, checked)
+
+	snap, err := st.NewSnapshot(root3)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), account.Balance)
+	require.Equal(t, types.StringToHash("2"), snap.GetStorage(addr, account.Root, slot1))
+	require.Equal(t, types.StringToHash("3"), snap.GetStorage(addr, account.Root, slot2))
+
+	// code is never pruned
+	_, ok := st.GetCode(codeHash)
+	require.True(t, ok)
+
+	// nothing left to prune
+	res, err = pruner.Prune([]types.Hash{root3})
+	require.NoError(t, err)
+	require.Zero(t, res.Deleted)
+}
+
+func TestRetainedStateRoots(t *testing.T) {
+	t.Parallel()
+
+	getStateRoot := func(number uint64) (types.Hash, bool) {
+		return types.BytesToHash(big.NewInt(int64(number + 1)).Bytes()), true
+	}
+
+	roots, err := RetainedStateRoots(10, 3, getStateRoot)
+	require.NoError(t, err)
+	require.Equal(t, []types.Hash{
+		types.BytesToHash([]byte{1}),
+		types.BytesToHash([]byte{9}),
+		types.BytesToHash([]byte{10}),
+		types.BytesToHash([]byte{11}),
+	}, roots)
+
+	// a retained block which can't be resolved aborts the pruning
+	_, err = RetainedStateRoots(10, 3, func(number uint64) (types.Hash, bool) {
+		if number == 9 {
+			return types.ZeroHash, false
+		}
+
+		return getStateRoot(number)
+	})
+	require.ErrorIs(t, err, ErrStateRootNotFound)
+}
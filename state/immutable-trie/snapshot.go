@@ -70,6 +70,16 @@ func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	return &account, nil
 }
 
+// GetAccountProof returns the merkle proof of the given account in the state trie
+func (s *Snapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	return Prove(s.GetRootHash(), crypto.Keccak256(addr.Bytes()), s.state.storage)
+}
+
+// GetStorageProof returns the merkle proof of the given storage slot in the storage trie with the given root
+func (s *Snapshot) GetStorageProof(root types.Hash, rawkey types.Hash) ([][]byte, error) {
+	return Prove(root, crypto.Keccak256(rawkey.Bytes()), s.state.storage)
+}
+
 func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return s.state.GetCode(hash)
 }
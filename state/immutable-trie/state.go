@@ -119,7 +119,7 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("state not found at hash %s", root)
+		return nil, fmt.Errorf("%w at hash %s", state.ErrStateNotAvailable, root)
 	}
 
 	t := &Trie{
@@ -132,3 +132,8 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// PurgeCache drops all the cached tries, so that pruned states are not served from memory
+func (s *State) PurgeCache() {
+	s.cache.Purge()
+}
@@ -0,0 +1,303 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errInvalidPath = errors.New("invalid trie path")
+
+// StateRangeVisitor receives the items of a state range
+type StateRangeVisitor struct {
+	// Proof is called for the stored nodes on the way from the root to the subtrie
+	Proof func(node []byte) error
+	// Node is called for the stored nodes of the subtrie, including the storage tries of its accounts
+	Node func(node []byte) error
+	// Code is called for the contract codes of the accounts of the subtrie
+	Code func(code []byte) error
+}
+
+// WalkStateRange traverses the subtrie under the given path (nibbles) of the state with the given root.
+// An empty path stands for the whole state. Stored nodes which are shared by several
+// storage tries are visited only once
+func WalkStateRange(root types.Hash, path []byte, storage Storage, visitor *StateRangeVisitor) error {
+	for _, nibble := range path {
+		if nibble > 0xf {
+			return errInvalidPath
+		}
+	}
+
+	data, ok, err := storage.Get(root.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w at hash %s", state.ErrStateNotAvailable, root)
+	}
+
+	visited := make(map[types.Hash]struct{})
+
+	walker := &trieWalker{
+		storage: storage,
+		visitNode: func(hash, data []byte) (bool, error) {
+			key := types.BytesToHash(hash)
+			if _, ok := visited[key]; ok {
+				return false, nil
+			}
+
+			visited[key] = struct{}{}
+
+			return true, visitor.Node(data)
+		},
+		visitAccount: func(account *state.Account) error {
+			codeHash := types.BytesToHash(account.CodeHash)
+			if len(account.CodeHash) == 0 || codeHash == types.EmptyCodeHash {
+				return nil
+			}
+
+			code, ok := storage.GetCode(codeHash)
+			if !ok {
+				return fmt.Errorf("code %s not found", codeHash)
+			}
+
+			return visitor.Code(code)
+		},
+	}
+
+	if len(path) == 0 {
+		return walker.walkNode(root.Bytes(), false)
+	}
+
+	if err := visitor.Proof(data); err != nil {
+		return err
+	}
+
+	node, err := parseNode(data, storage)
+	if err != nil {
+		return err
+	}
+
+	return walkSubtrie(walker, visitor, node, path)
+}
+
+// walkSubtrie descends along the path, reporting the stored nodes on the way as proof,
+// and traverses the subtrie the path leads to
+func walkSubtrie(walker *trieWalker, visitor *StateRangeVisitor, node Node, path []byte) error {
+	if len(path) == 0 {
+		return walker.walkChildren(node, false)
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if !n.hash {
+			// the key of the leaf ends before the path
+			return nil
+		}
+
+		data, ok, err := walker.storage.Get(n.buf)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("trie node %s not found", types.BytesToHash(n.buf))
+		}
+
+		if err := visitor.Proof(data); err != nil {
+			return err
+		}
+
+		child, err := parseNode(data, walker.storage)
+		if err != nil {
+			return err
+		}
+
+		return walkSubtrie(walker, visitor, child, path)
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		if len(path) <= len(key) {
+			if !bytes.HasPrefix(key, path) {
+				return nil
+			}
+
+			// the whole short node lies under the path
+			return walker.walkChildren(n, false)
+		}
+
+		if !bytes.HasPrefix(path, key) {
+			return nil
+		}
+
+		return walkSubtrie(walker, visitor, n.child, path[len(key):])
+
+	case *FullNode:
+		return walkSubtrie(walker, visitor, n.children[path[0]], path[1:])
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// VerifyStateRangeProof checks that the proof of the state range under the given path (nibbles) is the one
+// WalkStateRange reports: it starts at the state root and each of its nodes is the stored node
+// the previous one leads to along the path, up to the subtrie of the range or to the point
+// where the path diverges from the trie
+func VerifyStateRangeProof(root types.Hash, path []byte, proof [][]byte) error {
+	for _, nibble := range path {
+		if nibble > 0xf {
+			return errInvalidPath
+		}
+	}
+
+	if len(path) == 0 {
+		if len(proof) > 0 {
+			return errors.New("unexpected proof of the whole state")
+		}
+
+		return nil
+	}
+
+	hash := root.Bytes()
+
+	for i, data := range proof {
+		if hash == nil {
+			return fmt.Errorf("proof node %d is beyond the path", i)
+		}
+
+		if !bytes.Equal(hashit(data), hash) {
+			return fmt.Errorf("proof node %d doesn't match the hash %s", i, types.BytesToHash(hash))
+		}
+
+		node, err := parseNode(data, nil)
+		if err != nil {
+			return fmt.Errorf("proof node %d: %w", i, err)
+		}
+
+		hash, path = nextStateRangeNode(node, path)
+	}
+
+	if hash != nil {
+		return fmt.Errorf("proof is missing the node %s", types.BytesToHash(hash))
+	}
+
+	return nil
+}
+
+// nextStateRangeNode descends along the path within the given node (and its embedded children)
+// the way walkSubtrie does. It returns the hash of the next stored node on the path and the remaining
+// part of the path, or nil hash if the proof ends within the given node
+func nextStateRangeNode(node Node, path []byte) ([]byte, []byte) {
+	for len(path) > 0 {
+		switch n := node.(type) {
+		case *ValueNode:
+			if !n.hash {
+				return nil, nil
+			}
+
+			return n.buf, path
+
+		case *ShortNode:
+			key := n.key
+			if hasTerminator(key) {
+				key = key[:len(key)-1]
+			}
+
+			if len(path) <= len(key) || !bytes.HasPrefix(path, key) {
+				return nil, nil
+			}
+
+			node, path = n.child, path[len(key):]
+
+		case *FullNode:
+			node, path = n.children[path[0]], path[1:]
+
+		default:
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// VerifyState checks that the whole state with the given root is present in the storage:
+// all the trie nodes, the storage tries of the accounts and their contract codes.
+// The state trie and the storage tries must hash to their roots
+func VerifyState(root types.Hash, storage Storage) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	visited := make(map[types.Hash]struct{})
+	storageRoots := make(map[types.Hash]struct{})
+
+	walker := &trieWalker{
+		storage: storage,
+		visitNode: func(hash, _ []byte) (bool, error) {
+			key := types.BytesToHash(hash)
+			if _, ok := visited[key]; ok {
+				return false, nil
+			}
+
+			visited[key] = struct{}{}
+
+			return true, nil
+		},
+		visitAccount: func(account *state.Account) error {
+			codeHash := types.BytesToHash(account.CodeHash)
+			if len(account.CodeHash) != 0 && codeHash != types.EmptyCodeHash {
+				if _, ok := storage.GetCode(codeHash); !ok {
+					return fmt.Errorf("code %s not found", codeHash)
+				}
+			}
+
+			if account.Root != types.EmptyRootHash && account.Root != types.ZeroHash {
+				storageRoots[account.Root] = struct{}{}
+			}
+
+			return nil
+		},
+	}
+
+	if err := walker.walkNode(root.Bytes(), false); err != nil {
+		return err
+	}
+
+	if err := checkTrieHash(root, storage); err != nil {
+		return err
+	}
+
+	for storageRoot := range storageRoots {
+		if err := checkTrieHash(storageRoot, storage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkTrieHash checks that the trie with the given root hashes to it
+func checkTrieHash(root types.Hash, storage Storage) error {
+	hash, err := HashChecker(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if hash != root {
+		return fmt.Errorf("trie hash mismatch, expected %s but got %s", root, hash)
+	}
+
+	return nil
+}
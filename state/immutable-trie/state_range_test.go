@@ -0,0 +1,154 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestWalkStateRange(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+
+	code := []byte{0x1, 0x2, 0x3}
+	codeHash := types.BytesToHash(hashit(code))
+
+	require.NoError(t, st.SetCode(codeHash, code))
+
+	objects := make([]*state.Object, 0, 64)
+
+	for i := 0; i < 64; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i + 1)),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%4 == 0 {
+			obj.CodeHash = codeHash
+			obj.Storage = []*state.StorageObject{
+				{Key: types.StringToHash("1").Bytes(), Val: types.StringToHash("1").Bytes()},
+				{Key: big.NewInt(int64(i + 2)).Bytes(), Val: types.StringToHash("2").Bytes()},
+			}
+		}
+
+		objects = append(objects, obj)
+	}
+
+	snap, err := st.NewSnapshot(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	_, rootBytes, err := snap.Commit(objects)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rootBytes)
+
+	// copies the state range into the target storage, checking the proof
+	fetch := func(t *testing.T, target Storage, path []byte) {
+		t.Helper()
+
+		var proof [][]byte
+
+		require.NoError(t, WalkStateRange(root, path, storage, &StateRangeVisitor{
+			Proof: func(node []byte) error {
+				proof = append(proof, node)
+
+				return target.Put(hashit(node), node)
+			},
+			Node: func(node []byte) error {
+				return target.Put(hashit(node), node)
+			},
+			Code: func(code []byte) error {
+				return target.SetCode(types.BytesToHash(hashit(code)), code)
+			},
+		}))
+
+		if len(path) > 0 {
+			require.NotEmpty(t, proof)
+			require.Equal(t, root.Bytes(), hashit(proof[0]))
+		}
+
+		require.NoError(t, VerifyStateRangeProof(root, path, proof))
+	}
+
+	t.Run("whole state", func(t *testing.T) {
+		t.Parallel()
+
+		target := NewMemoryStorage()
+		fetch(t, target, nil)
+
+		require.NoError(t, VerifyState(root, target))
+	})
+
+	t.Run("state split into ranges", func(t *testing.T) {
+		t.Parallel()
+
+		target := NewMemoryStorage()
+
+		for i := 0; i < 256; i++ {
+			fetch(t, target, []byte{byte(i >> 4), byte(i & 0xf)})
+		}
+
+		require.NoError(t, VerifyState(root, target))
+
+		snap, err := NewState(target).NewSnapshot(root)
+		require.NoError(t, err)
+
+		account, err := snap.GetAccount(objects[4].Address)
+		require.NoError(t, err)
+		require.Equal(t, objects[4].Balance, account.Balance)
+		require.Equal(t, types.StringToHash("1"), snap.GetStorage(objects[4].Address, account.Root, types.StringToHash("1")))
+	})
+
+	t.Run("incomplete state", func(t *testing.T) {
+		t.Parallel()
+
+		target := NewMemoryStorage()
+
+		for i := 0; i < 15; i++ {
+			fetch(t, target, []byte{byte(i)})
+		}
+
+		require.Error(t, VerifyState(root, target))
+	})
+
+	t.Run("proof of another path", func(t *testing.T) {
+		t.Parallel()
+
+		var proof [][]byte
+
+		require.NoError(t, WalkStateRange(root, []byte{0x1, 0x2}, storage, &StateRangeVisitor{
+			Proof: func(node []byte) error {
+				proof = append(proof, node)
+
+				return nil
+			},
+			Node: func([]byte) error { return nil },
+			Code: func([]byte) error { return nil },
+		}))
+
+		require.Error(t, VerifyStateRangeProof(root, []byte{0x3, 0x4}, proof))
+		require.Error(t, VerifyStateRangeProof(root, []byte{0x1, 0x2}, proof[:1]))
+		require.Error(t, VerifyStateRangeProof(root, []byte{0x1, 0x2}, append(proof, proof[0])))
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, WalkStateRange(root, []byte{0x10}, storage, &StateRangeVisitor{}), errInvalidPath)
+	})
+
+	t.Run("unknown root", func(t *testing.T) {
+		t.Parallel()
+
+		err := WalkStateRange(types.StringToHash("1"), nil, storage, &StateRangeVisitor{})
+		require.ErrorIs(t, err, state.ErrStateNotAvailable)
+	})
+}
@@ -26,6 +26,8 @@ var (
 type Batch interface {
 	// Put puts key and value into batch. It can not return error because actual writing is done with Write method
 	Put(k, v []byte)
+	// Delete removes the key from the database once the batch is written
+	Delete(k []byte)
 	// Write writes all the key values pair previosly putted with Put method to the database
 	Write() error
 }
@@ -40,6 +42,8 @@ type Storage interface {
 	GetCode(hash types.Hash) ([]byte, bool)
 	Stat(property string) (string, error)
 	Compact(start []byte, limit []byte) error
+	// Iterate calls fn for each key/value pair of the storage, until fn returns false
+	Iterate(fn func(k, v []byte) bool) error
 
 	Close() error
 }
@@ -59,6 +63,10 @@ func (b *KVBatch) Put(k, v []byte) {
 	b.batch.Put(k, v)
 }
 
+func (b *KVBatch) Delete(k []byte) {
+	b.batch.Delete(k)
+}
+
 func (b *KVBatch) Write() error {
 	return b.db.Write(b.batch, nil)
 }
@@ -126,6 +134,21 @@ func (kv *KVStorage) Compact(start []byte, limit []byte) error {
 	return kv.db.CompactRange(util.Range{Start: start, Limit: limit})
 }
 
+// Iterate calls fn for each key/value pair of the storage, until fn returns false.
+// The iteration is done over a consistent snapshot of the database
+func (kv *KVStorage) Iterate(fn func(k, v []byte) bool) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -215,6 +238,37 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+// Iterate calls fn for each key/value pair of the storage, until fn returns false.
+// The iteration is done over a copy of the storage, so fn is free to modify it
+func (m *memStorage) Iterate(fn func(k, v []byte) bool) error {
+	m.l.Lock()
+
+	keys := make([]string, 0, len(m.db))
+	for k := range m.db {
+		keys = append(keys, k)
+	}
+
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = m.db[k]
+	}
+
+	m.l.Unlock()
+
+	for i, k := range keys {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if !fn(key, values[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -228,6 +282,13 @@ func (m *memBatch) Put(p, v []byte) {
 	(*m.db)[hex.EncodeToHex(p)] = buf
 }
 
+func (m *memBatch) Delete(p []byte) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	delete(*m.db, hex.EncodeToHex(p))
+}
+
 func (m *memBatch) Write() error {
 	return nil
 }
@@ -239,6 +300,13 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 		return nil, false, err
 	}
 
+	n, err := parseNode(data, storage)
+
+	return n, err == nil, err
+}
+
+// parseNode decodes a RLP encoded trie node as it is kept in the storage
+func parseNode(data []byte, storage Storage) (Node, error) {
 	// NOTE. We dont need to make copies of the bytes because the nodes
 	// take the reference from data itself which is a safe copy.
 	p := parserPool.Get()
@@ -246,16 +314,14 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 
 	v, err := p.Parse(data)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	if v.Type() != fastrlp.TypeArray {
-		return nil, false, fmt.Errorf("storage item should be an array")
+		return nil, fmt.Errorf("storage item should be an array")
 	}
 
-	n, err := decodeNode(v, storage)
-
-	return n, err == nil, err
+	return decodeNode(v, storage)
 }
 
 func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
@@ -0,0 +1,91 @@
+package itrie
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// trieWalker traverses the stored nodes of a trie.
+// For the state trie, the storage tries of the accounts are traversed as well
+type trieWalker struct {
+	storage Storage
+
+	// visitNode is called for each stored node, its subtrie is skipped if false is returned
+	visitNode func(hash, data []byte) (bool, error)
+	// visitAccount is called for each account of the state trie (optional)
+	visitAccount func(account *state.Account) error
+}
+
+// walkNode traverses the stored node with the given hash and all the nodes reachable from it
+func (w *trieWalker) walkNode(hash []byte, isStorage bool) error {
+	data, ok, err := w.storage.Get(hash)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("trie node %s not found", types.BytesToHash(hash))
+	}
+
+	if visit, err := w.visitNode(hash, data); err != nil || !visit {
+		return err
+	}
+
+	node, err := parseNode(data, w.storage)
+	if err != nil {
+		return err
+	}
+
+	return w.walkChildren(node, isStorage)
+}
+
+// walkChildren traverses the nodes reachable from the given (already visited) node
+func (w *trieWalker) walkChildren(node Node, isStorage bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return w.walkNode(n.buf, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("can't parse account: %w", err)
+		}
+
+		if w.visitAccount != nil {
+			if err := w.visitAccount(&account); err != nil {
+				return err
+			}
+		}
+
+		if account.Root == types.EmptyRootHash || account.Root == types.ZeroHash {
+			return nil
+		}
+
+		return w.walkNode(account.Root.Bytes(), true)
+
+	case *ShortNode:
+		return w.walkChildren(n.child, isStorage)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := w.walkChildren(child, isStorage); err != nil {
+				return err
+			}
+		}
+
+		return w.walkChildren(n.value, isStorage)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
@@ -30,8 +30,9 @@ func newMockContract(value *big.Int, gas uint64, code []byte) *runtime.Contract
 type mockHost struct {
 	mock.Mock
 
-	tracer     runtime.VMTracer
-	accessList *runtime.AccessList
+	tracer           runtime.VMTracer
+	accessList       *runtime.AccessList
+	transientStorage map[types.Address]map[types.Hash]types.Hash
 }
 
 func (m *mockHost) AccountExists(addr types.Address) bool {
@@ -52,6 +53,22 @@ func (m *mockHost) SetState(
 	panic("Not implemented in tests") //nolint:gocritic
 }
 
+func (m *mockHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.transientStorage[addr][key]
+}
+
+func (m *mockHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	if m.transientStorage == nil {
+		m.transientStorage = make(map[types.Address]map[types.Hash]types.Hash)
+	}
+
+	if m.transientStorage[addr] == nil {
+		m.transientStorage[addr] = make(map[types.Hash]types.Hash)
+	}
+
+	m.transientStorage[addr][key] = value
+}
+
 func (m *mockHost) SetStorage(
 	addr types.Address,
 	key types.Hash,
@@ -390,6 +390,36 @@ func opMStore8(c *state) {
 	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
 }
 
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dst := c.pop()
+	src := c.pop()
+	length := c.pop()
+
+	if length.Sign() == 0 {
+		return
+	}
+
+	// the memory is expanded to cover both the source and the destination
+	if !c.allocateMemory(src, length) || !c.allocateMemory(dst, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	d, s := dst.Uint64(), src.Uint64()
+
+	copy(c.memory[d:d+size], c.memory[s:s+size])
+}
+
 // --- storage ---
 
 func opSload(c *state) {
@@ -501,6 +531,40 @@ func opSStore(c *state) {
 	}
 }
 
+// --- transient storage ---
+
+func opTload(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	loc := c.top()
+
+	val := c.host.GetTransientStorage(c.msg.Address, uint256ToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTstore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientStorage(c.msg.Address, key, val)
+}
+
 const sha3WordGas uint64 = 6
 
 func opSha3(c *state) {
@@ -1143,6 +1207,9 @@ func opCreate(op OpCode) instruction {
 		}
 
 		contract.Type = runtime.Create
+		if op == CREATE2 {
+			contract.Type = runtime.Create2
+		}
 
 		// Correct call
 		result := c.host.Callx(contract, c.host)
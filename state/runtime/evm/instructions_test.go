@@ -590,6 +590,72 @@ func TestMStore8(t *testing.T) {
 	assert.Equal(t, one, v.ToBig())
 }
 
+func TestMCopy(t *testing.T) {
+	t.Run("copies the memory area", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		s.push(*uint256.NewInt(0x1234)) // value
+		s.push(zero256)                 // offset
+
+		opMStore(s)
+
+		gas := s.gas
+
+		s.push(*uint256.NewInt(32)) // length
+		s.push(zero256)             // source
+		s.push(*uint256.NewInt(32)) // destination
+
+		opMCopy(s)
+		require.NoError(t, s.err)
+
+		// memory expansion by a word and copying a word
+		assert.Equal(t, uint64(6), gas-s.gas)
+
+		s.push(*uint256.NewInt(32))
+
+		opMLoad(s)
+
+		v := s.pop()
+		assert.Equal(t, uint64(0x1234), v.Uint64())
+	})
+
+	t.Run("copies the overlapping memory area", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		s.push(*uint256.NewInt(0x1234)) // value
+		s.push(zero256)                 // offset
+
+		opMStore(s)
+
+		s.push(*uint256.NewInt(32)) // length
+		s.push(zero256)             // source
+		s.push(*uint256.NewInt(16)) // destination
+
+		opMCopy(s)
+		require.NoError(t, s.err)
+
+		s.push(*uint256.NewInt(16))
+
+		opMLoad(s)
+
+		v := s.pop()
+		assert.Equal(t, uint64(0x1234), v.Uint64())
+		assert.Equal(t, 64, len(s.memory))
+	})
+
+	t.Run("Cancun disabled", func(t *testing.T) {
+		allExceptCancunFork := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+		s, closeFn := getState(&allExceptCancunFork)
+		defer closeFn()
+
+		opMCopy(s)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
 func TestSload(t *testing.T) {
 	t.Run("Istanbul", func(t *testing.T) {
 		s, closeFn := getState(&chain.ForksInTime{Istanbul: true})
@@ -736,6 +802,61 @@ func TestSStore(t *testing.T) {
 	})
 }
 
+func TestTransientStorage(t *testing.T) {
+	t.Run("stores and loads the value", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		s.host = &mockHost{}
+
+		s.push(*uint256.NewInt(2)) // value
+		s.push(one256)             // key
+
+		opTstore(s)
+		require.NoError(t, s.err)
+
+		s.push(one256)
+
+		opTload(s)
+
+		v := s.pop()
+		assert.Equal(t, uint64(2), v.Uint64())
+
+		s.push(zero256)
+
+		opTload(s)
+
+		v = s.pop()
+		assert.Equal(t, uint64(0), v.Uint64())
+	})
+
+	t.Run("TSTORE in static call", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		s.host = &mockHost{}
+		s.msg.Static = true
+
+		s.push(one256)
+		s.push(one256)
+
+		opTstore(s)
+		assert.Equal(t, errWriteProtection, s.err)
+	})
+
+	t.Run("Cancun disabled", func(t *testing.T) {
+		allExceptCancunFork := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+		s, closeFn := getState(&allExceptCancunFork)
+		defer closeFn()
+
+		s.push(one256)
+
+		opTload(s)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
 func TestBalance(t *testing.T) {
 	balance := big.NewInt(100)
 
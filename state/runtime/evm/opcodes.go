@@ -200,6 +200,15 @@ const (
 	// JUMPDEST corresponds to a possible jump destination
 	JUMPDEST = 0x5B
 
+	// TLOAD reads a (u)int256 from transient storage
+	TLOAD = 0x5C
+
+	// TSTORE writes a (u)int256 to transient storage
+	TSTORE = 0x5D
+
+	// MCOPY copies a memory area to another place in memory
+	MCOPY = 0x5E
+
 	// PUSH0 pushes a 0 constant onto the stack
 	PUSH0 = 0x5F
 
@@ -327,6 +336,9 @@ var opCodeToString = map[OpCode]string{
 	MSIZE:          "MSIZE",
 	GAS:            "GAS",
 	JUMPDEST:       "JUMPDEST",
+	TLOAD:          "TLOAD",
+	TSTORE:         "TSTORE",
+	MCOPY:          "MCOPY",
 	CREATE:         "CREATE",
 	CALL:           "CALL",
 	RETURN:         "RETURN",
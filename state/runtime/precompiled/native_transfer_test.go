@@ -96,6 +96,16 @@ func (d dummyHost) SetState(
 	d.t.Fatalf("SetState is not implemented")
 }
 
+func (d dummyHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	d.t.Fatalf("GetTransientStorage is not implemented")
+
+	return types.Hash{}
+}
+
+func (d dummyHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	d.t.Fatalf("SetTransientStorage is not implemented")
+}
+
 func (d dummyHost) SetStorage(addr types.Address, key types.Hash, value types.Hash, config *chain.ForksInTime) runtime.StorageStatus {
 	d.t.Fatalf("SetStorage is not implemented")
 
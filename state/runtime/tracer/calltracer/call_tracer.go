@@ -30,6 +30,7 @@ type Call struct {
 	GasUsed string  `json:"gasUsed"`
 	Input   string  `json:"input"`
 	Output  string  `json:"output"`
+	Error   string  `json:"error,omitempty"`
 	Calls   []*Call `json:"calls,omitempty"`
 
 	parent   *Call
@@ -37,6 +38,10 @@ type Call struct {
 }
 
 type CallTracer struct {
+	// RecordErrors keeps tracing when a call fails, reporting the error in the call
+	// instead of terminating the whole trace
+	RecordErrors bool
+
 	call               *Call
 	activeCall         *Call
 	activeGas          uint64
@@ -135,11 +140,15 @@ func (c *CallTracer) CallEnd(depth int, output []byte, err error) {
 	c.activeCall.GasUsed = hex.EncodeUint64(gasUsed)
 	c.activeGas = 0
 
+	if err != nil && c.RecordErrors {
+		c.activeCall.Error = err.Error()
+	}
+
 	if depth > 1 {
 		c.activeCall = c.activeCall.parent
 	}
 
-	if err != nil {
+	if err != nil && !c.RecordErrors {
 		c.Cancel(err)
 	}
 }
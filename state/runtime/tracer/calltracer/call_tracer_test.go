@@ -285,4 +285,27 @@ func TestCallTracer_CallEnd(t *testing.T) {
 		require.Equal(t, "0x0", tracer.activeCall.GasUsed)
 		require.Equal(t, uint64(500), tracer.activeCall.startGas)
 	})
+
+	t.Run("call_end_when_depth_is_2_error_recorded", func(t *testing.T) {
+		t.Parallel()
+
+		parent := &Call{startGas: 2000}
+
+		tracer := &CallTracer{RecordErrors: true}
+		tracer.activeAvailableGas = 500
+		tracer.activeCall = &Call{
+			startGas: 1000,
+			parent:   parent,
+		}
+
+		child := tracer.activeCall
+
+		tracer.CallEnd(2, output, err)
+
+		require.Equal(t, parent, tracer.activeCall)
+		require.Equal(t, err.Error(), child.Error)
+		require.Equal(t, hex.EncodeUint64(500), child.GasUsed)
+		require.False(t, tracer.stop)
+		require.NoError(t, tracer.reason)
+	})
 }
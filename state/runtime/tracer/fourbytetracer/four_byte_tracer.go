@@ -0,0 +1,108 @@
+package fourbytetracer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+)
+
+const selectorLength = 4
+
+// FourByteTracer counts the function selectors of the calls made by the transaction,
+// along with the size of the call data. The result maps "selector-size" to the number of calls,
+// the calls to the precompiled contracts and the contract creations are not counted
+type FourByteTracer struct {
+	ids         map[string]int
+	precompiles map[types.Address]struct{}
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewFourByteTracer() *FourByteTracer {
+	precompiles := make(map[types.Address]struct{})
+	for _, addr := range precompiled.NewPrecompiled().Addrs {
+		precompiles[addr] = struct{}{}
+	}
+
+	return &FourByteTracer{
+		ids:         make(map[string]int),
+		precompiles: precompiles,
+	}
+}
+
+func (f *FourByteTracer) Cancel(err error) {
+	f.cancelLock.Lock()
+	defer f.cancelLock.Unlock()
+
+	f.reason = err
+	f.stop = true
+}
+
+func (f *FourByteTracer) cancelled() bool {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	return f.stop
+}
+
+func (f *FourByteTracer) Clear() {
+	f.ids = make(map[string]int)
+}
+
+func (f *FourByteTracer) GetResult() (interface{}, error) {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	if f.reason != nil {
+		return nil, f.reason
+	}
+
+	return f.ids, nil
+}
+
+func (f *FourByteTracer) TxStart(gasLimit uint64) {
+}
+
+func (f *FourByteTracer) TxEnd(gasLeft uint64) {
+}
+
+func (f *FourByteTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if f.cancelled() || len(input) < selectorLength {
+		return
+	}
+
+	if typ := runtime.CallType(callType); typ == runtime.Create || typ == runtime.Create2 {
+		return
+	}
+
+	if _, ok := f.precompiles[to]; ok {
+		return
+	}
+
+	id := fmt.Sprintf("%s-%d", hex.EncodeToHex(input[:selectorLength]), len(input)-selectorLength)
+	f.ids[id]++
+}
+
+func (f *FourByteTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (f *FourByteTracer) CaptureState(memory []byte, stack []uint256.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if f.cancelled() {
+		state.Halt()
+	}
+}
+
+func (f *FourByteTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
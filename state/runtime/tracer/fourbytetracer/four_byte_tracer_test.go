@@ -0,0 +1,60 @@
+package fourbytetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestFourByteTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from     = types.StringToAddress("1")
+		to       = types.StringToAddress("1000")
+		selector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	)
+
+	f := NewFourByteTracer()
+
+	f.CallStart(1, from, to, 0, 100000, big.NewInt(0), append(selector, make([]byte, 64)...))
+	f.CallStart(2, to, to, 0, 50000, big.NewInt(0), append(selector, make([]byte, 64)...))
+	f.CallStart(2, to, to, 3, 50000, big.NewInt(0), selector)
+
+	// too short input, contract creation and precompile call are not counted
+	f.CallStart(2, to, to, 0, 50000, big.NewInt(0), selector[:3])
+	f.CallStart(2, to, types.StringToAddress("2000"), int(runtime.Create), 50000, big.NewInt(0), selector)
+	f.CallStart(2, to, types.StringToAddress("3000"), int(runtime.Create2), 50000, big.NewInt(0), selector)
+	f.CallStart(2, to, types.StringToAddress("2"), 0, 50000, big.NewInt(0), selector)
+
+	res, err := f.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		"0xa9059cbb-64": 2,
+		"0xa9059cbb-0":  1,
+	}, res)
+
+	f.Clear()
+
+	res, err = f.GetResult()
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
+func TestFourByteTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	f := NewFourByteTracer()
+	f.Cancel(err)
+
+	res, resErr := f.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+}
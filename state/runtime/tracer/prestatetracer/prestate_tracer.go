@@ -0,0 +1,297 @@
+package prestatetracer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+)
+
+var _ tracer.StateTracer = (*PrestateTracer)(nil)
+
+type Config struct {
+	DiffMode bool // report the accounts changed by the transaction, with their states before and after it
+}
+
+// Account is the state of an account
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// exists returns false if the account is empty, meaning it doesn't exist in the state
+func (a *Account) exists() bool {
+	return a.Balance != "0x0" || a.Nonce != 0 || a.Code != ""
+}
+
+// DiffResult is the result of the tracer in the diff mode
+type DiffResult struct {
+	Post map[types.Address]*Account `json:"post"`
+	Pre  map[types.Address]*Account `json:"pre"`
+}
+
+// PrestateTracer reports the state of the accounts touched by the transaction before its execution,
+// or the changes made by the transaction in the diff mode
+type PrestateTracer struct {
+	Config Config
+
+	preState  tracer.StateReader
+	postState tracer.StateReader
+
+	// the touched accounts along with their touched storage slots
+	accounts map[types.Address]map[types.Hash]struct{}
+	created  map[types.Address]struct{}
+	deleted  map[types.Address]struct{}
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:   config,
+		accounts: make(map[types.Address]map[types.Hash]struct{}),
+		created:  make(map[types.Address]struct{}),
+		deleted:  make(map[types.Address]struct{}),
+	}
+}
+
+func (p *PrestateTracer) Cancel(err error) {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+
+	p.reason = err
+	p.stop = true
+}
+
+func (p *PrestateTracer) cancelled() bool {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	return p.stop
+}
+
+func (p *PrestateTracer) Clear() {
+	p.preState = nil
+	p.postState = nil
+	p.accounts = make(map[types.Address]map[types.Hash]struct{})
+	p.created = make(map[types.Address]struct{})
+	p.deleted = make(map[types.Address]struct{})
+}
+
+func (p *PrestateTracer) GetResult() (interface{}, error) {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	if p.reason != nil {
+		return nil, p.reason
+	}
+
+	pre := make(map[types.Address]*Account, len(p.accounts))
+
+	if p.preState != nil {
+		for addr, slots := range p.accounts {
+			pre[addr] = newAccount(p.preState, addr, slots)
+		}
+	}
+
+	var result interface{} = pre
+	if p.Config.DiffMode {
+		result = p.diff(pre)
+	}
+
+	// the contracts created by the transaction have no state before it
+	for addr := range p.created {
+		if account, ok := pre[addr]; ok && !account.exists() {
+			delete(pre, addr)
+		}
+	}
+
+	return result, nil
+}
+
+// diff leaves only the accounts and the fields changed by the transaction
+func (p *PrestateTracer) diff(pre map[types.Address]*Account) *DiffResult {
+	result := &DiffResult{
+		Post: make(map[types.Address]*Account),
+		Pre:  pre,
+	}
+
+	if p.postState == nil {
+		return result
+	}
+
+	for addr, preAccount := range pre {
+		// the destructed accounts have no state after the transaction
+		if _, ok := p.deleted[addr]; ok {
+			continue
+		}
+
+		var (
+			postAccount = newAccount(p.postState, addr, p.accounts[addr])
+			changes     = &Account{}
+			modified    = false
+		)
+
+		if postAccount.Balance != preAccount.Balance {
+			modified = true
+			changes.Balance = postAccount.Balance
+		}
+
+		if postAccount.Nonce != preAccount.Nonce {
+			modified = true
+			changes.Nonce = postAccount.Nonce
+		}
+
+		if postAccount.Code != preAccount.Code {
+			modified = true
+			changes.Code = postAccount.Code
+		}
+
+		for key, val := range preAccount.Storage {
+			newVal := postAccount.Storage[key]
+
+			// the empty slots are omitted
+			if val == types.ZeroHash {
+				delete(preAccount.Storage, key)
+			}
+
+			if val == newVal {
+				delete(preAccount.Storage, key)
+
+				continue
+			}
+
+			modified = true
+
+			if newVal != types.ZeroHash {
+				if changes.Storage == nil {
+					changes.Storage = make(map[types.Hash]types.Hash)
+				}
+
+				changes.Storage[key] = newVal
+			}
+		}
+
+		if modified {
+			result.Post[addr] = changes
+		} else {
+			delete(result.Pre, addr)
+		}
+	}
+
+	return result
+}
+
+func (p *PrestateTracer) TxPreState(state tracer.StateReader, touched []types.Address) {
+	p.preState = state
+
+	for _, addr := range touched {
+		p.touchAccount(addr)
+	}
+}
+
+func (p *PrestateTracer) TxPostState(state tracer.StateReader) {
+	p.postState = state
+}
+
+func (p *PrestateTracer) TxStart(gasLimit uint64) {
+}
+
+func (p *PrestateTracer) TxEnd(gasLeft uint64) {
+}
+
+func (p *PrestateTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if p.cancelled() {
+		return
+	}
+
+	p.touchAccount(from)
+	p.touchAccount(to)
+
+	if typ := runtime.CallType(callType); typ == runtime.Create || typ == runtime.Create2 {
+		p.created[to] = struct{}{}
+	}
+}
+
+func (p *PrestateTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (p *PrestateTracer) CaptureState(memory []byte, stack []uint256.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if p.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			p.touchSlot(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH:
+		if sp >= 1 {
+			p.touchAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+
+	case evm.SELFDESTRUCT:
+		if sp >= 1 {
+			p.touchAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+
+		p.deleted[contractAddress] = struct{}{}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			p.touchAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+	}
+}
+
+func (p *PrestateTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+func (p *PrestateTracer) touchAccount(addr types.Address) {
+	if _, ok := p.accounts[addr]; !ok {
+		p.accounts[addr] = make(map[types.Hash]struct{})
+	}
+}
+
+func (p *PrestateTracer) touchSlot(addr types.Address, key types.Hash) {
+	p.touchAccount(addr)
+	p.accounts[addr][key] = struct{}{}
+}
+
+// newAccount reads the account and the given storage slots from the state
+func newAccount(state tracer.StateReader, addr types.Address, slots map[types.Hash]struct{}) *Account {
+	account := &Account{
+		Balance: hex.EncodeBig(state.GetBalance(addr)),
+		Nonce:   state.GetNonce(addr),
+	}
+
+	if code := state.GetCode(addr); len(code) > 0 {
+		account.Code = hex.EncodeToHex(code)
+	}
+
+	if len(slots) > 0 {
+		account.Storage = make(map[types.Hash]types.Hash, len(slots))
+
+		for key := range slots {
+			account.Storage[key] = state.GetState(addr, key)
+		}
+	}
+
+	return account
+}
@@ -0,0 +1,224 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockAccount struct {
+	balance int64
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+type mockState map[types.Address]*mockAccount
+
+func (m mockState) GetBalance(addr types.Address) *big.Int {
+	if a, ok := m[addr]; ok {
+		return big.NewInt(a.balance)
+	}
+
+	return big.NewInt(0)
+}
+
+func (m mockState) GetNonce(addr types.Address) uint64 {
+	if a, ok := m[addr]; ok {
+		return a.nonce
+	}
+
+	return 0
+}
+
+func (m mockState) GetCode(addr types.Address) []byte {
+	if a, ok := m[addr]; ok {
+		return a.code
+	}
+
+	return nil
+}
+
+func (m mockState) GetState(addr types.Address, key types.Hash) types.Hash {
+	if a, ok := m[addr]; ok {
+		return a.storage[key]
+	}
+
+	return types.ZeroHash
+}
+
+type mockVMState struct {
+	halted bool
+}
+
+func (m *mockVMState) Halt() {
+	m.halted = true
+}
+
+var (
+	sender   = types.StringToAddress("1")
+	coinbase = types.StringToAddress("2")
+	contract = types.StringToAddress("3")
+	callee   = types.StringToAddress("4")
+	created  = types.StringToAddress("5")
+
+	slot1 = types.StringToHash("1")
+	slot2 = types.StringToHash("2")
+)
+
+// traceTx feeds the tracer with a transaction which calls the contract, which reads and writes its storage,
+// checks the balance of the callee and creates a new contract
+func traceTx(p *PrestateTracer, pre, post mockState) {
+	p.TxPreState(pre, []types.Address{sender, coinbase})
+	p.TxStart(100000)
+	p.CallStart(1, sender, contract, 0, 100000, big.NewInt(0), nil)
+
+	vmState := &mockVMState{}
+
+	p.CaptureState(nil, []uint256.Int{*new(uint256.Int).SetBytes(slot1.Bytes())},
+		evm.SLOAD, contract, 1, nil, vmState)
+	p.CaptureState(nil, []uint256.Int{*uint256.NewInt(1), *new(uint256.Int).SetBytes(slot2.Bytes())},
+		evm.SSTORE, contract, 2, nil, vmState)
+	p.CaptureState(nil, []uint256.Int{*new(uint256.Int).SetBytes(callee.Bytes())},
+		evm.BALANCE, contract, 1, nil, vmState)
+
+	p.CallStart(2, contract, created, int(runtime.Create), 50000, big.NewInt(0), nil)
+	p.CallEnd(2, nil, nil)
+	p.CallEnd(1, nil, nil)
+	p.TxEnd(0)
+	p.TxPostState(post)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	t.Parallel()
+
+	newPreState := func() mockState {
+		return mockState{
+			sender:   {balance: 1000, nonce: 1},
+			coinbase: {balance: 10},
+			contract: {balance: 5, nonce: 1, code: []byte{0x1}, storage: map[types.Hash]types.Hash{
+				slot1: types.StringToHash("10"),
+			}},
+			callee: {balance: 7},
+		}
+	}
+
+	newPostState := func() mockState {
+		return mockState{
+			sender:   {balance: 900, nonce: 2},
+			coinbase: {balance: 110},
+			contract: {balance: 5, nonce: 2, code: []byte{0x1}, storage: map[types.Hash]types.Hash{
+				slot1: types.StringToHash("10"),
+				slot2: types.StringToHash("1"),
+			}},
+			callee:  {balance: 7},
+			created: {nonce: 1, code: []byte{0x2}},
+		}
+	}
+
+	t.Run("prestate", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPrestateTracer(Config{})
+		traceTx(p, newPreState(), newPostState())
+
+		res, err := p.GetResult()
+		require.NoError(t, err)
+		require.Equal(t, map[types.Address]*Account{
+			sender:   {Balance: "0x3e8", Nonce: 1},
+			coinbase: {Balance: "0xa"},
+			contract: {Balance: "0x5", Nonce: 1, Code: "0x01", Storage: map[types.Hash]types.Hash{
+				slot1: types.StringToHash("10"),
+				slot2: types.ZeroHash,
+			}},
+			callee: {Balance: "0x7"},
+		}, res)
+	})
+
+	t.Run("diff mode", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPrestateTracer(Config{DiffMode: true})
+		traceTx(p, newPreState(), newPostState())
+
+		res, err := p.GetResult()
+		require.NoError(t, err)
+		require.Equal(t, &DiffResult{
+			Pre: map[types.Address]*Account{
+				sender:   {Balance: "0x3e8", Nonce: 1},
+				coinbase: {Balance: "0xa"},
+				contract: {Balance: "0x5", Nonce: 1, Code: "0x01", Storage: map[types.Hash]types.Hash{}},
+			},
+			Post: map[types.Address]*Account{
+				sender:   {Balance: "0x384", Nonce: 2},
+				coinbase: {Balance: "0x6e"},
+				contract: {Nonce: 2, Storage: map[types.Hash]types.Hash{slot2: types.StringToHash("1")}},
+				created:  {Nonce: 1, Code: "0x02"},
+			},
+		}, res)
+	})
+
+	t.Run("diff mode with destructed account", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPrestateTracer(Config{DiffMode: true})
+
+		pre, post := newPreState(), newPostState()
+		post[callee].balance += post[contract].balance
+		post[contract].balance = 0
+
+		p.TxPreState(pre, nil)
+		p.CallStart(1, sender, contract, 0, 100000, big.NewInt(0), nil)
+		p.CaptureState(nil, []uint256.Int{*new(uint256.Int).SetBytes(callee.Bytes())},
+			evm.SELFDESTRUCT, contract, 1, nil, &mockVMState{})
+		p.CallEnd(1, nil, nil)
+		p.TxPostState(post)
+
+		res, err := p.GetResult()
+		require.NoError(t, err)
+
+		diff, ok := res.(*DiffResult)
+		require.True(t, ok)
+		require.Contains(t, diff.Pre, contract)
+		require.NotContains(t, diff.Post, contract)
+		require.Equal(t, &Account{Balance: "0xc"}, diff.Post[callee])
+	})
+}
+
+func TestPrestateTracer_Clear(t *testing.T) {
+	t.Parallel()
+
+	p := NewPrestateTracer(Config{})
+	p.TxPreState(mockState{}, []types.Address{sender})
+	p.CallStart(1, sender, created, int(runtime.Create), 100000, big.NewInt(0), nil)
+
+	p.Clear()
+
+	require.Nil(t, p.preState)
+	require.Empty(t, p.accounts)
+	require.Empty(t, p.created)
+}
+
+func TestPrestateTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	p := NewPrestateTracer(Config{})
+	p.Cancel(err)
+
+	vmState := &mockVMState{}
+	p.CaptureState(nil, nil, int(evm.STOP), contract, 0, nil, vmState)
+	require.True(t, vmState.halted)
+
+	res, resErr := p.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+}
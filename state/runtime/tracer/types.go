@@ -15,6 +15,18 @@ type RuntimeHost interface {
 	GetStorage(types.Address, types.Hash) types.Hash
 }
 
+// StateReader is the interface defining the methods for reading the state of the accounts by tracer
+type StateReader interface {
+	// GetBalance returns the balance of the account
+	GetBalance(types.Address) *big.Int
+	// GetNonce returns the nonce of the account
+	GetNonce(types.Address) uint64
+	// GetCode returns the code of the account
+	GetCode(types.Address) []byte
+	// GetState returns the value of the storage slot of the account
+	GetState(types.Address, types.Hash) types.Hash
+}
+
 type VMState interface {
 	// Halt tells VM to terminate its process
 	Halt()
@@ -69,3 +81,14 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// StateTracer is the tracer which inspects the state of the accounts touched by the transaction
+type StateTracer interface {
+	Tracer
+
+	// TxPreState passes the state before the transaction, along with the accounts
+	// the transaction touches outside of the execution (like the sender and the coinbase)
+	TxPreState(state StateReader, touched []types.Address)
+	// TxPostState passes the state after the transaction
+	TxPostState(state StateReader)
+}
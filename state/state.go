@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -11,6 +12,10 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrStateNotAvailable is returned when the state for the given root is not present in the storage,
+// either because it was never stored or because it was pruned
+var ErrStateNotAvailable = errors.New("state not available")
+
 // State represents an interface for interacting with a state that can be
 // snapshotted, queried for data, and checked for existence of specific items.
 type State interface {
@@ -72,6 +77,14 @@ type State interface {
 type Snapshot interface {
 	readSnapshot
 
+	// GetAccountProof returns the merkle proof (RLP encoded trie nodes, starting from the root)
+	// of the given account in the state trie
+	GetAccountProof(addr types.Address) ([][]byte, error)
+
+	// GetStorageProof returns the merkle proof (RLP encoded trie nodes, starting from the root)
+	// of the given storage slot in the storage trie with the given root
+	GetStorageProof(root types.Hash, key types.Hash) ([][]byte, error)
+
 	Commit(objs []*Object) (Snapshot, []byte, error)
 }
 
@@ -109,6 +122,20 @@ type IteratorDump struct {
 	Next []byte `json:"next,omitempty"` // nil if no more accounts
 }
 
+// AccountProof is the merkle proof of an account and a set of its storage slots (EIP-1186).
+type AccountProof struct {
+	Account       *Account
+	Proof         [][]byte
+	StorageProofs []*StorageProof
+}
+
+// StorageProof is the merkle proof of a single storage slot (EIP-1186).
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
 // StorageRangeResult is the result of a debug_storageRangeAt API call.
 type StorageRangeResult struct {
 	Storage storageMap `json:"storage"`
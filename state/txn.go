@@ -39,6 +39,9 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// transientStorageIndex is the index of the transient storage (EIP-1153)
+	transientStorageIndex = types.BytesToHash([]byte{4}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -70,6 +73,16 @@ func newTxn(snapshot readSnapshot) *Txn {
 	}
 }
 
+// Copy returns a copy of the txn, which is not affected by the further changes of the txn
+func (txn *Txn) Copy() *Txn {
+	return &Txn{
+		snapshot:  txn.snapshot,
+		snapshots: []*iradix.Tree{},
+		txn:       txn.txn.CommitOnly().Txn(),
+		codeCache: txn.codeCache,
+	}
+}
+
 // GetDumpTree function returns accounts based on the selected criteria.
 func (txn *Txn) GetDumpTree(dumpObject *Dump, opts *DumpInfo, deleteEmptyObjects bool) ([]byte, error) {
 	if err := txn.CleanDeleteObjects(deleteEmptyObjects); err != nil {
@@ -627,6 +640,42 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64) //nolint:forcetypeassert
 }
 
+// GetTransientState returns the value of the key in the transient storage of the address
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	data, exists := txn.txn.Get(transientStorageIndex)
+	if !exists {
+		return types.Hash{}
+	}
+
+	val, exists := data.(*iradix.Tree).Get(transientStorageKey(addr, key)) //nolint:forcetypeassert
+	if !exists {
+		return types.Hash{}
+	}
+
+	return val.(types.Hash) //nolint:forcetypeassert
+}
+
+// SetTransientState sets the value of the key in the transient storage of the address.
+// The transient storage is kept in an immutable tree, so that it's reverted along with the snapshots
+func (txn *Txn) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	storage := iradix.New()
+	if data, exists := txn.txn.Get(transientStorageIndex); exists {
+		storage = data.(*iradix.Tree) //nolint:forcetypeassert
+	}
+
+	if value == types.ZeroHash {
+		storage, _, _ = storage.Delete(transientStorageKey(addr, key))
+	} else {
+		storage, _, _ = storage.Insert(transientStorageKey(addr, key), value)
+	}
+
+	txn.txn.Insert(transientStorageIndex, storage)
+}
+
+func transientStorageKey(addr types.Address, key types.Hash) []byte {
+	return append(addr.Bytes(), key.Bytes()...)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -740,8 +789,9 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 		txn.txn.Insert(k, obj2)
 	}
 
-	// delete refunds
+	// delete refunds and the transient storage, both live for a single transaction
 	txn.txn.Delete(refundIndex)
+	txn.txn.Delete(transientStorageIndex)
 
 	return nil
 }
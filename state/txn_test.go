@@ -51,6 +51,14 @@ func (m *mockSnapshot) GetRootHash() types.Hash {
 	return emptyStateHash
 }
 
+func (m *mockSnapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	return nil, nil
+}
+
+func (m *mockSnapshot) GetStorageProof(root types.Hash, key types.Hash) ([][]byte, error) {
+	return nil, nil
+}
+
 func (m *mockSnapshot) Commit(objs []*Object) (Snapshot, []byte, error) {
 	return nil, nil, nil
 }
@@ -136,3 +144,43 @@ func TestIncrNonce(t *testing.T) {
 	require.NoError(t, txn.IncrNonce(address1))
 	require.Equal(t, nonMaxUint64NonceValue+1, txn.GetNonce(address1))
 }
+
+func TestTransientState(t *testing.T) {
+	t.Parallel()
+
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr1, hash1, hash1)
+	assert.Equal(t, hash1, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr2, hash1))
+
+	// the transient storage is reverted along with the snapshot
+	ss := txn.Snapshot()
+	txn.SetTransientState(addr1, hash1, hash2)
+	txn.SetTransientState(addr2, hash1, hash2)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+	assert.Equal(t, hash1, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr2, hash1))
+
+	// the transient storage is cleared at the end of the transaction
+	require.NoError(t, txn.CleanDeleteObjects(true))
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr1, hash1))
+}
+
+func TestTxnCopy(t *testing.T) {
+	t.Parallel()
+
+	txn := newTestTxn(defaultPreState)
+	txn.SetState(addr1, hash1, hash1)
+
+	copied := txn.Copy()
+
+	txn.SetState(addr1, hash1, hash2)
+	txn.AddBalance(addr1, big.NewInt(1))
+
+	assert.Equal(t, hash1, copied.GetState(addr1, hash1))
+	assert.Equal(t, hash2, txn.GetState(addr1, hash1))
+	assert.Equal(t, new(big.Int).Add(copied.GetBalance(addr1), big.NewInt(1)), txn.GetBalance(addr1))
+}
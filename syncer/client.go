@@ -292,12 +292,60 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
+// ReportInvalidBlock reports the peer which sent a block failing the verification
+func (m *syncPeerClient) ReportInvalidBlock(peerID peer.ID, blockNumber uint64) {
+	m.network.ReportPeer(peerID, network.PenaltyInvalidBlock, fmt.Sprintf("invalid block %d", blockNumber))
+}
+
 // GetBlocks returns a stream of blocks from given height to peer's latest
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
 	from uint64,
 	timeoutPerBlock time.Duration,
 ) (<-chan *types.Block, error) {
+	return m.GetBlockRange(peerID, from, 0, timeoutPerBlock)
+}
+
+// GetBlockRange returns a stream of blocks in the given range (to = 0 stands for peer's latest)
+func (m *syncPeerClient) GetBlockRange(
+	peerID peer.ID,
+	from, to uint64,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.Block, error) {
+	fullBlockCh, err := m.streamBlocks(peerID, &proto.GetBlocksRequest{From: from, To: to}, timeoutPerBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCh := make(chan *types.Block, 1)
+
+	go func() {
+		defer close(blockCh)
+
+		for fullBlock := range fullBlockCh {
+			blockCh <- fullBlock.Block
+		}
+	}()
+
+	return blockCh, nil
+}
+
+// GetBlocksWithReceipts returns a stream of blocks, along with their receipts, from given height to peer's latest
+func (m *syncPeerClient) GetBlocksWithReceipts(
+	peerID peer.ID,
+	from uint64,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.FullBlock, error) {
+	return m.streamBlocks(peerID, &proto.GetBlocksRequest{From: from, Receipts: true}, timeoutPerBlock)
+}
+
+// streamBlocks requests the blocks from the peer and returns them via a channel,
+// which is closed once the stream ends, fails or a block doesn't arrive within the timeout
+func (m *syncPeerClient) streamBlocks(
+	peerID peer.ID,
+	req *proto.GetBlocksRequest,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.FullBlock, error) {
 	clt, err := m.newSyncPeerClient(peerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
@@ -305,9 +353,7 @@ func (m *syncPeerClient) GetBlocks(
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	stream, err := clt.GetBlocks(ctx, &proto.GetBlocksRequest{
-		From: from,
-	})
+	stream, err := clt.GetBlocks(ctx, req)
 	if err != nil {
 		cancel()
 
@@ -318,7 +364,7 @@ func (m *syncPeerClient) GetBlocks(
 	streamBlockCh, streamErrorCh := blockStreamToChannel(stream)
 
 	// output channel
-	blockCh := make(chan *types.Block, 1)
+	blockCh := make(chan *types.FullBlock, 1)
 
 	go func() {
 		defer cancel()
@@ -335,6 +381,10 @@ func (m *syncPeerClient) GetBlocks(
 			case err := <-streamErrorCh:
 				m.logger.Error("failed to get block from gRPC stream", "peer", peerID, "err", err)
 
+				if errors.Is(err, errMalformedBlock) {
+					m.network.ReportPeer(peerID, network.PenaltyMalformedMessage, "malformed block")
+				}
+
 				return
 			case <-time.After(timeoutPerBlock):
 				m.logger.Warn("block doesn't reach within timeout", "timeout", timeoutPerBlock)
@@ -347,6 +397,55 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetStateRange fetches the trie nodes and codes under the given path of the state with the given root,
+// handing them over to the handler chunk by chunk as they arrive, so that the range is never held in memory
+// as a whole. The request fails if no data arrives from the peer within the timeout
+func (m *syncPeerClient) GetStateRange(
+	peerID peer.ID,
+	root types.Hash,
+	path []byte,
+	timeout time.Duration,
+	handler func(*StateRange) error,
+) error {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	timer := time.AfterFunc(timeout, cancel)
+	defer timer.Stop()
+
+	stream, err := clt.GetStateRange(ctx, &proto.GetStateRangeRequest{
+		Root: root.Bytes(),
+		Path: path,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open GetStateRange stream: %w", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get state range from gRPC stream: %w", err)
+		}
+
+		timer.Reset(timeout)
+
+		if err := handler(&StateRange{
+			Proof: chunk.Proof,
+			Nodes: chunk.Nodes,
+			Codes: chunk.Codes,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -359,18 +458,32 @@ func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient
 	return proto.NewSyncPeerClient(conn), nil
 }
 
-// fromProto gets block from gRPC response data
-func fromProto(protoBlock *proto.Block) (*types.Block, error) {
+// errMalformedBlock is returned when a block received from a peer can't be decoded
+var errMalformedBlock = errors.New("malformed block")
+
+// fromProto gets block, and its receipts if they are sent along with it, from gRPC response data
+func fromProto(protoBlock *proto.Block) (*types.FullBlock, error) {
 	block := &types.Block{}
 	if err := block.UnmarshalRLP(protoBlock.Block); err != nil {
 		return nil, err
 	}
 
-	return block, nil
+	fullBlock := &types.FullBlock{Block: block}
+
+	if protoBlock.Receipts != nil {
+		var receipts types.Receipts
+		if err := receipts.UnmarshalRLP(protoBlock.Receipts); err != nil {
+			return nil, err
+		}
+
+		fullBlock.Receipts = receipts
+	}
+
+	return fullBlock, nil
 }
 
-func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
-	blockCh := make(chan *types.Block)
+func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.FullBlock, <-chan error) {
+	blockCh := make(chan *types.FullBlock)
 	errorCh := make(chan error, 1)
 
 	go func() {
@@ -392,12 +505,13 @@ func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.
 			block, err := fromProto(protoBlock)
 			if err != nil {
 				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
-				errorCh <- err
+				errorCh <- fmt.Errorf("%w: %w", errMalformedBlock, err)
 
 				break
 			}
 
-			metrics.SetGauge([]string{syncerMetrics, "ingress_bytes"}, float32(len(protoBlock.Block)))
+			metrics.SetGauge([]string{syncerMetrics, "ingress_bytes"},
+				float32(len(protoBlock.Block)+len(protoBlock.Receipts)))
 
 			blockCh <- block
 		}
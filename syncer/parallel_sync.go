@@ -0,0 +1,446 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// defaultBlockRangeSize is the number of blocks requested at once from a peer whose throughput is unknown
+	defaultBlockRangeSize = 64
+	// minBlockRangeSize and maxBlockRangeSize bound the number of blocks requested at once from a peer
+	minBlockRangeSize = 8
+	maxBlockRangeSize = 512
+	// blockRangeDuration is the targeted time of downloading a single block range,
+	// the range size is derived from it and from the peer's throughput
+	blockRangeDuration = 2 * time.Second
+	// maxBlocksAhead bounds the number of the downloaded blocks waiting for the insertion
+	maxBlocksAhead = 4096
+	// throughputSmoothing is the weight of the latest measurement in the peer's throughput score
+	throughputSmoothing = 0.3
+)
+
+var errIncompleteBlockRange = errors.New("incomplete block range")
+
+// blockRange is an inclusive range of block heights
+type blockRange struct {
+	from, to uint64
+}
+
+// blockRangeResult holds the blocks of a range downloaded from a peer
+type blockRangeResult struct {
+	blockRange
+
+	peerID peer.ID
+	blocks []*types.Block
+}
+
+// peerScores keeps track of the block download throughput (blocks per second) of the peers
+type peerScores struct {
+	lock   sync.Mutex
+	scores map[peer.ID]float64
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[peer.ID]float64),
+	}
+}
+
+// update records the given number of blocks downloaded from the peer in the given time
+func (p *peerScores) update(id peer.ID, blocks int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		elapsed = time.Millisecond
+	}
+
+	throughput := float64(blocks) / elapsed.Seconds()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if score, ok := p.scores[id]; ok {
+		throughput = (1-throughputSmoothing)*score + throughputSmoothing*throughput
+	}
+
+	p.scores[id] = throughput
+}
+
+// penalize lowers the score of the peer which failed to deliver a block range
+func (p *peerScores) penalize(id peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if score, ok := p.scores[id]; ok {
+		p.scores[id] = score / 2
+	}
+}
+
+// remove forgets the score of the peer
+func (p *peerScores) remove(id peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.scores, id)
+}
+
+// rangeSize returns the number of blocks to request at once from the peer
+func (p *peerScores) rangeSize(id peer.ID) uint64 {
+	p.lock.Lock()
+	score, ok := p.scores[id]
+	p.lock.Unlock()
+
+	if !ok {
+		return defaultBlockRangeSize
+	}
+
+	size := uint64(score * blockRangeDuration.Seconds())
+
+	switch {
+	case size < minBlockRangeSize:
+		return minBlockRangeSize
+	case size > maxBlockRangeSize:
+		return maxBlockRangeSize
+	default:
+		return size
+	}
+}
+
+// blockRangeScheduler hands out the block ranges to the download workers.
+// The failed ranges are re-requested first, and no range is handed out too far ahead
+// of the inserted blocks, so that the number of the buffered blocks is bounded
+type blockRangeScheduler struct {
+	lock sync.Mutex
+
+	next    uint64             // the lowest block which is not inserted yet
+	cursor  uint64             // the lowest block which is not handed out yet
+	target  uint64             // the highest block to download
+	retries []blockRange       // the failed ranges, sorted by height
+	peers   map[peer.ID]uint64 // the latest block of the peers which are not dropped
+
+	// changedCh is closed (and replaced) on every change of the scheduler
+	changedCh chan struct{}
+}
+
+func newBlockRangeScheduler(from uint64, peers []*NoForkPeer) *blockRangeScheduler {
+	q := &blockRangeScheduler{
+		next:      from,
+		cursor:    from,
+		peers:     make(map[peer.ID]uint64, len(peers)),
+		changedCh: make(chan struct{}),
+	}
+
+	for _, p := range peers {
+		q.peers[p.ID] = p.Number
+	}
+
+	q.updateTarget()
+
+	return q
+}
+
+// take hands out the next range to download from the peer. If there is none,
+// the returned channel is closed once the scheduler changes
+func (q *blockRangeScheduler) take(id peer.ID, size uint64) (blockRange, bool, <-chan struct{}) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	latest, ok := q.peers[id]
+	if !ok {
+		return blockRange{}, false, nil
+	}
+
+	limit := q.next + maxBlocksAhead
+
+	for i, r := range q.retries {
+		if r.from >= limit || r.from > latest {
+			break
+		}
+
+		if r.to > latest {
+			// the peer takes the part of the range it has
+			q.retries[i].from = latest + 1
+
+			return blockRange{from: r.from, to: latest}, true, nil
+		}
+
+		q.retries = append(q.retries[:i], q.retries[i+1:]...)
+
+		return r, true, nil
+	}
+
+	if q.cursor > q.target || q.cursor >= limit || q.cursor > latest {
+		return blockRange{}, false, q.changedCh
+	}
+
+	r := blockRange{from: q.cursor, to: min(q.cursor+size-1, q.target, latest, limit-1)}
+	q.cursor = r.to + 1
+
+	return r, true, nil
+}
+
+// retry schedules the range to be downloaded again
+func (q *blockRangeScheduler) retry(r blockRange) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.retries = append(q.retries, r)
+	sort.Slice(q.retries, func(i, j int) bool {
+		return q.retries[i].from < q.retries[j].from
+	})
+
+	q.notify()
+}
+
+// drop stops handing out the ranges to the peer, the blocks only it has are not downloaded anymore
+func (q *blockRangeScheduler) drop(id peer.ID) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	delete(q.peers, id)
+	q.updateTarget()
+	q.notify()
+}
+
+// dropped returns whether the peer has been dropped
+func (q *blockRangeScheduler) dropped(id peer.ID) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	_, ok := q.peers[id]
+
+	return !ok
+}
+
+// inserted records the insertion of the block with the given height
+func (q *blockRangeScheduler) inserted(number uint64) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.next = number + 1
+	q.notify()
+}
+
+// state returns the lowest block which is not inserted yet, the highest block to download
+// and a channel which is closed once the scheduler changes
+func (q *blockRangeScheduler) state() (uint64, uint64, <-chan struct{}) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.next, q.target, q.changedCh
+}
+
+// updateTarget sets the target to the highest block of the remaining peers
+func (q *blockRangeScheduler) updateTarget() {
+	q.target = q.next - 1
+
+	for _, latest := range q.peers {
+		q.target = max(q.target, latest)
+	}
+}
+
+func (q *blockRangeScheduler) notify() {
+	close(q.changedCh)
+	q.changedCh = make(chan struct{})
+}
+
+// parallelSyncWithPeers downloads the blocks concurrently from the given peers, each peer
+// fetching a range of blocks at a time, sized by the peer's throughput. The ranges a peer fails
+// to deliver are re-requested from the other peers, and the peer is not used anymore (it's added to the skip list).
+// The downloaded ranges are buffered and the blocks are inserted in order
+func (s *syncer) parallelSyncWithPeers(
+	peers []*NoForkPeer,
+	skipList map[peer.ID]bool,
+	newBlockCallback func(*types.FullBlock) bool,
+) (uint64, bool, error) {
+	localLatest := s.blockchain.Header().Number
+	shouldTerminate := false
+
+	s.lock.RLock()
+	blockTimeout := s.blockTimeout
+	s.lock.RUnlock()
+
+	scheduler := newBlockRangeScheduler(localLatest+1, peers)
+
+	_, target, _ := scheduler.state()
+
+	// Create a blockchain subscription for the sync progression and start tracking
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(target)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resultsCh := make(chan *blockRangeResult, len(peers))
+
+	var wg sync.WaitGroup
+
+	for _, p := range peers {
+		peerID := p.ID
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			s.downloadBlockRanges(ctx, peerID, scheduler, resultsCh, blockTimeout)
+		}()
+	}
+
+	defer func() {
+		cancel()
+		wg.Wait()
+
+		for _, p := range peers {
+			if scheduler.dropped(p.ID) {
+				skipList[p.ID] = true
+			}
+		}
+
+		// Stop monitoring the sync progression upon exit
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	var (
+		lastReceivedNumber uint64
+		buffered           = make(map[uint64]*blockRangeResult)
+	)
+
+	for {
+		next, target, changedCh := scheduler.state()
+		if next > target {
+			return lastReceivedNumber, shouldTerminate, nil
+		}
+
+		res, ok := buffered[next]
+		if !ok {
+			select {
+			case res := <-resultsCh:
+				buffered[res.from] = res
+			case <-changedCh:
+			}
+
+			continue
+		}
+
+		delete(buffered, next)
+
+		for _, block := range res.blocks {
+			if block.Number() > target {
+				break
+			}
+
+			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
+			if err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				s.logger.Warn("unable to verify block, peer dropped", "peer ID", res.peerID,
+					"block", block.Number(), "error", err)
+
+				scheduler.retry(blockRange{from: block.Number(), to: res.to})
+				scheduler.drop(res.peerID)
+				s.syncPeerClient.ReportInvalidBlock(res.peerID, block.Number())
+
+				break
+			}
+
+			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return lastReceivedNumber, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
+			}
+
+			updateMetrics(fullBlock)
+			shouldTerminate = newBlockCallback(fullBlock)
+
+			lastReceivedNumber = block.Number()
+			scheduler.inserted(lastReceivedNumber)
+		}
+	}
+}
+
+// downloadBlockRanges downloads the block ranges handed out by the scheduler from the peer,
+// until the context is canceled or the peer fails to deliver a range
+func (s *syncer) downloadBlockRanges(
+	ctx context.Context,
+	peerID peer.ID,
+	scheduler *blockRangeScheduler,
+	resultsCh chan<- *blockRangeResult,
+	timeout time.Duration,
+) {
+	for {
+		if scheduler.dropped(peerID) {
+			return
+		}
+
+		r, ok, changedCh := scheduler.take(peerID, s.peerScores.rangeSize(peerID))
+		if !ok {
+			if changedCh == nil {
+				return
+			}
+
+			select {
+			case <-changedCh:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		start := time.Now()
+
+		blocks, err := s.fetchBlockRange(peerID, r, timeout)
+		if err != nil {
+			s.logger.Warn("failed to download block range, peer dropped", "peer ID", peerID,
+				"from", r.from, "to", r.to, "error", err)
+
+			s.peerScores.penalize(peerID)
+			scheduler.retry(r)
+			scheduler.drop(peerID)
+
+			return
+		}
+
+		s.peerScores.update(peerID, len(blocks), time.Since(start))
+
+		select {
+		case resultsCh <- &blockRangeResult{blockRange: r, peerID: peerID, blocks: blocks}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fetchBlockRange downloads all the blocks of the range from the peer
+func (s *syncer) fetchBlockRange(peerID peer.ID, r blockRange, timeout time.Duration) ([]*types.Block, error) {
+	blockCh, err := s.syncPeerClient.GetBlockRange(peerID, r.from, r.to, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.closeBlockStream(peerID, blockCh)
+
+	blocks := make([]*types.Block, 0, r.to-r.from+1)
+
+	for block := range blockCh {
+		if expected := r.from + uint64(len(blocks)); block.Number() != expected {
+			return nil, fmt.Errorf("unexpected block %d received instead of %d", block.Number(), expected)
+		}
+
+		blocks = append(blocks, block)
+
+		if block.Number() == r.to {
+			return blocks, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w, %d of %d blocks received", errIncompleteBlockRange, len(blocks), r.to-r.from+1)
+}
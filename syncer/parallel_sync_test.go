@@ -0,0 +1,249 @@
+package syncer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func Test_parallelSyncWithPeers(t *testing.T) {
+	t.Parallel()
+
+	const blockNum = 300
+
+	blocks := createMockBlocks(blockNum)
+
+	var (
+		errPeerNoResponse = errors.New("peer is not responding")
+
+		fastPeer       = &NoForkPeer{ID: peer.ID("A"), Number: blockNum, Distance: big.NewInt(0)}
+		failingPeer    = &NoForkPeer{ID: peer.ID("B"), Number: blockNum, Distance: big.NewInt(1)}
+		incompletePeer = &NoForkPeer{ID: peer.ID("C"), Number: blockNum, Distance: big.NewInt(2)}
+		behindPeer     = &NoForkPeer{ID: peer.ID("D"), Number: blockNum / 2, Distance: big.NewInt(3)}
+	)
+
+	var (
+		lock         sync.Mutex
+		syncedBlocks = make([]*types.Block, 0, blockNum)
+		latest       = uint64(0)
+	)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: func() *types.Header {
+				lock.Lock()
+				defer lock.Unlock()
+
+				return &types.Header{Number: latest}
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				lock.Lock()
+				defer lock.Unlock()
+
+				syncedBlocks = append(syncedBlocks, b.Block)
+				latest = b.Block.Number()
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getBlockRangeHandler: func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+				switch id {
+				case failingPeer.ID:
+					return nil, errPeerNoResponse
+				case incompletePeer.ID:
+					// delivers only the first block of the range
+					return blocksToCh(blocks[from-1:from], 0), nil
+				default:
+					return blocksToCh(blocks[from-1:to], time.Millisecond), nil
+				}
+			},
+		},
+		&mockProgression{},
+	)
+
+	skipList := make(map[peer.ID]bool)
+
+	lastNumber, shouldTerminate, err := syncer.parallelSyncWithPeers(
+		[]*NoForkPeer{fastPeer, failingPeer, incompletePeer, behindPeer},
+		skipList,
+		func(b *types.FullBlock) bool {
+			return b.Block.Number() == blockNum
+		},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(blockNum), lastNumber)
+	assert.True(t, shouldTerminate)
+	assert.Equal(t, blocks, syncedBlocks)
+
+	// the peers which failed to deliver a range are skipped afterwards
+	assert.Equal(t, map[peer.ID]bool{failingPeer.ID: true, incompletePeer.ID: true}, skipList)
+}
+
+func Test_parallelSyncWithPeers_BadBlock(t *testing.T) {
+	t.Parallel()
+
+	const blockNum = 100
+
+	var (
+		blocks    = createMockBlocks(blockNum)
+		badBlocks = createMockBlocks(blockNum)
+
+		errInvalidBlock = errors.New("invalid block")
+
+		honestPeer    = &NoForkPeer{ID: peer.ID("A"), Number: blockNum, Distance: big.NewInt(0)}
+		maliciousPeer = &NoForkPeer{ID: peer.ID("B"), Number: blockNum, Distance: big.NewInt(1)}
+	)
+
+	for _, b := range badBlocks {
+		b.Header.ExtraData = []byte("bad")
+	}
+
+	syncedBlocks := make([]*types.Block, 0, blockNum)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(0),
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				if len(b.Header.ExtraData) > 0 {
+					return nil, errInvalidBlock
+				}
+
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				syncedBlocks = append(syncedBlocks, b.Block)
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getBlockRangeHandler: func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+				if id == maliciousPeer.ID {
+					return blocksToCh(badBlocks[from-1:to], 0), nil
+				}
+
+				return blocksToCh(blocks[from-1:to], 10*time.Millisecond), nil
+			},
+		},
+		&mockProgression{},
+	)
+
+	// the ranges are small, so that both of the peers get some
+	syncer.peerScores.update(honestPeer.ID, minBlockRangeSize, blockRangeDuration)
+	syncer.peerScores.update(maliciousPeer.ID, minBlockRangeSize, blockRangeDuration)
+
+	skipList := make(map[peer.ID]bool)
+
+	lastNumber, _, err := syncer.parallelSyncWithPeers(
+		[]*NoForkPeer{honestPeer, maliciousPeer},
+		skipList,
+		func(*types.FullBlock) bool { return false },
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(blockNum), lastNumber)
+	assert.Equal(t, blocks, syncedBlocks)
+	assert.True(t, skipList[maliciousPeer.ID])
+}
+
+func Test_blockRangeScheduler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peerA = &NoForkPeer{ID: peer.ID("A"), Number: 5000}
+		peerB = &NoForkPeer{ID: peer.ID("B"), Number: 100}
+	)
+
+	scheduler := newBlockRangeScheduler(1, []*NoForkPeer{peerA, peerB})
+
+	// the ranges don't exceed the peer's latest block
+	r, ok, _ := scheduler.take(peerB.ID, 64)
+	require.True(t, ok)
+	assert.Equal(t, blockRange{from: 1, to: 64}, r)
+
+	r, ok, _ = scheduler.take(peerB.ID, 64)
+	require.True(t, ok)
+	assert.Equal(t, blockRange{from: 65, to: 100}, r)
+
+	_, ok, changedCh := scheduler.take(peerB.ID, 64)
+	require.False(t, ok)
+	require.NotNil(t, changedCh)
+
+	// the ranges are not handed out too far ahead of the inserted blocks
+	r, ok, _ = scheduler.take(peerA.ID, maxBlocksAhead)
+	require.True(t, ok)
+	assert.Equal(t, blockRange{from: 101, to: maxBlocksAhead}, r)
+
+	_, ok, changedCh = scheduler.take(peerA.ID, 64)
+	require.False(t, ok)
+
+	// the failed ranges are handed out first, even partially
+	scheduler.retry(blockRange{from: 65, to: 100})
+	scheduler.drop(peerB.ID)
+
+	select {
+	case <-changedCh:
+	default:
+		t.Fatal("scheduler change not notified")
+	}
+
+	r, ok, _ = scheduler.take(peerA.ID, 64)
+	require.True(t, ok)
+	assert.Equal(t, blockRange{from: 65, to: 100}, r)
+
+	_, ok, changedCh = scheduler.take(peerB.ID, 64)
+	require.False(t, ok)
+	require.Nil(t, changedCh)
+
+	scheduler.inserted(64)
+
+	r, ok, _ = scheduler.take(peerA.ID, 64)
+	require.True(t, ok)
+	assert.Equal(t, blockRange{from: maxBlocksAhead + 1, to: maxBlocksAhead + 64}, r)
+
+	// the target is lowered when the peers having the highest blocks are dropped
+	scheduler.drop(peerA.ID)
+
+	next, target, _ := scheduler.state()
+	assert.Equal(t, uint64(65), next)
+	assert.Equal(t, uint64(64), target)
+}
+
+func Test_peerScores_rangeSize(t *testing.T) {
+	t.Parallel()
+
+	scores := newPeerScores()
+	id := peer.ID("A")
+
+	assert.Equal(t, uint64(defaultBlockRangeSize), scores.rangeSize(id))
+
+	scores.update(id, 100, time.Second)
+	assert.Equal(t, uint64(100*blockRangeDuration.Seconds()), scores.rangeSize(id))
+
+	scores.update(id, 100_000, time.Second)
+	assert.Equal(t, uint64(maxBlockRangeSize), scores.rangeSize(id))
+
+	scores.remove(id)
+	scores.update(id, 1, time.Second)
+	assert.Equal(t, uint64(minBlockRangeSize), scores.rangeSize(id))
+
+	scores.remove(id)
+	assert.Equal(t, uint64(defaultBlockRangeSize), scores.rangeSize(id))
+}
@@ -2,6 +2,7 @@ package syncer
 
 import (
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -67,3 +68,26 @@ func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
 
 	return bestPeer
 }
+
+// PeersFrom returns the peers whose latest block is at least the given height, the best ones first
+func (m *PeerMap) PeersFrom(number uint64, skipMap map[peer.ID]bool) []*NoForkPeer {
+	var peers []*NoForkPeer
+
+	m.Range(func(key, value interface{}) bool {
+		peer, _ := value.(*NoForkPeer)
+
+		if peer.Number < number || (skipMap != nil && skipMap[peer.ID]) {
+			return true
+		}
+
+		peers = append(peers, peer)
+
+		return true
+	})
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].IsBetter(peers[j])
+	})
+
+	return peers
+}
@@ -22,6 +22,8 @@ type SyncPeerClient interface {
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns stream of state trie nodes under the specified path of the state
+	GetStateRange(ctx context.Context, in *GetStateRangeRequest, opts ...grpc.CallOption) (SyncPeer_GetStateRangeClient, error)
 }
 
 type syncPeerClient struct {
@@ -73,6 +75,38 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetStateRange(ctx context.Context, in *GetStateRangeRequest, opts ...grpc.CallOption) (SyncPeer_GetStateRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[1], "/v1.SyncPeer/GetStateRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetStateRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetStateRangeClient interface {
+	Recv() (*StateRange, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetStateRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetStateRangeClient) Recv() (*StateRange, error) {
+	m := new(StateRange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
@@ -81,6 +115,8 @@ type SyncPeerServer interface {
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns stream of state trie nodes under the specified path of the state
+	GetStateRange(*GetStateRangeRequest, SyncPeer_GetStateRangeServer) error
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +130,9 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetStateRange(*GetStateRangeRequest, SyncPeer_GetStateRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetStateRange not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -146,6 +185,27 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncPeer_GetStateRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetStateRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetStateRange(m, &syncPeerGetStateRangeServer{stream})
+}
+
+type SyncPeer_GetStateRangeServer interface {
+	Send(*StateRange) error
+	grpc.ServerStream
+}
+
+type syncPeerGetStateRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetStateRangeServer) Send(m *StateRange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
@@ -161,6 +221,11 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _SyncPeer_GetBlocks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetStateRange",
+			Handler:       _SyncPeer_GetStateRange_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "syncer/proto/syncer.proto",
 }
@@ -3,33 +3,42 @@ package syncer
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hashicorp/go-metrics"
 )
 
+// stateRangeChunkSize is the approximate size of the trie nodes and codes sent in a single state range message
+const stateRangeChunkSize = 512 * 1024
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound        = errors.New("block not found")
+	ErrStateSyncUnsupported = errors.New("state sync is not supported by the peer")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain   Blockchain       // reference to the blockchain module
+	stateStorage itrie.Storage    // reference to the state storage
+	network      Network          // reference to the network module
+	stream       *grpc.GrpcStream // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 ) SyncPeerService {
 	return &syncPeerService{
-		blockchain: blockchain,
-		network:    network,
+		blockchain:   blockchain,
+		stateStorage: stateStorage,
+		network:      network,
 	}
 }
 
@@ -57,15 +66,25 @@ func (s *syncPeerService) GetBlocks(
 	req *proto.GetBlocksRequest,
 	stream proto.SyncPeer_GetBlocksServer,
 ) error {
-	// from to latest
-	for i := req.From; i <= s.blockchain.Header().Number; i++ {
+	// from to latest, or to the requested height
+	for i := req.From; i <= s.blockchain.Header().Number && (req.To == 0 || i <= req.To); i++ {
 		block, ok := s.blockchain.GetBlockByNumber(i, true)
 		if !ok {
 			return ErrBlockNotFound
 		}
 
 		resp := toProtoBlock(block)
-		metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(len(resp.Block)))
+
+		if req.Receipts {
+			receipts, err := s.blockchain.GetReceiptsByHash(block.Hash())
+			if err != nil {
+				return fmt.Errorf("failed to get receipts of block %d: %w", i, err)
+			}
+
+			resp.Receipts = types.Receipts(receipts).MarshalRLPTo(nil)
+		}
+
+		metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(len(resp.Block)+len(resp.Receipts)))
 
 		// if client closes stream, context.Canceled is given
 		if err := stream.Send(resp); err != nil {
@@ -91,6 +110,61 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
+// GetStateRange is a gRPC endpoint to return the trie nodes and codes under the given path
+// of the requested state via stream
+func (s *syncPeerService) GetStateRange(
+	req *proto.GetStateRangeRequest,
+	stream proto.SyncPeer_GetStateRangeServer,
+) error {
+	if s.stateStorage == nil {
+		return ErrStateSyncUnsupported
+	}
+
+	var (
+		chunk = &proto.StateRange{}
+		size  = 0
+		sent  = false
+	)
+
+	send := func() error {
+		metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(size))
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+
+		chunk, size, sent = &proto.StateRange{}, 0, true
+
+		return nil
+	}
+
+	add := func(items *[][]byte, item []byte) error {
+		*items = append(*items, item)
+
+		if size += len(item); size < stateRangeChunkSize {
+			return nil
+		}
+
+		return send()
+	}
+
+	err := itrie.WalkStateRange(types.BytesToHash(req.Root), req.Path, s.stateStorage, &itrie.StateRangeVisitor{
+		Proof: func(node []byte) error { return add(&chunk.Proof, node) },
+		Node:  func(node []byte) error { return add(&chunk.Nodes, node) },
+		Code:  func(code []byte) error { return add(&chunk.Codes, code) },
+	})
+	if err != nil {
+		return err
+	}
+
+	// the last chunk is sent even if it's empty, in case the range is empty as well
+	if size > 0 || !sent {
+		return send()
+	}
+
+	return nil
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
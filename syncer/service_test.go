@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
@@ -136,6 +137,52 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	}
 }
 
+func Test_syncPeerService_GetBlocksWithReceipts(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(3)
+	receipts := map[types.Hash][]*types.Receipt{}
+
+	for _, b := range blocks {
+		b.Header.ComputeHash()
+
+		receipts[b.Hash()] = []*types.Receipt{{CumulativeGasUsed: b.Number(), Logs: []*types.Log{}}}
+	}
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(3),
+			getBlockByNumberHandler: func(u uint64, _ bool) (*types.Block, bool) {
+				return blocks[u-1], true
+			},
+			getReceiptsByHashHandler: func(hash types.Hash) ([]*types.Receipt, error) {
+				return receipts[hash], nil
+			},
+		},
+	}
+
+	stream, err := newMockGrpcClient(t, service).GetBlocks(context.Background(), &proto.GetBlocksRequest{
+		From:     1,
+		Receipts: true,
+	})
+	require.NoError(t, err)
+
+	for _, b := range blocks {
+		protoBlock, err := stream.Recv()
+		require.NoError(t, err)
+
+		fullBlock, err := fromProto(protoBlock)
+		require.NoError(t, err)
+
+		assert.Equal(t, b.Number(), fullBlock.Block.Number())
+		require.Len(t, fullBlock.Receipts, 1)
+		assert.Equal(t, b.Number(), fullBlock.Receipts[0].CumulativeGasUsed)
+	}
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
 func TestGetStatus(t *testing.T) {
 	t.Parallel()
 
@@ -154,3 +201,45 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+func Test_syncPeerService_GetStateRange(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+
+	expected, err := serveStateRange(source, root, []byte{0x1})
+	assert.NoError(t, err)
+
+	client := newMockGrpcClient(t, &syncPeerService{stateStorage: source})
+
+	stream, err := client.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root: root.Bytes(),
+		Path: []byte{0x1},
+	})
+	assert.NoError(t, err)
+
+	received := &StateRange{}
+
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+
+			break
+		}
+
+		received.Proof = append(received.Proof, chunk.Proof...)
+		received.Nodes = append(received.Nodes, chunk.Nodes...)
+		received.Codes = append(received.Codes, chunk.Codes...)
+	}
+
+	assert.Equal(t, expected, received)
+
+	// the state is not served by the nodes without the state storage
+	stream, err = newMockGrpcClient(t, &syncPeerService{}).GetStateRange(context.Background(),
+		&proto.GetStateRangeRequest{Root: root.Bytes()})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.ErrorContains(t, err, ErrStateSyncUnsupported.Error())
+}
@@ -0,0 +1,351 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// stateSyncPivotDistance is the number of blocks the state sync pivot is behind the best peer's latest block,
+	// so that its state is still kept by the peers which prune the old states
+	stateSyncPivotDistance = 64
+	// stateRangePathLength is the length (in nibbles) of the paths the state is split by for the download
+	stateRangePathLength = 2
+)
+
+var (
+	errNoStateSyncPeers     = errors.New("no peers left to sync the state from")
+	errInvalidStateProof    = errors.New("invalid state range proof")
+	errPivotBlockNotReached = errors.New("stream closed before the pivot block")
+)
+
+// shouldSyncState returns whether the state has to be downloaded from the peers,
+// which is the case when the local chain is empty or when the state of its head is missing
+// (i.e. a previous state sync has been interrupted while importing the blocks)
+func (s *syncer) shouldSyncState(header *types.Header) bool {
+	if !s.stateSync {
+		return false
+	}
+
+	if header.Number == 0 {
+		return true
+	}
+
+	if header.StateRoot == types.EmptyRootHash {
+		return false
+	}
+
+	ok, err := s.stateStorage.Has(header.StateRoot.Bytes())
+
+	return err == nil && !ok
+}
+
+// syncStateWithPeer imports the blocks up to the pivot block, which is a recent block of the given peer,
+// along with their receipts, without executing them, and then downloads the state of the pivot from all
+// the peers which have it. The blocks are imported first, so that the header of the pivot, whose state root
+// the state is verified against, is verified by the consensus (including its seals) along with its ancestors.
+// The pivot is never below the local head, so if the head has no state (i.e. a previous state sync
+// has been interrupted) and the peer is not far enough ahead, the state of the head itself is downloaded.
+// The callback is invoked only for the pivot block, since the state of the blocks below it is not available
+func (s *syncer) syncStateWithPeer(
+	bestPeer *NoForkPeer,
+	newBlockCallback func(*types.FullBlock) bool,
+) (bool, error) {
+	s.lock.RLock()
+	blockTimeout := s.blockTimeout
+	s.lock.RUnlock()
+
+	var (
+		pivot  *types.FullBlock
+		header = s.blockchain.Header()
+	)
+
+	if bestPeer.Number > header.Number+stateSyncPivotDistance {
+		var err error
+
+		pivot, err = s.importBlocksWithoutState(bestPeer.ID, bestPeer.Number, bestPeer.Number-stateSyncPivotDistance)
+		if err != nil {
+			return false, err
+		}
+
+		header = pivot.Block.Header
+	}
+
+	peers := s.statePeers(header.Number)
+
+	s.logger.Info("state sync started", "pivot", header.Number, "root", header.StateRoot, "peers", len(peers))
+
+	start := time.Now().UTC()
+
+	if err := s.syncState(header.StateRoot, peers, blockTimeout); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("state synced", "pivot", header.Number, "root", header.StateRoot,
+		"duration", time.Since(start))
+
+	if pivot == nil {
+		// the blocks are executed from the head on
+		return false, nil
+	}
+
+	return newBlockCallback(pivot), nil
+}
+
+// statePeers returns the peers which have the block with the given number, the best ones first
+func (s *syncer) statePeers(number uint64) []peer.ID {
+	candidates := s.peerMap.PeersFrom(number, nil)
+
+	peers := make([]peer.ID, len(candidates))
+	for i, p := range candidates {
+		peers[i] = p.ID
+	}
+
+	return peers
+}
+
+// syncState downloads the state with the given root from the given peers in parallel.
+// The state is split into ranges by the path prefix, each peer fetches one range at a time
+// and a peer which fails to deliver a range is not used anymore. Once all the ranges are stored,
+// the whole state is verified against the root
+func (s *syncer) syncState(root types.Hash, peers []peer.ID, timeout time.Duration) error {
+	rangesNum := 1 << (4 * stateRangePathLength)
+	ranges := make(chan []byte, rangesNum)
+
+	for i := 0; i < rangesNum; i++ {
+		path := make([]byte, stateRangePathLength)
+		for j := range path {
+			path[j] = byte(i>>(4*(stateRangePathLength-j-1))) & 0xf
+		}
+
+		ranges <- path
+	}
+
+	var (
+		pending atomic.Int64
+		doneCh  = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+
+	pending.Store(int64(rangesNum))
+
+	for _, peerID := range peers {
+		peerID := peerID
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-doneCh:
+					return
+				case path := <-ranges:
+					if err := s.fetchStateRange(peerID, root, path, timeout); err != nil {
+						s.logger.Warn("failed to fetch state range, peer dropped from state sync",
+							"peer ID", peerID, "path", path, "error", err)
+
+						// hand the range over to the other peers
+						ranges <- path
+
+						return
+					}
+
+					if pending.Add(-1) == 0 {
+						close(doneCh)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if left := pending.Load(); left > 0 {
+		return fmt.Errorf("%w, %d state ranges left", errNoStateSyncPeers, left)
+	}
+
+	return itrie.VerifyState(root, s.stateStorage)
+}
+
+// fetchStateRange fetches the state range under the given path from the peer and stores it chunk by chunk.
+// The proof of the range must lead from the state root to the range, and it's verified as soon as
+// the range itself starts arriving, while the trie nodes and the codes are stored by their hash,
+// so that they can't be tampered with
+func (s *syncer) fetchStateRange(peerID peer.ID, root types.Hash, path []byte, timeout time.Duration) error {
+	var (
+		proof         [][]byte
+		proofVerified bool
+		size          int
+	)
+
+	verifyProof := func() error {
+		if err := verifyStateRangeProof(root, path, proof); err != nil {
+			return err
+		}
+
+		proofVerified = true
+
+		batch := s.stateStorage.Batch()
+		for _, node := range proof {
+			batch.Put(crypto.Keccak256(node), node)
+
+			size += len(node)
+		}
+
+		return batch.Write()
+	}
+
+	err := s.syncPeerClient.GetStateRange(peerID, root, path, timeout, func(chunk *StateRange) error {
+		if len(chunk.Proof) > 0 {
+			if proofVerified {
+				return fmt.Errorf("%w: proof received after the range", errInvalidStateProof)
+			}
+
+			proof = append(proof, chunk.Proof...)
+		}
+
+		if len(chunk.Nodes) == 0 && len(chunk.Codes) == 0 {
+			return nil
+		}
+
+		if !proofVerified {
+			if err := verifyProof(); err != nil {
+				return err
+			}
+		}
+
+		batch := s.stateStorage.Batch()
+		for _, node := range chunk.Nodes {
+			batch.Put(crypto.Keccak256(node), node)
+
+			size += len(node)
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		for _, code := range chunk.Codes {
+			if err := s.stateStorage.SetCode(types.BytesToHash(crypto.Keccak256(code)), code); err != nil {
+				return err
+			}
+
+			size += len(code)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the range might be empty
+	if !proofVerified {
+		if err := verifyProof(); err != nil {
+			return err
+		}
+	}
+
+	metrics.IncrCounter([]string{syncerMetrics, "state_ingress_bytes"}, float32(size))
+
+	return nil
+}
+
+// verifyStateRangeProof makes sure that the proof leads from the state root to the range under the given path
+func verifyStateRangeProof(root types.Hash, path []byte, proof [][]byte) error {
+	if len(proof) == 0 {
+		return fmt.Errorf("%w: proof is empty", errInvalidStateProof)
+	}
+
+	if err := itrie.VerifyStateRangeProof(root, path, proof); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidStateProof, err)
+	}
+
+	return nil
+}
+
+// importBlocksWithoutState imports the blocks up to the pivot from the peer, along with their receipts,
+// without executing them, and returns the pivot block
+func (s *syncer) importBlocksWithoutState(
+	peerID peer.ID,
+	peerLatestBlock uint64,
+	pivotNumber uint64,
+) (*types.FullBlock, error) {
+	localLatest := s.blockchain.Header().Number
+
+	s.lock.RLock()
+	blockTimeout := s.blockTimeout
+	s.lock.RUnlock()
+
+	blockCh, err := s.syncPeerClient.GetBlocksWithReceipts(peerID, localLatest+1, blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(peerLatestBlock)
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+
+		go func() {
+			for range blockCh {
+			}
+		}()
+
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	for {
+		select {
+		case fullBlock, ok := <-blockCh:
+			if !ok {
+				return nil, errPivotBlockNotReached
+			}
+
+			block := fullBlock.Block
+
+			// safe check
+			if block.Number() == 0 {
+				continue
+			}
+
+			if err := s.blockchain.VerifyFinalizedBlockWithoutState(block, fullBlock.Receipts); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+				s.syncPeerClient.ReportInvalidBlock(peerID, block.Number())
+
+				return nil, fmt.Errorf("unable to verify block, %w", err)
+			}
+
+			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return nil, fmt.Errorf("failed to write block while importing blocks: %w", err)
+			}
+
+			updateMetrics(fullBlock)
+
+			if block.Number() == pivotNumber {
+				return fullBlock, nil
+			}
+		case <-time.After(blockTimeout):
+			return nil, errTimeout
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package syncer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// newTestState commits a state with a number of accounts, some of them with code and storage
+func newTestState(t *testing.T) (itrie.Storage, types.Hash) {
+	t.Helper()
+
+	storage := itrie.NewMemoryStorage()
+	st := itrie.NewState(storage)
+
+	code := []byte{0x1, 0x2, 0x3}
+	codeHash := types.BytesToHash(crypto.Keccak256(code))
+
+	require.NoError(t, st.SetCode(codeHash, code))
+
+	objects := make([]*state.Object, 0, 100)
+
+	for i := 0; i < 100; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i + 1)),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%5 == 0 {
+			obj.CodeHash = codeHash
+			obj.Storage = []*state.StorageObject{
+				{Key: types.StringToHash("1").Bytes(), Val: big.NewInt(int64(i + 1)).Bytes()},
+			}
+		}
+
+		objects = append(objects, obj)
+	}
+
+	snap, err := st.NewSnapshot(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	_, root, err := snap.Commit(objects)
+	require.NoError(t, err)
+
+	return storage, types.BytesToHash(root)
+}
+
+// serveStateRange returns the state range as a peer would serve it
+func serveStateRange(storage itrie.Storage, root types.Hash, path []byte) (*StateRange, error) {
+	stateRange := &StateRange{}
+
+	err := itrie.WalkStateRange(root, path, storage, &itrie.StateRangeVisitor{
+		Proof: func(node []byte) error {
+			stateRange.Proof = append(stateRange.Proof, node)
+
+			return nil
+		},
+		Node: func(node []byte) error {
+			stateRange.Nodes = append(stateRange.Nodes, node)
+
+			return nil
+		},
+		Code: func(code []byte) error {
+			stateRange.Codes = append(stateRange.Codes, code)
+
+			return nil
+		},
+	})
+
+	return stateRange, err
+}
+
+func Test_syncState(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+
+	var (
+		errPeerNoResponse = errors.New("peer is not responding")
+
+		honestPeer       = peer.ID("A")
+		unresponsivePeer = peer.ID("B")
+		maliciousPeer    = peer.ID("C")
+	)
+
+	getStateRange := func(id peer.ID, root types.Hash, path []byte) (*StateRange, error) {
+		switch id {
+		case honestPeer:
+			return serveStateRange(source, root, path)
+		case maliciousPeer:
+			stateRange, err := serveStateRange(source, root, path)
+			if err != nil {
+				return nil, err
+			}
+
+			// the range is served without the proof
+			stateRange.Proof = nil
+
+			return stateRange, nil
+		default:
+			return nil, errPeerNoResponse
+		}
+	}
+
+	tests := []struct {
+		name  string
+		peers []peer.ID
+		err   error
+	}{
+		{
+			name:  "should sync the state from the honest peer",
+			peers: []peer.ID{unresponsivePeer, maliciousPeer, honestPeer},
+		},
+		{
+			name:  "should fail when there are no peers left",
+			peers: []peer.ID{unresponsivePeer, maliciousPeer},
+			err:   errNoStateSyncPeers,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer := NewTestSyncer(
+				nil,
+				&mockBlockchain{},
+				time.Second,
+				&mockSyncPeerClient{getStateRangeHandler: getStateRange},
+				&mockProgression{},
+			)
+			syncer.stateStorage = itrie.NewMemoryStorage()
+
+			err := syncer.syncState(root, test.peers, time.Second)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, itrie.VerifyState(root, syncer.stateStorage))
+		})
+	}
+}
+
+func Test_verifyStateRangeProof(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+
+	path := []byte{0x1, 0x2}
+
+	stateRange, err := serveStateRange(source, root, path)
+	require.NoError(t, err)
+	require.NoError(t, verifyStateRangeProof(root, path, stateRange.Proof))
+
+	require.ErrorIs(t, verifyStateRangeProof(root, path, nil), errInvalidStateProof)
+	require.ErrorIs(t, verifyStateRangeProof(types.StringToHash("1"), path, stateRange.Proof), errInvalidStateProof)
+
+	// proof node which is not referenced by the root
+	tampered := [][]byte{stateRange.Proof[0], {0x1, 0x2, 0x3}}
+	require.ErrorIs(t, verifyStateRangeProof(root, path, tampered), errInvalidStateProof)
+
+	// proof of another range, whose nodes are referenced by the root as well
+	require.ErrorIs(t, verifyStateRangeProof(root, []byte{0x3, 0x4}, stateRange.Proof), errInvalidStateProof)
+}
+
+func TestSync_StateSync(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+
+	const peerLatest = 100
+
+	blocks := createMockBlocks(peerLatest)
+	pivot := blocks[peerLatest-stateSyncPivotDistance-1]
+	pivot.Header.StateRoot = root
+
+	numbers := func(from, to uint64) []uint64 {
+		res := make([]uint64, 0, to-from+1)
+		for i := from; i <= to; i++ {
+			res = append(res, i)
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name string
+		// the number of the block the first import of the blocks without state is interrupted after
+		interruptedAt uint64
+	}{
+		{
+			name: "should import the blocks up to the pivot and execute the later ones",
+		},
+		{
+			name:          "should sync the state again when the import is interrupted",
+			interruptedAt: 20,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock           sync.Mutex
+				latest         = &types.Header{}
+				imports        int
+				importedBlocks []uint64
+				executedBlocks []uint64
+				callbackBlocks []uint64
+				peerID         = peer.ID("A")
+				peerStatus     = &NoForkPeer{ID: peerID, Number: peerLatest, Distance: big.NewInt(0)}
+			)
+
+			syncer := NewTestSyncer(
+				nil,
+				&mockBlockchain{
+					headerHandler: func() *types.Header {
+						lock.Lock()
+						defer lock.Unlock()
+
+						return latest
+					},
+					verifyWithoutStateHandler: func(b *types.Block, receipts []*types.Receipt) error {
+						importedBlocks = append(importedBlocks, b.Number())
+
+						return nil
+					},
+					verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+						executedBlocks = append(executedBlocks, b.Number())
+
+						return &types.FullBlock{Block: b}, nil
+					},
+					writeFullBlockHandler: func(b *types.FullBlock) error {
+						lock.Lock()
+						defer lock.Unlock()
+
+						latest = b.Block.Header
+
+						return nil
+					},
+				},
+				time.Second,
+				&mockSyncPeerClient{
+					getConnectedPeerStatusesHandler: func() []*NoForkPeer {
+						return []*NoForkPeer{peerStatus}
+					},
+					getBlocksHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.Block, error) {
+						return blocksToCh(blocks[from-1:], 0), nil
+					},
+					getBlocksWithReceiptsHandler: func(
+						_ peer.ID, from uint64, _ time.Duration,
+					) (<-chan *types.FullBlock, error) {
+						to := uint64(len(blocks))
+
+						imports++
+						if imports == 1 && test.interruptedAt > 0 {
+							to = test.interruptedAt
+						}
+
+						ch := make(chan *types.FullBlock)
+
+						go func() {
+							defer close(ch)
+
+							for _, b := range blocks[from-1 : to] {
+								ch <- &types.FullBlock{Block: b}
+							}
+						}()
+
+						return ch, nil
+					},
+					getStateRangeHandler: func(_ peer.ID, root types.Hash, path []byte) (*StateRange, error) {
+						return serveStateRange(source, root, path)
+					},
+				},
+				&mockProgression{},
+			)
+			syncer.stateSync = true
+			syncer.stateStorage = itrie.NewMemoryStorage()
+
+			errCh := make(chan error, 1)
+
+			go func() {
+				errCh <- syncer.Sync(func(b *types.FullBlock) bool {
+					callbackBlocks = append(callbackBlocks, b.Block.Number())
+
+					return b.Block.Number() >= peerLatest
+				})
+			}()
+
+			syncer.peerMap.Put(peerStatus)
+			syncer.newStatusCh <- struct{}{}
+
+			select {
+			case err := <-errCh:
+				require.NoError(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("sync not completed")
+			}
+
+			// the blocks up to the pivot are imported without execution, while the later ones are executed.
+			// None of the blocks is executed on top of a head without state
+			assert.Equal(t, numbers(1, pivot.Number()), importedBlocks)
+			assert.Equal(t, numbers(pivot.Number()+1, peerLatest), executedBlocks)
+			assert.Equal(t, numbers(pivot.Number(), peerLatest), callbackBlocks)
+			assert.NoError(t, itrie.VerifyState(root, syncer.stateStorage))
+		})
+	}
+}
+
+func Test_syncStateWithPeer_InvalidPivot(t *testing.T) {
+	t.Parallel()
+
+	const peerLatest = 100
+
+	var (
+		blocks      = createMockBlocks(peerLatest)
+		pivotNumber = uint64(peerLatest - stateSyncPivotDistance)
+		peerID      = peer.ID("A")
+		stateRanges atomic.Int64
+	)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(0),
+			verifyWithoutStateHandler: func(b *types.Block, _ []*types.Receipt) error {
+				if b.Number() == pivotNumber {
+					return errors.New("invalid seals")
+				}
+
+				return nil
+			},
+			writeFullBlockHandler: func(*types.FullBlock) error {
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getBlocksWithReceiptsHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.FullBlock, error) {
+				ch := make(chan *types.FullBlock, len(blocks))
+
+				for _, b := range blocks[from-1:] {
+					ch <- &types.FullBlock{Block: b}
+				}
+
+				close(ch)
+
+				return ch, nil
+			},
+			getStateRangeHandler: func(peer.ID, types.Hash, []byte) (*StateRange, error) {
+				stateRanges.Add(1)
+
+				return &StateRange{}, nil
+			},
+		},
+		&mockProgression{},
+	)
+	syncer.stateStorage = itrie.NewMemoryStorage()
+
+	_, err := syncer.syncStateWithPeer(
+		&NoForkPeer{ID: peerID, Number: peerLatest, Distance: big.NewInt(0)},
+		func(*types.FullBlock) bool { return false },
+	)
+	require.ErrorContains(t, err, "invalid seals")
+
+	// the state of the pivot is not requested unless its header is verified
+	require.Zero(t, stateRanges.Load())
+}
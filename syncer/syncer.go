@@ -8,6 +8,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-metrics"
@@ -31,9 +32,15 @@ type syncer struct {
 	syncProgression Progression
 
 	peerMap         *PeerMap
+	peerScores      *peerScores
 	syncPeerService SyncPeerService
 	syncPeerClient  SyncPeerClient
 
+	// stateStorage is the storage the synced state is written to
+	stateStorage itrie.Storage
+	// stateSync enables downloading the state from the peers instead of executing all the blocks
+	stateSync bool
+
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
@@ -47,17 +54,22 @@ func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
+	stateSync bool,
 	blockTimeout time.Duration,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, stateStorage),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
+		stateStorage:    stateStorage,
+		stateSync:       stateSync && stateStorage != nil,
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
+		peerScores:      newPeerScores(),
 	}
 }
 
@@ -146,6 +158,7 @@ func (s *syncer) putToPeerMap(status *NoForkPeer) {
 // removeFromPeerMap removes the peer from peer map
 func (s *syncer) removeFromPeerMap(peerID peer.ID) {
 	s.peerMap.Remove(peerID)
+	s.peerScores.remove(peerID)
 }
 
 // notifyNewStatusEvent emits signal to newStatusCh
@@ -175,6 +188,11 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 	localLatest := s.blockchain.Header().Number
 	skipList := make(map[peer.ID]bool)
 
+	// on an empty chain the state sync is attempted once, in case it fails the blocks are executed.
+	// Once the blocks below the pivot are imported without their state, the state sync is retried
+	// until it succeeds, since no block can be executed on top of a head without state
+	stateSyncFailed := false
+
 	for {
 		s.lock.RLock()
 		blockTimeout := s.blockTimeout
@@ -208,14 +226,49 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
-		// fetch block from the peer
-		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+		if header := s.blockchain.Header(); s.shouldSyncState(header) &&
+			(header.Number > 0 || (!stateSyncFailed && bestPeer.Number > localLatest+stateSyncPivotDistance)) {
+			shouldTerminate, err := s.syncStateWithPeer(bestPeer, callback)
+			if err != nil {
+				if s.blockchain.Header().Number > 0 {
+					s.logger.Warn("failed to sync state, try to next peer", "peer ID", bestPeer.ID, "error", err)
+
+					skipList[bestPeer.ID] = true
+
+					continue
+				}
+
+				stateSyncFailed = true
+
+				s.logger.Warn("failed to sync state, falling back to full sync", "peer ID", bestPeer.ID, "error", err)
+			} else if shouldTerminate {
+				break
+			}
+		}
+
+		var (
+			lastNumber      uint64
+			shouldTerminate bool
+			err             error
+		)
+
+		// fetch blocks from all the peers ahead, or from the best peer if it's the only one
+		peers := s.peerMap.PeersFrom(localLatest+1, skipList)
+		if len(peers) > 1 {
+			lastNumber, shouldTerminate, err = s.parallelSyncWithPeers(peers, skipList, callback)
+		} else {
+			lastNumber, shouldTerminate, err = s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+		}
+
 		if err != nil {
 			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
 		}
 
 		if lastNumber < bestPeer.Number {
-			skipList[bestPeer.ID] = true
+			// the peers failing the parallel sync are already skipped by its scheduler
+			if len(peers) <= 1 {
+				skipList[bestPeer.ID] = true
+			}
 
 			// continue to next peer
 			continue
@@ -277,6 +330,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
 			if err != nil {
 				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+				s.syncPeerClient.ReportInvalidBlock(peerID, block.Number())
 
 				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
 			}
@@ -297,6 +351,18 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 	}
 }
 
+// closeBlockStream closes the block stream to the peer and drains the remaining blocks
+func (s *syncer) closeBlockStream(peerID peer.ID, blockCh <-chan *types.Block) {
+	if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+		s.logger.Error("Failed to close stream: ", err)
+	}
+
+	go func() {
+		for range blockCh {
+		}
+	}()
+}
+
 func updateMetrics(fullBlock *types.FullBlock) {
 	metrics.SetGauge([]string{syncerMetrics, "tx_num"}, float32(len(fullBlock.Block.Transactions)))
 	metrics.SetGauge([]string{syncerMetrics, "receipts_num"}, float32(len(fullBlock.Receipts)))
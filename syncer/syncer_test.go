@@ -40,6 +40,8 @@ type mockBlockchain struct {
 	headerHandler               func() *types.Header
 	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
+	verifyWithoutStateHandler   func(*types.Block, []*types.Receipt) error
+	getReceiptsByHashHandler    func(types.Hash) ([]*types.Receipt, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
 }
@@ -63,6 +65,14 @@ func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock,
 	return m.verifyFinalizedBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedBlockWithoutState(b *types.Block, receipts []*types.Receipt) error {
+	return m.verifyWithoutStateHandler(b, receipts)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
 func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
@@ -93,8 +103,12 @@ type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getBlocksWithReceiptsHandler          func(peer.ID, uint64, time.Duration) (<-chan *types.FullBlock, error)
+	getBlockRangeHandler                  func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+	getStateRangeHandler                  func(peer.ID, types.Hash, []byte) (*StateRange, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+	reportInvalidBlockHandler             func(peer.ID, uint64)
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -123,6 +137,42 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetBlocksWithReceipts(
+	id peer.ID,
+	start uint64,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.FullBlock, error) {
+	return m.getBlocksWithReceiptsHandler(id, start, timeoutPerBlock)
+}
+
+func (m *mockSyncPeerClient) GetBlockRange(
+	id peer.ID,
+	from, to uint64,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.Block, error) {
+	return m.getBlockRangeHandler(id, from, to, timeoutPerBlock)
+}
+
+func (m *mockSyncPeerClient) GetStateRange(
+	id peer.ID,
+	root types.Hash,
+	path []byte,
+	timeout time.Duration,
+	handler func(*StateRange) error,
+) error {
+	stateRange, err := m.getStateRangeHandler(id, root, path)
+	if err != nil {
+		return err
+	}
+
+	// the proof is sent in its own chunk ahead of the range
+	if err := handler(&StateRange{Proof: stateRange.Proof}); err != nil {
+		return err
+	}
+
+	return handler(&StateRange{Nodes: stateRange.Nodes, Codes: stateRange.Codes})
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -135,6 +185,12 @@ func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
 
+func (m *mockSyncPeerClient) ReportInvalidBlock(peerID peer.ID, blockNumber uint64) {
+	if m.reportInvalidBlockHandler != nil {
+		m.reportInvalidBlockHandler(peerID, blockNumber)
+	}
+}
+
 func GetAllElementsFromPeerMap(t *testing.T, p *PeerMap) []*NoForkPeer {
 	t.Helper()
 
@@ -176,6 +232,7 @@ func NewTestSyncer(
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
+		peerScores:      newPeerScores(),
 	}
 }
 
@@ -629,6 +686,10 @@ func TestSync(t *testing.T) {
 
 							return peerCh, nil
 						},
+						// used when the blocks are downloaded from multiple peers in parallel
+						getBlockRangeHandler: func(_ peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+							return blocksToCh(blocks[from-1:to], 0), nil
+						},
 					},
 					progression,
 				)
@@ -701,6 +762,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		lastSyncedBlockNumber uint64
 		shouldTerminate       bool
 		err                   error
+		reportedBlocks        []uint64
 	}{
 		{
 			name:            "should sync blocks to the latest successfully",
@@ -768,6 +830,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			lastSyncedBlockNumber: 5,
 			shouldTerminate:       false,
 			err:                   errInvalidBlock,
+			reportedBlocks:        []uint64{6},
 		},
 		{
 			name:            "should return error if block insertion is failed",
@@ -824,7 +887,8 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			t.Parallel()
 
 			var (
-				syncedBlocks = make([]*types.Block, 0, len(test.blocks))
+				syncedBlocks   = make([]*types.Block, 0, len(test.blocks))
+				reportedBlocks []uint64
 
 				syncer = NewTestSyncer(
 					nil,
@@ -844,6 +908,11 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 					test.blockTimeout,
 					&mockSyncPeerClient{
 						getBlocksHandler: test.getBlocksHandler,
+						reportInvalidBlockHandler: func(id peer.ID, blockNumber uint64) {
+							assert.Equal(t, peer.ID("X"), id)
+
+							reportedBlocks = append(reportedBlocks, blockNumber)
+						},
 					},
 					&mockProgression{},
 				)
@@ -855,6 +924,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
+			assert.Equal(t, test.reportedBlocks, reportedBlocks)
 		})
 	}
 }
@@ -29,6 +29,10 @@ type Blockchain interface {
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
+	// VerifyFinalizedBlockWithoutState verifies finalized block and its receipts without executing it
+	VerifyFinalizedBlockWithoutState(block *types.Block, receipts []*types.Receipt) error
+	// GetReceiptsByHash returns the receipts of the block with the given hash
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
@@ -57,6 +61,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// ReportPeer lowers the reputation of the peer because of its misbehaviour
+	ReportPeer(peerID peer.ID, penalty int64, reason string)
 }
 
 type Syncer interface {
@@ -103,6 +109,13 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetBlockRange returns a stream of blocks in the given range, to = 0 stands for peer's latest
+	GetBlockRange(id peer.ID, from, to uint64, timeout time.Duration) (<-chan *types.Block, error)
+	// GetBlocksWithReceipts returns a stream of blocks, along with their receipts, from given height to peer's latest
+	GetBlocksWithReceipts(peer.ID, uint64, time.Duration) (<-chan *types.FullBlock, error)
+	// GetStateRange streams the trie nodes and codes under the given path of the state with the given root
+	// to the handler, chunk by chunk
+	GetStateRange(peer.ID, types.Hash, []byte, time.Duration, func(*StateRange) error) error
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
@@ -113,4 +126,16 @@ type SyncPeerClient interface {
 	DisablePublishingPeerStatus()
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
 	EnablePublishingPeerStatus()
+	// ReportInvalidBlock reports the peer which sent a block failing the verification
+	ReportInvalidBlock(peerID peer.ID, blockNumber uint64)
+}
+
+// StateRange holds the trie nodes and codes of a chunk of a state range received from a peer
+type StateRange struct {
+	// Proof holds the stored trie nodes on the way from the root to the range
+	Proof [][]byte
+	// Nodes holds the stored trie nodes of the range, including the storage tries of its accounts
+	Nodes [][]byte
+	// Codes holds the contract codes of the accounts of the range
+	Codes [][]byte
 }
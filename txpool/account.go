@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"maps"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -219,6 +220,11 @@ func (m *nonceToTxLookup) lock() {
 	m.mutex.Lock()
 }
 
+// tryLock is the non-blocking version of lock, it returns false if the lookup is already locked
+func (m *nonceToTxLookup) tryLock() bool {
+	return m.mutex.TryLock()
+}
+
 func (m *nonceToTxLookup) unlock() {
 	m.mutex.Unlock()
 }
@@ -457,3 +463,81 @@ func (a *account) getLowestTx() *types.Transaction {
 
 	return nil
 }
+
+// pooledTxs returns the promoted and the enqueued transactions of the account, ordered by nonce
+func (a *account) pooledTxs() []*types.Transaction {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	txs := make([]*types.Transaction, 0, a.promoted.length()+a.enqueued.length())
+	txs = append(txs, a.promoted.queue...)
+	txs = append(txs, a.enqueued.queue...)
+
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce() < txs[j].Nonce()
+	})
+
+	return txs
+}
+
+// localAccounts is a thread safe set of the accounts
+// whose transactions were submitted through this node, bounded by the limit
+type localAccounts struct {
+	lock     sync.RWMutex
+	accounts map[types.Address]struct{}
+	limit    int
+}
+
+func newLocalAccounts(limit int) *localAccounts {
+	return &localAccounts{accounts: make(map[types.Address]struct{}), limit: limit}
+}
+
+// add adds the account to the set, unless the set is full.
+// Returns true if the account is part of the set
+func (l *localAccounts) add(addr types.Address) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, ok := l.accounts[addr]; ok {
+		return true
+	}
+
+	if len(l.accounts) >= l.limit {
+		return false
+	}
+
+	l.accounts[addr] = struct{}{}
+
+	return true
+}
+
+func (l *localAccounts) remove(addr types.Address) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.accounts, addr)
+}
+
+func (l *localAccounts) contains(addr types.Address) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	_, ok := l.accounts[addr]
+
+	return ok
+}
+
+func (l *localAccounts) list() []types.Address {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	addrs := make([]types.Address, 0, len(l.accounts))
+	for addr := range l.accounts {
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
@@ -0,0 +1,164 @@
+package txpool
+
+import (
+	"math/big"
+
+	"github.com/hashicorp/go-metrics"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// evictionCandidate is the next transaction of an account to be evicted
+type evictionCandidate struct {
+	account *account
+	tx      *types.Transaction
+	tip     *big.Int
+}
+
+// evictUnderpriced makes room for the transaction in the full pool, by evicting the remote transactions
+// paying a lower effective tip. The accounts' transactions are evicted starting from the highest nonce,
+// so no nonce gaps are left behind. The transactions of the local accounts are never evicted, nor are
+// the first promoted transactions of the accounts, which might be already selected for the block being built.
+// Must be called holding the locks of the account of the transaction. The locks of the other accounts
+// are only tried, skipping the accounts which are busy, so that no lock order between the accounts is needed
+func (p *TxPool) evictUnderpriced(tx *types.Transaction, slots uint64) {
+	baseFee := new(big.Int).SetUint64(p.GetBaseFee())
+	minTip := tx.EffectiveGasTip(baseFee)
+
+	candidates := make([]*evictionCandidate, 0)
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account, _ := value.(*account)
+
+		if addr == tx.From() || p.locals.contains(addr) {
+			return true
+		}
+
+		if candidate := nextEvictionCandidate(account, baseFee, minTip); candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+
+		return true
+	})
+
+	evicted := make([]*types.Transaction, 0)
+
+	for p.gauge.freeSlots() < slots && len(candidates) > 0 {
+		// the cheapest candidate is evicted first
+		cheapest := 0
+
+		for i, candidate := range candidates {
+			if candidate.tip.Cmp(candidates[cheapest].tip) < 0 {
+				cheapest = i
+			}
+		}
+
+		candidate := candidates[cheapest]
+
+		if p.evictTx(candidate) {
+			evicted = append(evicted, candidate.tx)
+		}
+
+		if next := nextEvictionCandidate(candidate.account, baseFee, minTip); next != nil {
+			candidates[cheapest] = next
+		} else {
+			candidates = append(candidates[:cheapest], candidates[cheapest+1:]...)
+		}
+	}
+
+	if len(evicted) == 0 {
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "evicted_tx"}, float32(len(evicted)))
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, toHash(evicted...)...)
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("evicted underpriced txs", "num", len(evicted), "hash", tx.Hash().String())
+	}
+}
+
+// nextEvictionCandidate returns the transaction of the account to be evicted next,
+// nil if there is none paying less than the given tip
+func nextEvictionCandidate(account *account, baseFee, minTip *big.Int) *evictionCandidate {
+	if !account.promoted.tryLock(false) {
+		return nil
+	}
+	defer account.promoted.unlock()
+
+	if !account.enqueued.tryLock(false) {
+		return nil
+	}
+	defer account.enqueued.unlock()
+
+	tx := evictableTx(account)
+	if tx == nil {
+		return nil
+	}
+
+	tip := tx.EffectiveGasTip(baseFee)
+	if tip.Cmp(minTip) >= 0 {
+		return nil
+	}
+
+	return &evictionCandidate{account: account, tx: tx, tip: tip}
+}
+
+// evictableTx returns the transaction of the account with the highest nonce,
+// unless it is the first promoted one. The queues must be locked
+func evictableTx(account *account) *types.Transaction {
+	if tx := account.enqueued.last(); tx != nil {
+		return tx
+	}
+
+	if account.promoted.length() > 1 {
+		return account.promoted.last()
+	}
+
+	return nil
+}
+
+// evictTx removes the candidate from the pool, if it's still the next evictable transaction of its account
+// and the account is not busy
+func (p *TxPool) evictTx(candidate *evictionCandidate) bool {
+	account := candidate.account
+
+	if !account.promoted.tryLock(true) {
+		return false
+	}
+	defer account.promoted.unlock()
+
+	if !account.enqueued.tryLock(true) {
+		return false
+	}
+	defer account.enqueued.unlock()
+
+	if !account.nonceToTx.tryLock() {
+		return false
+	}
+	defer account.nonceToTx.unlock()
+
+	if evictableTx(account) != candidate.tx {
+		return false
+	}
+
+	if account.enqueued.length() > 0 {
+		account.enqueued.popLast()
+	} else {
+		account.promoted.popLast()
+
+		// the evicted nonce is expected again
+		account.setNonce(candidate.tx.Nonce())
+
+		p.updatePending(-1)
+	}
+
+	account.nonceToTx.remove(candidate.tx)
+	p.index.remove(candidate.tx)
+	p.gauge.decrease(slotsRequired(candidate.tx))
+
+	return true
+}
@@ -0,0 +1,147 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newPricedTx(t *testing.T, sender *eoa, nonce uint64, gasPrice int64) *types.Transaction {
+	t.Helper()
+
+	tx := newTx(sender.Address, nonce, 1, types.LegacyTxType)
+	tx.SetGasPrice(big.NewInt(gasPrice))
+
+	return sender.signTx(t, tx, signerLondon)
+}
+
+func TestEvictUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPoolWithSlots(4)
+	require.NoError(t, err)
+
+	pool.SetSigner(signerLondon)
+
+	subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_DROPPED})
+	defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+	var (
+		localAccount   = new(eoa).create(t)
+		remoteAccounts = []*eoa{new(eoa).create(t), new(eoa).create(t), new(eoa).create(t)}
+		richAccounts   = []*eoa{new(eoa).create(t), new(eoa).create(t)}
+
+		localTx   = newPricedTx(t, localAccount, 0, 1)
+		remoteTxs = []*types.Transaction{
+			newPricedTx(t, remoteAccounts[0], 0, 3),
+			newPricedTx(t, remoteAccounts[1], 0, 2),
+			newPricedTx(t, remoteAccounts[2], 0, 5),
+		}
+	)
+
+	require.NoError(t, pool.AddTx(localTx))
+
+	for _, tx := range remoteTxs {
+		require.NoError(t, pool.addTx(gossip, tx))
+	}
+
+	// the transaction paying no more than the cheapest remote one is rejected
+	require.ErrorIs(t, pool.addTx(gossip, newPricedTx(t, richAccounts[0], 0, 2)), ErrTxPoolOverflow)
+
+	// the cheapest remote transactions are evicted, while the cheaper local one is kept
+	require.NoError(t, pool.addTx(gossip, newPricedTx(t, richAccounts[0], 0, 4)))
+	require.NoError(t, pool.addTx(gossip, newPricedTx(t, richAccounts[1], 0, 4)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events := waitForEvents(ctx, subscription, 2)
+	require.Len(t, events, 2)
+	assert.Equal(t, remoteTxs[1].Hash().String(), events[0].TxHash)
+	assert.Equal(t, remoteTxs[0].Hash().String(), events[1].TxHash)
+
+	for hash, expected := range map[types.Hash]bool{
+		localTx.Hash():      true,
+		remoteTxs[0].Hash(): false,
+		remoteTxs[1].Hash(): false,
+		remoteTxs[2].Hash(): true,
+	} {
+		_, ok := pool.GetPendingTx(hash)
+		assert.Equal(t, expected, ok)
+	}
+
+	assert.Equal(t, uint64(4), pool.gauge.read())
+}
+
+func TestEvictUnderpriced_Promoted(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPoolWithSlots(3)
+	require.NoError(t, err)
+
+	pool.SetSigner(signerLondon)
+	pool.Start()
+
+	defer pool.Close()
+
+	var (
+		remoteAccount = new(eoa).create(t)
+		richAccount   = new(eoa).create(t)
+	)
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		require.NoError(t, pool.addTx(gossip, newPricedTx(t, remoteAccount, nonce, 1)))
+	}
+
+	account := pool.accounts.get(remoteAccount.Address)
+
+	require.Eventually(t, func() bool {
+		return account.getNonce() == 3
+	}, time.Second, 10*time.Millisecond)
+
+	// the highest promoted transaction is evicted and its nonce is expected again
+	require.NoError(t, pool.addTx(gossip, newPricedTx(t, richAccount, 0, 2)))
+
+	assert.Equal(t, uint64(2), account.getNonce())
+	assert.Equal(t, uint64(2), account.promoted.length())
+	assert.Nil(t, account.nonceToTx.get(2))
+}
+
+func TestEvictUnderpriced_RejectedTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPoolWithSlots(4)
+	require.NoError(t, err)
+
+	pool.SetSigner(signerLondon)
+
+	var (
+		remoteAccount = new(eoa).create(t)
+		richAccount   = new(eoa).create(t)
+	)
+
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		require.NoError(t, pool.addTx(gossip, newPricedTx(t, remoteAccount, nonce, 1)))
+	}
+
+	require.True(t, pool.gauge.highPressure())
+
+	// the future transaction is rejected under high pressure, without evicting anything
+	require.ErrorIs(t, pool.addTx(gossip, newPricedTx(t, richAccount, 1, 10)), ErrRejectFutureTx)
+
+	account := pool.accounts.get(remoteAccount.Address)
+
+	assert.Equal(t, uint64(4), account.enqueued.length())
+	assert.Equal(t, uint64(4), pool.gauge.read())
+
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		assert.NotNil(t, account.nonceToTx.get(nonce))
+	}
+}
@@ -0,0 +1,208 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errJournalClosed = errors.New("journal closed")
+
+// journal is an append-only file of the local transactions,
+// which keeps them across the node restarts.
+// Each transaction is stored as its RLP encoding, prefixed with its length
+type journal struct {
+	path string
+
+	lock   sync.Mutex
+	writer *os.File // nil while the journal is being loaded
+	closed bool
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads the transactions from the journal and passes them to the add function.
+// A truncated record at the end of the journal, left by an interrupted write, is ignored
+func (j *journal) load(add func(tx *types.Transaction) error) (loaded, dropped int, err error) {
+	file, err := os.Open(j.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, 0, nil
+		}
+
+		return 0, 0, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		var size uint32
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return loaded, dropped, nil
+			}
+
+			return loaded, dropped, err
+		}
+
+		if size > txMaxSize {
+			return loaded, dropped, fmt.Errorf("journal record of %d bytes exceeds the maximum transaction size", size)
+		}
+
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(reader, raw); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return loaded, dropped, nil
+			}
+
+			return loaded, dropped, err
+		}
+
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return loaded, dropped, fmt.Errorf("failed to decode journal transaction: %w", err)
+		}
+
+		loaded++
+
+		if err := add(tx); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert appends the transaction to the journal and syncs it to the disk.
+// It is a no-op while the journal is not open for writing
+func (j *journal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+
+	if err := writeJournalRecord(j.writer, tx); err != nil {
+		return err
+	}
+
+	return j.writer.Sync()
+}
+
+// rotate replaces the journal with the given transactions and opens it for writing
+func (j *journal) rotate(txs []*types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.closed {
+		return errJournalClosed
+	}
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+
+		j.writer = nil
+	}
+
+	tmpPath := j.path + ".new"
+
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(tmp)
+
+	for _, tx := range txs {
+		if err := writeJournalRecord(writer, tx); err != nil {
+			tmp.Close()
+
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+
+	if err := syncDir(filepath.Dir(j.path)); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	j.writer = file
+
+	return nil
+}
+
+// close flushes the journal to the disk and closes it
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.closed = true
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+// syncDir syncs the directory to the disk, persisting the files renamed into it
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer dir.Close()
+
+	return dir.Sync()
+}
+
+func writeJournalRecord(w io.Writer, tx *types.Transaction) error {
+	raw := tx.MarshalRLP()
+
+	record := make([]byte, 4, 4+len(raw))
+	binary.BigEndian.PutUint32(record, uint32(len(raw)))
+
+	_, err := w.Write(append(record, raw...))
+
+	return err
+}
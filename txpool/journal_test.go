@@ -0,0 +1,167 @@
+package txpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.rlp")
+	j := newJournal(path)
+
+	loadAll := func() []*types.Transaction {
+		t.Helper()
+
+		var txs []*types.Transaction
+
+		_, _, err := newJournal(path).load(func(tx *types.Transaction) error {
+			txs = append(txs, tx)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		return txs
+	}
+
+	// the missing journal is empty
+	assert.Empty(t, loadAll())
+
+	// the transactions are not journaled until the journal is opened by the rotation
+	require.NoError(t, j.insert(newTx(addr1, 0, 1, types.LegacyTxType)))
+
+	txs := []*types.Transaction{
+		newTx(addr1, 0, 1, types.LegacyTxType),
+		newTx(addr1, 1, 1, types.DynamicFeeTxType),
+	}
+
+	for _, tx := range txs {
+		tx.ComputeHash()
+	}
+
+	require.NoError(t, j.rotate(txs[:1]))
+	require.NoError(t, j.insert(txs[1]))
+
+	loaded := loadAll()
+	require.Len(t, loaded, 2)
+
+	for i, tx := range loaded {
+		tx.ComputeHash()
+		assert.Equal(t, txs[i].Hash(), tx.Hash())
+	}
+
+	// the rotation replaces the journaled transactions
+	require.NoError(t, j.rotate(txs[1:]))
+	require.Len(t, loadAll(), 1)
+
+	// the truncated record of an interrupted write is skipped
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0x0, 0x0, 0x1})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	require.Len(t, loadAll(), 1)
+
+	require.NoError(t, j.close())
+	require.ErrorIs(t, j.rotate(txs), errJournalClosed)
+}
+
+func TestTxPool_Journal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.rlp")
+
+	newPool := func() *TxPool {
+		t.Helper()
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			getDefaultEnabledForks(),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				JournalPath:        path,
+				JournalRotation:    time.Hour,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signerLondon)
+		pool.SetBaseFee(mockHeader)
+		pool.Start()
+
+		return pool
+	}
+
+	localAccount := new(eoa).create(t)
+	remoteAccount := new(eoa).create(t)
+
+	pool := newPool()
+
+	localTxs := []*types.Transaction{
+		localAccount.signTx(t, newTx(localAccount.Address, 0, 1, types.LegacyTxType), signerLondon),
+		localAccount.signTx(t, newTx(localAccount.Address, 1, 1, types.LegacyTxType), signerLondon),
+	}
+	remoteTx := remoteAccount.signTx(t, newTx(remoteAccount.Address, 0, 1, types.LegacyTxType), signerLondon)
+
+	for _, tx := range localTxs {
+		require.NoError(t, pool.AddTx(tx))
+	}
+
+	require.NoError(t, pool.addTx(gossip, remoteTx))
+
+	pool.Close()
+
+	// the local transactions are restored after the restart, while the gossiped ones are not
+	pool = newPool()
+	defer pool.Close()
+
+	for _, tx := range localTxs {
+		_, ok := pool.GetPendingTx(tx.Hash())
+		assert.True(t, ok)
+	}
+
+	_, ok := pool.GetPendingTx(remoteTx.Hash())
+	assert.False(t, ok)
+	assert.True(t, pool.locals.contains(localAccount.Address))
+}
+
+func TestTxPool_LocalAccountsLimit(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.locals = newLocalAccounts(1)
+
+	pool.getOrCreateAccount(addr1)
+	pool.getOrCreateAccount(addr2).enqueued.push(newTx(addr2, 0, 1, types.LegacyTxType))
+
+	require.True(t, pool.addLocalAccount(addr1))
+	require.True(t, pool.addLocalAccount(addr1))
+
+	// the local account without transactions in the pool makes room for the new one
+	require.True(t, pool.addLocalAccount(addr2))
+	assert.False(t, pool.locals.contains(addr1))
+	assert.True(t, pool.locals.contains(addr2))
+
+	// the local account with transactions in the pool is kept
+	require.False(t, pool.addLocalAccount(addr3))
+	assert.False(t, pool.locals.contains(addr3))
+	assert.True(t, pool.locals.contains(addr2))
+}
@@ -36,6 +36,23 @@ func (q *accountQueue) lock(write bool) {
 	q.wLock.Store(write)
 }
 
+// tryLock is the non-blocking version of lock, it returns false if the queue is already locked
+func (q *accountQueue) tryLock(write bool) bool {
+	var locked bool
+
+	if write {
+		locked = q.TryLock()
+	} else {
+		locked = q.TryRLock()
+	}
+
+	if locked {
+		q.wLock.Store(write)
+	}
+
+	return locked
+}
+
 func (q *accountQueue) unlock() {
 	if q.wLock.Swap(false) {
 		q.Unlock()
@@ -95,6 +112,43 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// last returns the transaction with the highest nonce without removing it.
+func (q *accountQueue) last() *types.Transaction {
+	if i := q.lastIndex(); i >= 0 {
+		return q.queue[i]
+	}
+
+	return nil
+}
+
+// popLast removes the transaction with the highest nonce from the queue and returns it.
+func (q *accountQueue) popLast() *types.Transaction {
+	i := q.lastIndex()
+	if i < 0 {
+		return nil
+	}
+
+	transaction, ok := heap.Remove(&q.queue, i).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	return transaction
+}
+
+// lastIndex returns the heap index of the transaction with the highest nonce, -1 if the queue is empty.
+func (q *accountQueue) lastIndex() int {
+	last := -1
+
+	for i, tx := range q.queue {
+		if last < 0 || tx.Nonce() > q.queue[last].Nonce() {
+			last = i
+		}
+	}
+
+	return last
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
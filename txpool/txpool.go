@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,13 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// defaultJournalRotation is the default interval of rewriting the journal of the local transactions
+	defaultJournalRotation = time.Hour
+
+	// maxLocalAccounts is the maximum number of the accounts treated as local,
+	// as their transactions are journaled and never evicted
+	maxLocalAccounts = 1024
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -71,6 +79,7 @@ type txOrigin int
 const (
 	local  txOrigin = iota // json-RPC/gRPC endpoints
 	gossip                 // gossip protocol
+	reorg                  // blocks removed from the chain
 )
 
 func (o txOrigin) String() (s string) {
@@ -79,6 +88,8 @@ func (o txOrigin) String() (s string) {
 		s = "local"
 	case gossip:
 		s = "gossip"
+	case reorg:
+		s = "reorg"
 	}
 
 	return
@@ -104,6 +115,12 @@ type Config struct {
 	TxGossipBatchSize  uint64
 	ChainID            *big.Int
 	PeerID             peer.ID
+
+	// JournalPath is the file keeping the local transactions across the restarts.
+	// The journal is disabled if empty
+	JournalPath string
+	// JournalRotation is the interval of rewriting the journal with the local transactions in the pool
+	JournalRotation time.Duration
 }
 
 /* All requests are passed to the main loop
@@ -200,6 +217,13 @@ type TxPool struct {
 
 	// WG for batch flushing
 	gossipWG sync.WaitGroup
+
+	// accounts whose transactions were submitted through this node
+	locals *localAccounts
+
+	// journal of the local transactions, nil if disabled
+	journal         *journal
+	journalRotation time.Duration
 }
 
 const batchersNum = 1
@@ -225,6 +249,8 @@ func NewTxPool(
 		chainID:           config.ChainID,
 		localPeerID:       config.PeerID,
 		txGossipBatchSize: int(config.TxGossipBatchSize),
+		locals:            newLocalAccounts(maxLocalAccounts),
+		journalRotation:   config.JournalRotation,
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -236,6 +262,14 @@ func NewTxPool(
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
+	if config.JournalPath != "" {
+		pool.journal = newJournal(config.JournalPath)
+
+		if pool.journalRotation == 0 {
+			pool.journalRotation = defaultJournalRotation
+		}
+	}
+
 	if network != nil {
 		// subscribe to the gossip protocol
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
@@ -243,6 +277,8 @@ func NewTxPool(
 			return nil, err
 		}
 
+		topic.SetValidator(pool.validateGossipTx)
+
 		if subscribeErr := topic.Subscribe(pool.addGossipTx); subscribeErr != nil {
 			return nil, fmt.Errorf("unable to subscribe to gossip topic, %w", subscribeErr)
 		}
@@ -339,6 +375,13 @@ func (p *TxPool) Start() {
 	// start gossip batchers
 	p.startGossipBatchers()
 
+	// restore the local transactions from the previous run
+	if p.journal != nil {
+		p.loadJournal()
+
+		go p.journalLoop()
+	}
+
 	//	run the handler for high gauge level pruning
 	go func() {
 		for {
@@ -373,6 +416,61 @@ func (p *TxPool) Close() {
 	p.eventManager.Close()
 	close(p.shutdownCh)
 	p.stopGossipBatchers() // wait for gossip flush
+
+	if p.journal != nil {
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the journal", "err", err)
+		}
+	}
+}
+
+// loadJournal adds the journaled local transactions to the pool,
+// and rewrites the journal with the ones which are still in the pool
+func (p *TxPool) loadJournal() {
+	loaded, dropped, err := p.journal.load(p.addLocalTx)
+	if err != nil {
+		p.logger.Error("failed to load the journal", "err", err)
+	}
+
+	p.logger.Info("loaded local transactions from the journal", "loaded", loaded, "dropped", dropped)
+
+	p.rotateJournal()
+}
+
+// journalLoop periodically rewrites the journal with the local transactions in the pool,
+// so that the ones which left the pool are removed from it
+func (p *TxPool) journalLoop() {
+	ticker := time.NewTicker(p.journalRotation)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.rotateJournal()
+		}
+	}
+}
+
+func (p *TxPool) rotateJournal() {
+	txs := make([]*types.Transaction, 0)
+
+	for _, addr := range p.locals.list() {
+		if account := p.accounts.get(addr); account != nil {
+			txs = append(txs, account.pooledTxs()...)
+		}
+	}
+
+	if err := p.journal.rotate(txs); err != nil {
+		if !errors.Is(err, errJournalClosed) {
+			p.logger.Error("failed to rotate the journal", "err", err)
+		}
+
+		return
+	}
+
+	p.logger.Debug("rotated the journal", "txs", len(txs))
 }
 
 // SetSigner sets the signer the pool will use
@@ -389,12 +487,33 @@ func (p *TxPool) SetSealing(sealing bool) {
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
-	if err := p.addTx(local, tx); err != nil {
+	if err := p.addLocalTx(tx); err != nil {
 		p.logger.Error("failed to add tx", "err", err)
 
 		return err
 	}
 
+	// the transactions of the senders not tracked as local are not journaled
+	if p.journal != nil && p.locals.contains(tx.From()) {
+		if err := p.journal.insert(tx); err != nil {
+			p.logger.Error("failed to journal tx", "err", err, "hash", tx.Hash().String())
+		}
+	}
+
+	return nil
+}
+
+// addLocalTx adds the transaction submitted through this node to the pool,
+// marking its sender as local, and broadcasts it to the network (if enabled).
+func (p *TxPool) addLocalTx(tx *types.Transaction) error {
+	if err := p.addTx(local, tx); err != nil {
+		return err
+	}
+
+	if !p.addLocalAccount(tx.From()) {
+		p.logger.Debug("local accounts limit reached, sender not tracked as local", "addr", tx.From())
+	}
+
 	// broadcast the transaction only if a topic
 	// subscription is present
 	if p.topic != nil {
@@ -404,6 +523,23 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+// addLocalAccount marks the account as local. Once the limit of the local accounts is reached,
+// the accounts which no longer have transactions in the pool make room for the new one.
+// Returns true if the account is marked as local
+func (p *TxPool) addLocalAccount(addr types.Address) bool {
+	if p.locals.add(addr) {
+		return true
+	}
+
+	for _, localAddr := range p.locals.list() {
+		if account := p.accounts.get(localAddr); account == nil || len(account.pooledTxs()) == 0 {
+			p.locals.remove(localAddr)
+		}
+	}
+
+	return p.locals.add(addr)
+}
+
 // Prepare generates all the transactions
 // ready for execution. (primaries)
 func (p *TxPool) Prepare() {
@@ -622,6 +758,54 @@ func (p *TxPool) ResetWithBlock(block *types.Block) {
 	}
 }
 
+// ResetWithHeader resets the nonces of the accounts to the state of the given header,
+// once the head of the chain is set back to it. The transactions of the removed blocks are added again,
+// along with the pooled transactions of the accounts, which no longer follow their nonces
+func (p *TxPool) ResetWithHeader(header *types.Header, removed []*types.Transaction) {
+	p.SetBaseFee(header)
+
+	txs := make([]*types.Transaction, 0, len(removed))
+
+	for _, tx := range removed {
+		// the state transactions are not expected in the pool
+		if tx.Type() != types.StateTxType {
+			txs = append(txs, tx)
+		}
+	}
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, ok := key.(types.Address)
+		if !ok {
+			return false
+		}
+
+		account := p.accounts.get(addr)
+		nonce := p.store.GetNonce(header.StateRoot, addr)
+
+		if tx := account.getLowestTx(); tx != nil {
+			txs = append(txs, account.pooledTxs()...)
+			p.dropAccount(account, nonce, tx)
+		} else {
+			account.setNonce(nonce)
+		}
+
+		account.resetDemotions()
+
+		return true
+	})
+
+	// the transactions are added by nonce, so that the ones of the same account are promoted in order
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Nonce() < txs[j].Nonce()
+	})
+
+	for _, tx := range txs {
+		if err := p.addTx(reorg, tx); err != nil && p.logger.IsDebug() {
+			p.logger.Debug("failed to add tx after the head was set back", "hash", tx.Hash(), "err", err)
+		}
+	}
+}
+
 // ReinsertProposed returns all txs from the accounts proposed queue to the promoted queue
 // it is called from consensus_runtime when new round > 0 starts or when current sequence is cancelled
 func (p *TxPool) ReinsertProposed() {
@@ -960,6 +1144,13 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	var slotsIncreased uint64
 	if slotsAllocated > slotsFreed {
 		slotsIncreased = slotsAllocated - slotsFreed
+
+		// make room in the full pool by evicting the cheaper transactions,
+		// only once the transaction passed all the checks
+		if slotsIncreased > p.gauge.freeSlots() {
+			p.evictUnderpriced(tx, slotsIncreased)
+		}
+
 		if !p.gauge.increaseWithinLimit(slotsIncreased) {
 			return ErrTxPoolOverflow
 		}
@@ -1078,6 +1269,43 @@ func (p *TxPool) addGossipTx(obj interface{}, peerID peer.ID) {
 	}
 }
 
+// validateGossipTx is the validator of the gossip topic. The transactions are decoded and their
+// signatures are checked before they are relayed, so that the malformed and the improperly signed
+// transactions are rejected, and the peer which sent them is reported
+func (p *TxPool) validateGossipTx(obj interface{}) error {
+	raw, ok := obj.(*proto.Txn)
+	if !ok {
+		return errors.New("invalid type of gossip transaction message")
+	}
+
+	if raw.Raw == nil {
+		return errors.New("empty gossip transaction message")
+	}
+
+	txs := &types.Transactions{}
+	if err := txs.UnmarshalRLP(raw.Raw.Value); err != nil {
+		return fmt.Errorf("undecodable gossip transactions: %w", err)
+	}
+
+	// the signer is set once the pool is set up
+	if p.signer == nil {
+		return nil
+	}
+
+	for _, tx := range *txs {
+		from, err := p.signer.Sender(tx)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrExtractSignature, err)
+		}
+
+		if tx.From() != types.ZeroAddress && tx.From() != from {
+			return ErrInvalidSender
+		}
+	}
+
+	return nil
+}
+
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
 func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	if len(stateNonces) == 0 {
@@ -778,6 +778,29 @@ func TestAddGossipTx(t *testing.T) {
 
 		assert.Equal(t, uint64(0), pool.accounts.get(sender).enqueued.length())
 	})
+
+	t.Run("invalid gossip transactions are rejected by the topic validator", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(signer)
+
+		signedTx, err := signer.SignTx(newTx(sender, 0, 1, types.LegacyTxType), key)
+		require.NoError(t, err)
+
+		require.NoError(t, pool.validateGossipTx(getProtoTx(signedTx)))
+
+		// empty and undecodable transactions
+		require.ErrorContains(t, pool.validateGossipTx(&proto.Txn{}), "empty gossip transaction message")
+		require.ErrorContains(t, pool.validateGossipTx(&proto.Txn{Raw: &any.Any{Value: []byte{0x1}}}),
+			"undecodable gossip transactions")
+
+		// transaction without signature
+		require.ErrorIs(t,
+			pool.validateGossipTx(getProtoTx(newTx(types.ZeroAddress, 1, 1, types.LegacyTxType))),
+			ErrExtractSignature)
+	})
 }
 
 func TestDropKnownGossipTx(t *testing.T) {
@@ -4116,6 +4139,65 @@ func TestResetWithBlockSetsBaseFee(t *testing.T) {
 	require.Equal(t, blocks[len(blocks)-2].Header.BaseFee, pool.GetBaseFee())
 }
 
+func TestResetWithHeader(t *testing.T) {
+	t.Parallel()
+
+	store := NewDefaultMockStore(mockHeader)
+	store.nonce = 1
+
+	pool, err := newTestPool(store)
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// promoted txs of the first account
+	for nonce := uint64(1); nonce < 4; nonce++ {
+		require.NoError(t, pool.addTx(local, newTx(addr1, nonce, 1, types.LegacyTxType)))
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	// enqueued tx of the second account
+	require.NoError(t, pool.addTx(local, newTx(addr2, 3, 1, types.LegacyTxType)))
+
+	// account without txs
+	pool.getOrCreateAccount(addr3)
+
+	require.Equal(t, uint64(4), pool.gauge.read())
+	require.Equal(t, uint64(4), pool.accounts.get(addr1).getNonce())
+
+	// the txs of the removed blocks, the state tx is not added to the pool
+	removed := []*types.Transaction{
+		newTx(addr2, 2, 1, types.LegacyTxType),
+		newTx(addr2, 1, 1, types.LegacyTxType),
+		newTx(addr3, 1, 1, types.StateTxType),
+	}
+
+	header := &types.Header{Number: 1, BaseFee: defaultPriceLimit}
+	pool.ResetWithHeader(header, removed)
+
+	require.Equal(t, header.BaseFee, pool.GetBaseFee())
+
+	// the pooled and the removed txs follow the nonces of the header again
+	require.Equal(t, uint64(6), pool.gauge.read())
+
+	for i := 0; i < 2; i++ {
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	require.Equal(t, uint64(6), pool.Length())
+
+	for _, addr := range []types.Address{addr1, addr2} {
+		acc := pool.accounts.get(addr)
+
+		require.Equal(t, uint64(4), acc.getNonce())
+		require.Equal(t, uint64(3), acc.promoted.length())
+		require.Equal(t, uint64(0), acc.enqueued.length())
+	}
+
+	acc := pool.accounts.get(addr3)
+	require.Equal(t, uint64(1), acc.getNonce())
+	require.Nil(t, acc.getLowestTx())
+}
+
 func TestAddTx_TxReplacement(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,53 @@
+package types
+
+import "math/big"
+
+// ValidatorInfo is the public identity of a validator, along with its voting power
+type ValidatorInfo struct {
+	Address     Address
+	BlsKey      []byte
+	VotingPower *big.Int
+	IsActive    bool
+}
+
+// EpochInfo describes the epoch a block belongs to, along with the sprint of the block within the epoch
+type EpochInfo struct {
+	Number           uint64
+	FirstBlock       uint64
+	LastBlock        uint64
+	SprintSize       uint64
+	SprintFirstBlock uint64
+	SprintLastBlock  uint64
+}
+
+// BlockSigners are the validators which committed a block
+type BlockSigners struct {
+	Signers           []Address
+	SignedVotingPower *big.Int
+	TotalVotingPower  *big.Int
+}
+
+// ValidatorUptime is the participation of a validator in the blocks of an epoch
+type ValidatorUptime struct {
+	Address Address
+	// SignedBlocks is the number of blocks whose committed seal includes the validator
+	SignedBlocks uint64
+	// MissedSeals is the number of blocks whose committed seal lacks the validator
+	MissedSeals uint64
+	// ProposedBlocks is the number of blocks proposed by the validator
+	ProposedBlocks uint64
+	// SkippedRounds is the number of rounds the validator was the proposer of, which didn't produce a block
+	SkippedRounds uint64
+}
+
+// DoubleSignEvidence proves that a validator signed two conflicting consensus messages
+// of the same type, at the same height and round. The messages are kept encoded along with their signatures,
+// so the evidence can be verified by anyone
+type DoubleSignEvidence struct {
+	Height        uint64
+	Round         uint64
+	MessageType   string
+	Signer        Address
+	FirstMessage  []byte
+	SecondMessage []byte
+}